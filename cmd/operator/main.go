@@ -63,6 +63,8 @@ func init() {
 		defaults.OperatorPort, "Port to listen on.")
 	rootFlags.IntVar(&option.OperatorConfig.WorkersCount, "workers",
 		defaults.WorkersCount, "Number of workers to run for the controller.")
+	rootFlags.StringVar(&option.OperatorConfig.ClusterDomain, "cluster-domain",
+		defaults.ClusterDomain, "DNS domain of the kubernetes cluster.")
 
 	// Convinces glog that Parse() has been called to avoid noisy logs.
 	// https://github.com/kubernetes/kubernetes/issues/17162#issuecomment-225596212
@@ -3,7 +3,6 @@
 set -euo pipefail
 
 CRD_DIR=./contrib/crd/
-CRDFILE_NAME=dgraph.io_dgraphclusters.yaml
 
 TMP_DIR=`mktemp -d`
 
@@ -11,11 +10,14 @@ trap 'rm -rf $TMP_DIR' EXIT INT TERM
 
 CRDGEN_DIR=$TMP_DIR make crdgen
 
-if ! $(diff ${CRD_DIR}/${CRDFILE_NAME} ${TMP_DIR}/${CRDFILE_NAME}); then
-  echo "Detected a difference in CRD definition"
-  echo "diff: `diff ${CRD_DIR}/${CRDFILE_NAME} ${TMP_DIR}/${CRDFILE_NAME}`"
-  echo "Please rerun 'make crdgen' and commit your changes"
-  exit 1
-fi
+for CRDFILE in ${TMP_DIR}/*.yaml; do
+  CRDFILE_NAME=`basename ${CRDFILE}`
+  if ! $(diff ${CRD_DIR}/${CRDFILE_NAME} ${TMP_DIR}/${CRDFILE_NAME}); then
+    echo "Detected a difference in CRD definition ${CRDFILE_NAME}"
+    echo "diff: `diff ${CRD_DIR}/${CRDFILE_NAME} ${TMP_DIR}/${CRDFILE_NAME}`"
+    echo "Please rerun 'make crdgen' and commit your changes"
+    exit 1
+  fi
+done
 
 echo "[*] CRD definition is up to date."
@@ -46,6 +46,19 @@ const (
 
 	// DgraphClusterKindDefinition is Kind name of the custom resource definition.
 	DgraphClusterKindDefinition = "DgraphCluster"
+
+	// DgraphLiveLoadKindDefinition is Kind name of the live load custom resource definition.
+	DgraphLiveLoadKindDefinition = "DgraphLiveLoad"
+
+	// DgraphSchemaKindDefinition is Kind name of the schema custom resource definition.
+	DgraphSchemaKindDefinition = "DgraphSchema"
+
+	// DgraphGraphQLSchemaKindDefinition is Kind name of the GraphQL schema custom resource
+	// definition.
+	DgraphGraphQLSchemaKindDefinition = "DgraphGraphQLSchema"
+
+	// DgraphSnapshotKindDefinition is Kind name of the snapshot custom resource definition.
+	DgraphSnapshotKindDefinition = "DgraphSnapshot"
 )
 
 var (
@@ -90,6 +103,15 @@ func Resource(resource string) schema.GroupResource {
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&DgraphCluster{},
+		&DgraphClusterList{},
+		&DgraphLiveLoad{},
+		&DgraphLiveLoadList{},
+		&DgraphSchema{},
+		&DgraphSchemaList{},
+		&DgraphGraphQLSchema{},
+		&DgraphGraphQLSchemaList{},
+		&DgraphSnapshot{},
+		&DgraphSnapshotList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
@@ -103,6 +125,22 @@ func CreateCustomResourceDefinitions(clientset apiextclient.Interface) error {
 		return err
 	}
 
+	if err := createDgraphLiveLoadCRD(clientset); err != nil {
+		return err
+	}
+
+	if err := createDgraphSchemaCRD(clientset); err != nil {
+		return err
+	}
+
+	if err := createDgraphGraphQLSchemaCRD(clientset); err != nil {
+		return err
+	}
+
+	if err := createDgraphSnapshotCRD(clientset); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -123,10 +161,148 @@ var (
 // createDgraphClusterCRD creates a new Custom resource definition for kubernetes for type
 // DgraphCluster.
 func createDgraphClusterCRD(clientset apiextclient.Interface) error {
+	// DgraphCluster resource is namespace scoped, user can specify the namespace
+	// to create the cluster in.
+	res := newNamespacedCRD(DgraphClusterCRDName, apiextv1.CustomResourceDefinitionNames{
+		Plural:     DgraphClusterCRDPluralName,
+		Singular:   DgraphClusterCRDSingularName,
+		ShortNames: DgraphClusterCRDShortNames,
+		Kind:       DgraphClusterKindDefinition,
+	}, dgraphClusterCRV)
+
+	return createUpdateCRD(clientset, "DgraphCluster/v1alpha1", res)
+}
+
+var (
+	// DgraphLiveLoadCRDSingularName is the singular name of live load custom resource definition
+	DgraphLiveLoadCRDSingularName = "dgraphliveload"
+
+	// DgraphLiveLoadCRDPluralName is the plural name of live load custom resource definition
+	DgraphLiveLoadCRDPluralName = "dgraphliveloads"
+
+	// DgraphLiveLoadCRDShortNames are the abbreviated names to refer to this CRD's instances
+	DgraphLiveLoadCRDShortNames = []string{"dll"}
+
+	// DgraphLiveLoadCRDName is k8s represented name of the live load custom resource definition.
+	DgraphLiveLoadCRDName string = DgraphLiveLoadCRDPluralName + "." + SchemeGroupVersion.Group
+)
+
+// createDgraphLiveLoadCRD creates a new Custom resource definition for kubernetes for type
+// DgraphLiveLoad.
+func createDgraphLiveLoadCRD(clientset apiextclient.Interface) error {
+	// DgraphLiveLoad resource must be in the same namespace as the DgraphCluster
+	// it loads the data into.
+	res := newNamespacedCRD(DgraphLiveLoadCRDName, apiextv1.CustomResourceDefinitionNames{
+		Plural:     DgraphLiveLoadCRDPluralName,
+		Singular:   DgraphLiveLoadCRDSingularName,
+		ShortNames: DgraphLiveLoadCRDShortNames,
+		Kind:       DgraphLiveLoadKindDefinition,
+	}, dgraphLiveLoadCRV)
+
+	return createUpdateCRD(clientset, "DgraphLiveLoad/v1alpha1", res)
+}
+
+var (
+	// DgraphSchemaCRDSingularName is the singular name of schema custom resource definition
+	DgraphSchemaCRDSingularName = "dgraphschema"
 
-	res := &apiextv1.CustomResourceDefinition{
+	// DgraphSchemaCRDPluralName is the plural name of schema custom resource definition
+	DgraphSchemaCRDPluralName = "dgraphschemas"
+
+	// DgraphSchemaCRDShortNames are the abbreviated names to refer to this CRD's instances
+	DgraphSchemaCRDShortNames = []string{"dsch"}
+
+	// DgraphSchemaCRDName is k8s represented name of the schema custom resource definition.
+	DgraphSchemaCRDName string = DgraphSchemaCRDPluralName + "." + SchemeGroupVersion.Group
+)
+
+// createDgraphSchemaCRD creates a new Custom resource definition for kubernetes for type
+// DgraphSchema.
+func createDgraphSchemaCRD(clientset apiextclient.Interface) error {
+	// DgraphSchema resource must be in the same namespace as the DgraphCluster
+	// it applies the schema to.
+	res := newNamespacedCRD(DgraphSchemaCRDName, apiextv1.CustomResourceDefinitionNames{
+		Plural:     DgraphSchemaCRDPluralName,
+		Singular:   DgraphSchemaCRDSingularName,
+		ShortNames: DgraphSchemaCRDShortNames,
+		Kind:       DgraphSchemaKindDefinition,
+	}, dgraphSchemaCRV)
+
+	return createUpdateCRD(clientset, "DgraphSchema/v1alpha1", res)
+}
+
+var (
+	// DgraphGraphQLSchemaCRDSingularName is the singular name of GraphQL schema custom
+	// resource definition
+	DgraphGraphQLSchemaCRDSingularName = "dgraphgraphqlschema"
+
+	// DgraphGraphQLSchemaCRDPluralName is the plural name of GraphQL schema custom resource
+	// definition
+	DgraphGraphQLSchemaCRDPluralName = "dgraphgraphqlschemas"
+
+	// DgraphGraphQLSchemaCRDShortNames are the abbreviated names to refer to this CRD's
+	// instances
+	DgraphGraphQLSchemaCRDShortNames = []string{"dgql"}
+
+	// DgraphGraphQLSchemaCRDName is k8s represented name of the GraphQL schema custom
+	// resource definition.
+	DgraphGraphQLSchemaCRDName string = DgraphGraphQLSchemaCRDPluralName + "." +
+		SchemeGroupVersion.Group
+)
+
+// createDgraphGraphQLSchemaCRD creates a new Custom resource definition for kubernetes for
+// type DgraphGraphQLSchema.
+func createDgraphGraphQLSchemaCRD(clientset apiextclient.Interface) error {
+	// DgraphGraphQLSchema resource must be in the same namespace as the DgraphCluster
+	// it applies the schema to.
+	res := newNamespacedCRD(DgraphGraphQLSchemaCRDName, apiextv1.CustomResourceDefinitionNames{
+		Plural:     DgraphGraphQLSchemaCRDPluralName,
+		Singular:   DgraphGraphQLSchemaCRDSingularName,
+		ShortNames: DgraphGraphQLSchemaCRDShortNames,
+		Kind:       DgraphGraphQLSchemaKindDefinition,
+	}, dgraphGraphQLSchemaCRV)
+
+	return createUpdateCRD(clientset, "DgraphGraphQLSchema/v1alpha1", res)
+}
+
+var (
+	// DgraphSnapshotCRDSingularName is the singular name of snapshot custom resource
+	// definition
+	DgraphSnapshotCRDSingularName = "dgraphsnapshot"
+
+	// DgraphSnapshotCRDPluralName is the plural name of snapshot custom resource definition
+	DgraphSnapshotCRDPluralName = "dgraphsnapshots"
+
+	// DgraphSnapshotCRDShortNames are the abbreviated names to refer to this CRD's instances
+	DgraphSnapshotCRDShortNames = []string{"dsnap"}
+
+	// DgraphSnapshotCRDName is k8s represented name of the snapshot custom resource
+	// definition.
+	DgraphSnapshotCRDName string = DgraphSnapshotCRDPluralName + "." + SchemeGroupVersion.Group
+)
+
+// createDgraphSnapshotCRD creates a new Custom resource definition for kubernetes for
+// type DgraphSnapshot.
+func createDgraphSnapshotCRD(clientset apiextclient.Interface) error {
+	// DgraphSnapshot resource must be in the same namespace as the DgraphCluster it takes
+	// the snapshot of, volume snapshots are namespaced along with the claims.
+	res := newNamespacedCRD(DgraphSnapshotCRDName, apiextv1.CustomResourceDefinitionNames{
+		Plural:     DgraphSnapshotCRDPluralName,
+		Singular:   DgraphSnapshotCRDSingularName,
+		ShortNames: DgraphSnapshotCRDShortNames,
+		Kind:       DgraphSnapshotKindDefinition,
+	}, dgraphSnapshotCRV)
+
+	return createUpdateCRD(clientset, "DgraphSnapshot/v1alpha1", res)
+}
+
+// newNamespacedCRD returns a namespace scoped custom resource definition with status
+// subresource for the current version of the dgraph.io group.
+func newNamespacedCRD(name string, names apiextv1.CustomResourceDefinitionNames,
+	validation *apiextv1.CustomResourceValidation) *apiextv1.CustomResourceDefinition {
+	return &apiextv1.CustomResourceDefinition{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: DgraphClusterCRDName,
+			Name: name,
 			Labels: map[string]string{
 				CustomResourceDefinitionSchemaVersionKey: CustomResourceDefinitionSchemaVersion,
 			},
@@ -141,23 +317,13 @@ func createDgraphClusterCRD(clientset apiextclient.Interface) error {
 						Status: &apiextv1.CustomResourceSubresourceStatus{},
 					},
 					Storage: true,
-					Schema:  dgraphClusterCRV,
+					Schema:  validation,
 				},
 			},
-			Names: apiextv1.CustomResourceDefinitionNames{
-				Plural:     DgraphClusterCRDPluralName,
-				Singular:   DgraphClusterCRDSingularName,
-				ShortNames: DgraphClusterCRDShortNames,
-				Kind:       DgraphClusterKindDefinition,
-			},
-
-			// DgraphCluster resource is namespace scoped, user can specify the namespace
-			// to create the cluster in.
+			Names: names,
 			Scope: apiextv1.NamespaceScoped,
 		},
 	}
-
-	return createUpdateCRD(clientset, "DgraphCluster/v1alpha1", res)
 }
 
 // createUpdateCRD ensures the CRD object is created in the k8s cluster. It
@@ -18,12 +18,18 @@ package v1alpha1
 
 import (
 	"fmt"
+	"path"
+	"sort"
+	"time"
 
 	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
 	k8sutils "github.com/dgraph-io/dgraph-operator/pkg/k8s/utils"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // ClusterState represents the state of the cluster.
@@ -93,6 +99,18 @@ func (dc *DgraphCluster) internalComponentOverride(dcs *DgraphComponentSpec) {
 	if dcs.ImagePullPolicy == nil {
 		dcs.ImagePullPolicy = dc.Spec.ImagePullPolicy
 	}
+
+	if dcs.Tracing == nil {
+		dcs.Tracing = dc.Spec.Tracing.DeepCopy()
+	}
+
+	dcs.Annotations = k8sutils.MergeStringMaps(dc.Spec.Annotations, dcs.Annotations)
+	dcs.PodAnnotations = k8sutils.MergeStringMaps(dc.Spec.PodAnnotations, dcs.PodAnnotations)
+	dcs.PodLabels = k8sutils.MergeStringMaps(dc.Spec.PodLabels, dcs.PodLabels)
+	dcs.ServiceAnnotations = k8sutils.MergeStringMaps(dc.Spec.ServiceAnnotations,
+		dcs.ServiceAnnotations)
+	dcs.ServiceLabels = k8sutils.MergeStringMaps(dc.Spec.ServiceLabels, dcs.ServiceLabels)
+	dcs.PVCLabels = k8sutils.MergeStringMaps(dc.Spec.PVCLabels, dcs.PVCLabels)
 }
 
 // ZeroClusterSpec returns cluster specification for dgraph zero component
@@ -181,8 +199,60 @@ type DgraphClusterSpec struct {
 	// rather merged with the underlying specified annotations.
 	Annotations map[string]string `json:"annotations,omitempty"`
 
+	// Below labels and annotations are merged the same way as Annotations, the
+	// component configuration takes precedence for the keys specified in both.
+
+	// PodAnnotations are the annotations of the pods of the components.
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// PodLabels are the labels of the pods of the components.
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// ServiceAnnotations are the annotations of the services of the components.
+	ServiceAnnotations map[string]string `json:"serviceAnnotations,omitempty"`
+
+	// ServiceLabels are the labels of the services of the components.
+	ServiceLabels map[string]string `json:"serviceLabels,omitempty"`
+
+	// PVCLabels are the labels of the persistent volume claims of the components.
+	PVCLabels map[string]string `json:"pvcLabels,omitempty"`
+
 	// Resource requirements of the components, this can be overridden at component level.
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ClusterDomain is the DNS domain of the kubernetes cluster used to address the
+	// members of the dgraph cluster. Defaults to the cluster domain of the operator.
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+
+	// Bootstrap is the configuration to populate the cluster with initial data
+	// when it is created.
+	Bootstrap *BootstrapSpec `json:"bootstrap,omitempty"`
+
+	// RestoreFrom is the configuration to restore the persistent volume claims of the
+	// members from the volume snapshots of a DgraphSnapshot when the cluster is created.
+	// It's ignored once the cluster has been created.
+	RestoreFrom *RestoreSpec `json:"restoreFrom,omitempty"`
+
+	// Tablets is the configuration of the placement of predicate tablets across
+	// alpha groups.
+	Tablets *TabletsSpec `json:"tablets,omitempty"`
+
+	// Tracing is the configuration of the tracing of dgraph alpha and zero, this can be
+	// overridden at component level.
+	Tracing *TracingSpec `json:"tracing,omitempty"`
+
+	// PVCRetentionPolicy is the policy for the persistent volume claims of the alpha and
+	// zero members, which kubernetes never deletes on its own.
+	PVCRetentionPolicy *PVCRetentionPolicy `json:"pvcRetentionPolicy,omitempty"`
+
+	// Paused hibernates the cluster: the alpha members are shut down and all the
+	// components are scaled down to zero, keeping the persistent volume claims of the
+	// members. The cluster is resumed once it's unset.
+	Paused bool `json:"paused,omitempty"`
+
+	// HibernationSchedule hibernates the cluster automatically outside of the times of
+	// the schedule.
+	HibernationSchedule *HibernationScheduleSpec `json:"hibernationSchedule,omitempty"`
 }
 
 // AlphaServiceType returns the kubernetes service type to use for Alpha Cluster
@@ -217,6 +287,28 @@ func (dc *DgraphClusterSpec) GetClusterID() string {
 	return dc.ClusterID
 }
 
+// AlphaGroupSize returns the number of alpha members in each dgraph group, when
+// alpha groups are not specified.
+func (dc *DgraphClusterSpec) AlphaGroupSize() int32 {
+	if size := dc.ZeroCluster.ShardReplicaCount(); size > 0 {
+		return size
+	}
+	return 1
+}
+
+// AlphaGroupCount returns the number of dgraph groups the alpha members are
+// distributed into. If alpha groups are not specified dgraph zero assigns alpha
+// members to groups in the order they join, so member with ordinal i ends up in
+// group i/AlphaGroupSize()+1.
+func (dc *DgraphClusterSpec) AlphaGroupCount() int32 {
+	if len(dc.AlphaCluster.Groups) > 0 {
+		return int32(len(dc.AlphaCluster.Groups))
+	}
+
+	size := dc.AlphaGroupSize()
+	return (dc.AlphaCluster.Replicas + size - 1) / size
+}
+
 // DgraphClusterStatus represents the status of a DgraphCluster.
 type DgraphClusterStatus struct {
 	// ClusterID is the ID of the dgraph cluster deployed.
@@ -228,188 +320,2183 @@ type DgraphClusterStatus struct {
 	AlphaCluster AlphaClusterStatus `json:"alpha,omitempty"`
 	ZeroCluster  ZeroClusterStatus  `json:"zero,omitempty"`
 	Ratel        RatelStatus        `json:"ratel,omitempty"`
+
+	// Bootstrap is the status of the initial data load of the cluster.
+	Bootstrap *BootstrapStatus `json:"bootstrap,omitempty"`
+
+	// Tablets is the status of the predicate tablets of the cluster.
+	Tablets *TabletsStatus `json:"tablets,omitempty"`
+
+	// VolumeUsageTime is the time the usage of the volumes of the members was last
+	// collected.
+	VolumeUsageTime *metav1.Time `json:"volumeUsageTime,omitempty"`
+
+	// Hibernation is the status of the hibernation of the cluster, nil while it runs.
+	Hibernation *HibernationStatus `json:"hibernation,omitempty"`
 }
 
 // +k8s:openapi-gen=true
-// AlphaClusterSpec is the specification of the dgraph alpha cluster.
-type AlphaClusterSpec struct {
-	DgraphComponentSpec `json:",inline"`
+// HibernationScheduleSpec is a weekly schedule of the times a dgraph cluster runs, it's
+// hibernated the rest of the time.
+type HibernationScheduleSpec struct {
+	// Start is the time of the day the cluster is resumed, in the HH:MM format.
+	Start string `json:"start"`
+
+	// Stop is the time of the day the cluster is hibernated, in the HH:MM format. The
+	// cluster runs overnight if Stop is before Start.
+	Stop string `json:"stop"`
+
+	// Days of the week the cluster is resumed on, as three letter abbreviations such as
+	// Mon. Defaults to every day.
+	Days []string `json:"days,omitempty"`
+
+	// TimeZone is the name of the time zone of the schedule in the IANA time zone
+	// database. Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+}
 
-	// Storage is the configuration for persistent storage for dgraph component.
-	PersistentStorage *ComponentPersistentStorage `json:"persistentStorage,omitempty"`
+// hibernationTimeLayout is the layout of the times of day of hibernation schedules.
+const hibernationTimeLayout = "15:04"
 
-	// Number of replicas to run in the cluster.
-	Replicas int32 `json:"replicas"`
+// Validate returns an error if the hibernation schedule is invalid.
+func (hss *HibernationScheduleSpec) Validate() error {
+	_, err := hss.Running(time.Now())
+	return err
+}
 
-	// Config is the configuration of the dgraph component.
-	Config *AlphaConfig `json:"config,omitempty"`
+// Running returns true if the schedule runs the cluster at the provided time.
+func (hss *HibernationScheduleSpec) Running(now time.Time) (bool, error) {
+	start, err := time.Parse(hibernationTimeLayout, hss.Start)
+	if err != nil {
+		return false, fmt.Errorf("invalid hibernation schedule start %q", hss.Start)
+	}
+	stop, err := time.Parse(hibernationTimeLayout, hss.Stop)
+	if err != nil {
+		return false, fmt.Errorf("invalid hibernation schedule stop %q", hss.Stop)
+	}
+	if start.Equal(stop) {
+		return false, fmt.Errorf("hibernation schedule start and stop must differ")
+	}
+	loc, err := time.LoadLocation(hss.TimeZone)
+	if err != nil {
+		return false, fmt.Errorf("invalid hibernation schedule time zone %q: %s",
+			hss.TimeZone, err)
+	}
+
+	days := make(map[time.Weekday]bool, len(hss.Days))
+	for _, day := range hss.Days {
+		weekday, ok := parseWeekday(day)
+		if !ok {
+			return false, fmt.Errorf("invalid hibernation schedule day %q", day)
+		}
+		days[weekday] = true
+	}
+	resumedOn := func(day time.Weekday) bool {
+		return len(days) == 0 || days[day]
+	}
+
+	now = now.In(loc)
+	minute := now.Hour()*60 + now.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	stopMinute := stop.Hour()*60 + stop.Minute()
+	today := now.Weekday()
+	if startMinute < stopMinute {
+		return resumedOn(today) && minute >= startMinute && minute < stopMinute, nil
+	}
+
+	// The cluster runs overnight, from the start on the days it's resumed on to the stop
+	// on the next day.
+	yesterday := (today + 6) % 7
+	return (resumedOn(today) && minute >= startMinute) ||
+		(resumedOn(yesterday) && minute < stopMinute), nil
 }
 
-// LruMB returns the LRU MB configuration for dgraph alpha.
-func (acs *AlphaClusterSpec) LruMB() int32 {
-	if acs.Config == nil {
-		return defaults.LruMBValue
+// parseWeekday returns the day of the week with the provided three letter abbreviation.
+func parseWeekday(day string) (time.Weekday, bool) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if weekday.String()[:3] == day {
+			return weekday, true
+		}
 	}
-	return acs.Config.LruMB
+
+	return time.Sunday, false
 }
 
-// AlphaClusterStatus represents the cluster status of dgraph alpha components.
-type AlphaClusterStatus struct {
-	// StatefulSet is the status of stateful set associated with the specified
-	// alpha cluster.
-	StatefulSet *apps.StatefulSetStatus `json:"statefulSet,omitempty"`
+// HibernationRequested returns true if the cluster must be hibernated at the provided
+// time, because it's paused or outside of the times of its hibernation schedule.
+func (dc *DgraphCluster) HibernationRequested(now time.Time) (bool, error) {
+	if dc.Spec.Paused {
+		return true, nil
+	}
+	if dc.Spec.HibernationSchedule == nil {
+		return false, nil
+	}
 
-	// Members is the map of members in the alpha cluster.
-	Members map[string]DgraphComponent `json:"members,omitempty"`
+	running, err := dc.Spec.HibernationSchedule.Running(now)
+	return !running, err
 }
 
-// +k8s:openapi-gen=true
-// ZeroClusterSpec is the specification of the dgraph alpha cluster.
-type ZeroClusterSpec struct {
-	DgraphComponentSpec `json:",inline"`
+// HibernationPhase represents the phase of the hibernation of a dgraph cluster.
+type HibernationPhase string
 
-	// PersistentStorage is the configuration for persistent storage for dgraph component.
-	PersistentStorage *ComponentPersistentStorage `json:"persistentStorage,omitempty"`
+var (
+	// HibernationPhaseStopping represents that the alpha members have been shut down and
+	// are being scaled down to zero, while zero and ratel keep running.
+	HibernationPhaseStopping HibernationPhase = "stopping"
 
-	// Number of replicas to run in the cluster.
-	Replicas int32 `json:"replicas"`
+	// HibernationPhaseHibernated represents that all the components are scaled down to
+	// zero.
+	HibernationPhaseHibernated HibernationPhase = "hibernated"
 
-	// Config is the configuration of the dgraph zero.
-	Config *ZeroConfig `json:"config,omitempty"`
+	// HibernationPhaseResuming represents that zero is scaled back up, alpha and ratel
+	// are resumed once a quorum of zero members is ready.
+	HibernationPhaseResuming HibernationPhase = "resuming"
+
+	// HibernationPhaseStarting represents that zero is ready and alpha and ratel are
+	// scaled back up, the hibernation completes once all their members are ready.
+	HibernationPhaseStarting HibernationPhase = "starting"
+)
+
+// HibernationStatus represents the status of the hibernation of a dgraph cluster.
+type HibernationStatus struct {
+	// Phase is the current phase of the hibernation.
+	Phase HibernationPhase `json:"phase"`
+
+	// HibernationTime is the time the cluster started hibernating.
+	HibernationTime *metav1.Time `json:"hibernationTime,omitempty"`
+
+	// ZeroReplicas is the number of zero members when the cluster started hibernating.
+	ZeroReplicas int32 `json:"zeroReplicas"`
+
+	// AlphaReplicas is the number of alpha members of each alpha stateful set when the
+	// cluster started hibernating, by stateful set name.
+	AlphaReplicas map[string]int32 `json:"alphaReplicas,omitempty"`
+
+	// RatelReplicas is the number of ratel replicas when the cluster started hibernating.
+	RatelReplicas int32 `json:"ratelReplicas,omitempty"`
+
+	// Message is a human readable message about the current phase.
+	Message string `json:"message,omitempty"`
 }
 
-// ShardReplicaCount returns the zero replica count to be used for deployment
-// of the dgraph component.
-func (zcs *ZeroClusterSpec) ShardReplicaCount() int32 {
-	if zcs.Config == nil {
-		return zcs.Replicas
+// AlphaHibernated returns true if the alpha members must be scaled down to zero because
+// the cluster is hibernating, or resuming and waiting for zero.
+func (dc *DgraphCluster) AlphaHibernated() bool {
+	return dc.Status.Hibernation != nil &&
+		dc.Status.Hibernation.Phase != HibernationPhaseStarting
+}
+
+// ZeroHibernated returns true if the zero members must be scaled down to zero because
+// the alpha members of the hibernating cluster have been stopped.
+func (dc *DgraphCluster) ZeroHibernated() bool {
+	return dc.Status.Hibernation != nil &&
+		dc.Status.Hibernation.Phase == HibernationPhaseHibernated
+}
+
+// RatelHibernated returns true if ratel must be scaled down to zero because the cluster
+// is hibernated, or resuming and waiting for zero.
+func (dc *DgraphCluster) RatelHibernated() bool {
+	return dc.Status.Hibernation != nil &&
+		dc.Status.Hibernation.Phase != HibernationPhaseStopping &&
+		dc.Status.Hibernation.Phase != HibernationPhaseStarting
+}
+
+// ZeroReplicas returns the number of zero members, which is the number recorded when the
+// cluster started hibernating until the cluster has resumed.
+func (dc *DgraphCluster) ZeroReplicas() int32 {
+	if status := dc.Status.Hibernation; status != nil && status.ZeroReplicas > 0 {
+		return status.ZeroReplicas
 	}
-	return zcs.Config.ShardReplicaCount
+
+	return dc.Spec.ZeroCluster.Replicas
 }
 
-// ZeroClusterStatus represents the cluster status of dgraph alpha components.
-type ZeroClusterStatus struct {
-	// StatefulSet is the status of stateful set associated with the specified
-	// zero cluster.
-	StatefulSet *apps.StatefulSetStatus `json:"statefulSet,omitempty"`
+// HibernatedAlphaReplicas returns the number of members the alpha stateful set with the
+// provided name had when the cluster started hibernating, which it's resumed with. It
+// returns false if the cluster isn't hibernating or the stateful set didn't exist then.
+func (dc *DgraphCluster) HibernatedAlphaReplicas(name string) (int32, bool) {
+	if dc.Status.Hibernation == nil {
+		return 0, false
+	}
+	replicas, ok := dc.Status.Hibernation.AlphaReplicas[name]
 
-	// Members is the map of members in the zero cluster.
-	Members map[string]DgraphComponent `json:"members,omitempty"`
+	return replicas, ok
 }
 
-// +k8s:openapi-gen=true
-// RatelSpec holds the configuration of dgraph ratel components.
-type RatelSpec struct {
-	DgraphComponentSpec `json:",inline"`
+// RatelReplicas returns the number of ratel replicas, which is the number recorded when the
+// cluster started hibernating until the cluster has resumed.
+func (dc *DgraphCluster) RatelReplicas() int32 {
+	if status := dc.Status.Hibernation; status != nil && status.RatelReplicas > 0 {
+		return status.RatelReplicas
+	}
 
-	// Number of replicas of ratel to run in the cluster.
-	Replicas int32 `json:"replicas"`
+	return dc.Spec.Ratel.Replicas
 }
 
-// RatelStatus holds the status of dgraph ratel component.
-type RatelStatus struct {
-	// Deployment is the status of stateful set associated with the specified
-	// ratel cluster.
-	Deployment *apps.DeploymentStatus `json:"deployment,omitempty"`
+// BootstrapPhase represents the phase of bootstrapping a dgraph cluster.
+type BootstrapPhase string
 
-	// Members is the map of members in the zero cluster.
-	Members map[string]DgraphComponent `json:"members,omitempty"`
+var (
+	// BootstrapPhasePending represents that the bootstrap is waiting for dgraph
+	// zero to be ready.
+	BootstrapPhasePending BootstrapPhase = "pending"
+
+	// BootstrapPhaseBulkLoading represents that the bulk loader job is running.
+	BootstrapPhaseBulkLoading BootstrapPhase = "bulkLoading"
+
+	// BootstrapPhaseCopying represents that the bulk loader output is being copied
+	// into the alpha persistent volumes.
+	BootstrapPhaseCopying BootstrapPhase = "copying"
+
+	// BootstrapPhaseCompleted represents that the bootstrap is complete and alpha
+	// can be scaled up.
+	BootstrapPhaseCompleted BootstrapPhase = "completed"
+
+	// BootstrapPhaseFailed represents that the bootstrap failed and requires
+	// manual intervention.
+	BootstrapPhaseFailed BootstrapPhase = "failed"
+)
+
+// +k8s:openapi-gen=true
+// BootstrapSpec is the configuration to populate a new dgraph cluster with data.
+type BootstrapSpec struct {
+	// BulkLoad is the configuration to load data using dgraph bulk loader before
+	// the alpha cluster is started.
+	BulkLoad *BulkLoadSpec `json:"bulkLoad,omitempty"`
 }
 
 // +k8s:openapi-gen=true
-// DgraphComponentSpec is the common configuration values shared among different
-// dgraph components.
-type DgraphComponentSpec struct {
-	// Resource requirements of the components.
+// BulkLoadSpec is the configuration of dgraph bulk loader for the cluster.
+type BulkLoadSpec struct {
+	// Source is the location of the data and schema files.
+	Source DataSource `json:"source"`
+
+	// DataFiles is the path of RDF/JSON data file or directory relative to the source.
+	DataFiles string `json:"dataFiles"`
+
+	// SchemaFile is the path of the DQL schema file relative to the source.
+	SchemaFile string `json:"schemaFile"`
+
+	// Format of the data files, one of rdf or json. If empty it is detected
+	// by the bulk loader from the file extension.
+	Format string `json:"format,omitempty"`
+
+	// MapShards is the number of map shards to use for the bulk loader. Defaults
+	// to the number of alpha groups, it must not be less than that.
+	MapShards int32 `json:"mapShards,omitempty"`
+
+	// PersistentStorage is the configuration of the scratch volume used for the
+	// bulk loader output.
+	PersistentStorage *ComponentPersistentStorage `json:"persistentStorage"`
+
+	// Resource requirements of the bulk loader job.
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
 
-	// Base image of the component
-	BaseImage string `json:"baseImage,omitempty"`
+// MapShardCount returns the number of map shards to use for the bulk loader
+// producing reduceShards output shards.
+func (bls *BulkLoadSpec) MapShardCount(reduceShards int32) int32 {
+	if bls.MapShards < reduceShards {
+		return reduceShards
+	}
+	return bls.MapShards
+}
 
-	// ServiceType is type of service to create for the component.
-	// One of NodePort, ClusterIP, LoadBalancer. Defaults to ClusterIP.
-	ServiceType string `json:"serviceType,omitempty"`
+// +k8s:openapi-gen=true
+// DataSource is the location of files to load into a dgraph cluster. Exactly
+// one of the sources must be specified.
+type DataSource struct {
+	// ConfigMap is an existing config map in the namespace of the cluster whose
+	// keys are the files. Suitable for small data sets like test fixtures.
+	ConfigMap *corev1.ConfigMapVolumeSource `json:"configMap,omitempty"`
+
+	// PersistentVolumeClaim is an existing claim in the namespace of the cluster
+	// containing the files.
+	PersistentVolumeClaim *corev1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"` // nolint
+
+	// ObjectStore is the object storage location containing the files.
+	ObjectStore *ObjectStoreSource `json:"objectStore,omitempty"`
+}
 
-	// Version of the component. Override the cluster-level version if non-empty
-	Version string `json:"version,omitempty"`
+// +k8s:openapi-gen=true
+// ObjectStoreSource is a location in S3 compatible object storage.
+type ObjectStoreSource struct {
+	// URL of the directory in the object store, for example s3:///bucket/path or
+	// minio://host:9000/bucket/path.
+	URL string `json:"url"`
+
+	// CredentialsSecretName is the name of the secret whose keys are exported as
+	// environment variables to the loader, for example AWS_ACCESS_KEY_ID and
+	// AWS_SECRET_ACCESS_KEY.
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+}
 
-	// ImagePullPolicy of the dgraph component.
-	ImagePullPolicy *corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+// BootstrapStatus represents the status of bootstrapping a dgraph cluster.
+type BootstrapStatus struct {
+	// Phase is the current phase of the bootstrap.
+	Phase BootstrapPhase `json:"phase,omitempty"`
 
-	// Annotations of the component.
-	Annotations map[string]string `json:"annotations,omitempty"`
+	// Message is a human readable message about the current phase.
+	Message string `json:"message,omitempty"`
+
+	// CopiedMembers is the number of alpha members whose volume has been populated
+	// with the bulk loader output.
+	CopiedMembers int32 `json:"copiedMembers,omitempty"`
 }
 
-// Image returns the image to be used for deployment of the dgraph component.
-func (dcs *DgraphComponentSpec) Image() string {
-	return fmt.Sprintf("%s:%s", dcs.BaseImage, dcs.Version)
+// AlphaBootstrapPending returns true if the alpha cluster must not be started
+// yet because the initial data load is still in progress.
+func (dc *DgraphCluster) AlphaBootstrapPending() bool {
+	if dc.Spec.Bootstrap == nil || dc.Spec.Bootstrap.BulkLoad == nil {
+		return false
+	}
+
+	return dc.Status.Bootstrap == nil || dc.Status.Bootstrap.Phase != BootstrapPhaseCompleted
 }
 
-// PodImagePullPolicy returns the image pull policy to be used for deployment
-// of the dgraph component.
-func (dcs *DgraphComponentSpec) PodImagePullPolicy() corev1.PullPolicy {
-	return *dcs.ImagePullPolicy
+// TabletsSpec is the configuration of the placement of predicate tablets across
+// alpha groups.
+type TabletsSpec struct {
+	// Placement pins the tablets of the listed predicates to alpha groups. Tablets are
+	// moved to their group using zero, automatic rebalancing by zero should be disabled
+	// so that it doesn't move pinned tablets away. The alpha autoscaler doesn't drain
+	// groups which tablets are pinned to.
+	Placement []TabletPlacement `json:"placement,omitempty"`
+
+	// RebalanceInterval is the interval at which zero tries to rebalance tablets
+	// across alpha groups. Defaults to the default of zero.
+	RebalanceInterval *metav1.Duration `json:"rebalanceInterval,omitempty"`
+
+	// DisableRebalance turns off the automatic rebalancing of tablets by zero.
+	DisableRebalance bool `json:"disableRebalance,omitempty"`
+
+	// MaintenanceWindow is the daily window during which the operator moves tablets,
+	// including the ones of the alpha groups drained by the autoscaler. Tablets are
+	// moved at any time if not specified.
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
 }
 
-// ResourceRequirements returns the resource requirements to be used for deployment
-// of the dgraph component.
-func (dcs *DgraphComponentSpec) ResourceRequirements() corev1.ResourceRequirements {
-	if dcs.Resources == nil {
-		return corev1.ResourceRequirements{}
+// ZeroRebalanceInterval returns the value of the rebalance interval flag of zero, it is
+// empty if zero must use its default.
+func (ts *TabletsSpec) ZeroRebalanceInterval() string {
+	if ts == nil {
+		return ""
+	}
+	if ts.DisableRebalance {
+		return defaults.ZeroRebalanceDisabledInterval
+	}
+	if ts.RebalanceInterval != nil {
+		return ts.RebalanceInterval.Duration.String()
 	}
 
-	return *dcs.Resources.DeepCopy()
+	return ""
 }
 
-// DgraphComponent represents a single member of either alpha or zero cluster.
-type DgraphComponent struct {
-	Name string `json:"name"`
+// TabletPlacement pins the tablet of a predicate to an alpha group.
+type TabletPlacement struct {
+	// Predicate is the name of the predicate.
+	Predicate string `json:"predicate"`
 
-	ID           string `json:"id"`
-	ComponentURL string `json:"componentURL"`
-	Healthy      bool   `json:"health"`
+	// Group is the ID of the alpha group serving the predicate.
+	Group uint32 `json:"group"`
 }
 
-// ComponentPersistentStorage is the common type for storing configuration for
-// persistent storage to associate with the dgraph component.
-type ComponentPersistentStorage struct {
-	// StorageClassName is the name of the storage class to use for the
-	// persistent volumes for the dgraph component.
-	StorageClassName string `json:"storageClassName,omitempty"`
+// MaintenanceWindow is a daily window of time.
+type MaintenanceWindow struct {
+	// Start is the time of the day in UTC at which the window starts, in the
+	// format HH:MM.
+	Start string `json:"start"`
 
-	// Resource requirements for dgraph persistent storage.
-	Requests corev1.ResourceList `json:"requests,omitempty"`
+	// Duration is the length of the window.
+	Duration metav1.Duration `json:"duration"`
 }
 
-func (cps *ComponentPersistentStorage) StorageRequest() corev1.ResourceList {
-	if cps.Requests == nil {
-		return corev1.ResourceList{}
+// Contains returns true if the provided time is within the maintenance window.
+func (mw *MaintenanceWindow) Contains(t time.Time) (bool, error) {
+	start, err := time.Parse("15:04", mw.Start)
+	if err != nil {
+		return false, fmt.Errorf("invalid maintenance window start %q: %s", mw.Start, err)
 	}
 
-	return cps.Requests.DeepCopy()
+	// The window of the previous day may still be open if it spans midnight.
+	t = t.UTC()
+	for _, day := range []int{0, -1} {
+		windowStart := time.Date(t.Year(), t.Month(), t.Day()+day,
+			start.Hour(), start.Minute(), 0, 0, time.UTC)
+		if !t.Before(windowStart) && t.Before(windowStart.Add(mw.Duration.Duration)) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// TabletsStatus represents the placement of the predicate tablets of a dgraph cluster
+// as reported by zero.
+type TabletsStatus struct {
+	// Tablets is the placement and size of the tablets, ordered by predicate.
+	Tablets []TabletStatus `json:"tablets,omitempty"`
+
+	// PendingMoves is the list of pinned predicates which are not yet served by
+	// their group.
+	PendingMoves []string `json:"pendingMoves,omitempty"`
+
+	// Message is a human readable message about the placement of the tablets.
+	Message string `json:"message,omitempty"`
+}
+
+// TabletStatus represents a predicate tablet.
+type TabletStatus struct {
+	// Predicate is the name of the predicate.
+	Predicate string `json:"predicate"`
+
+	// Group is the ID of the alpha group serving the predicate.
+	Group uint32 `json:"group"`
+
+	// OnDiskBytes is the size of the tablet on disk.
+	OnDiskBytes int64 `json:"onDiskBytes,omitempty"`
 }
 
+// LiveLoadPhase represents the phase of a dgraph live loader run.
+type LiveLoadPhase string
+
+var (
+	// LiveLoadPhasePending represents that the live loader is waiting for the
+	// dgraph cluster to be available.
+	LiveLoadPhasePending LiveLoadPhase = "pending"
+
+	// LiveLoadPhaseRunning represents that the live loader job is running.
+	LiveLoadPhaseRunning LiveLoadPhase = "running"
+
+	// LiveLoadPhaseSucceeded represents that the live loader job has completed.
+	LiveLoadPhaseSucceeded LiveLoadPhase = "succeeded"
+
+	// LiveLoadPhaseFailed represents that the live loader job has failed.
+	LiveLoadPhaseFailed LiveLoadPhase = "failed"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // +k8s:openapi-gen=true
-// AlphaConfig is the configuration for dgraph alpha component.
-type AlphaConfig struct {
-	DgraphConfig `json:",inline"`
+// +kubebuilder:subresource:status
+// DgraphLiveLoad is a Kubernetes custom resource which represents a run of
+// dgraph live loader against a running dgraph cluster.
+type DgraphLiveLoad struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	// LruMB is the value of lrumb flag for dgraph alpha.
-	LruMB int32 `json:"lruMB,omitempty"`
+	// Specification of the live load to run.
+	Spec DgraphLiveLoadSpec `json:"spec"`
+
+	// Most recently observed status of the live load.
+	Status DgraphLiveLoadStatus `json:"status,omitempty"`
+}
+
+// AsOwnerReference returns the OwnerReference corresponding to DgraphLiveLoad
+// which can be used as OwnerReference for other resources in the cluster.
+func (dl *DgraphLiveLoad) AsOwnerReference() metav1.OwnerReference {
+	controller := true
+	blockOwnerDeletion := true
+
+	return metav1.OwnerReference{
+		APIVersion:         SchemeGroupVersion.String(),
+		Kind:               DgraphLiveLoadKindDefinition,
+		Name:               dl.GetName(),
+		UID:                dl.GetUID(),
+		Controller:         &controller,
+		BlockOwnerDeletion: &blockOwnerDeletion,
+	}
 }
 
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // +k8s:openapi-gen=true
-// ZeroConfig is the configuration of dgraph zero component.
-type ZeroConfig struct {
-	DgraphConfig `json:",inline"`
+// DgraphLiveLoadList is the list of DgraphLiveLoad in the k8s cluster.
+type DgraphLiveLoadList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
 
-	// ShardReplicaCount is the max number of replicas per data shard.
-	ShardReplicaCount int32 `json:"shardReplicaCount,omitempty"`
+	// Items is the list of DgraphLiveLoad
+	Items []DgraphLiveLoad `json:"items"`
 }
 
 // +k8s:openapi-gen=true
-// DgraphConfig is the common configuration for dgraph components.
-type DgraphConfig struct {
-	// URL of the jaeger collector for dgraph alpha and zero components.
-	JaegerCollector string `json:"jaegerCollector,omitempty"`
+// DgraphLiveLoadSpec is the underlying specification of the DgraphLiveLoad CRD.
+// The live load is run only once, changes to the specification after the
+// loader job has been created are not applied.
+type DgraphLiveLoadSpec struct {
+	// ClusterName is the name of the DgraphCluster in the namespace of the live
+	// load to load the data into.
+	ClusterName string `json:"clusterName"`
+
+	// Source is the location of the data and schema files.
+	Source DataSource `json:"source"`
+
+	// DataFiles is the path of RDF/JSON data file or directory relative to the source.
+	DataFiles string `json:"dataFiles"`
+
+	// SchemaFile is the path of the DQL schema file relative to the source. The
+	// schema of the cluster is left untouched if empty.
+	SchemaFile string `json:"schemaFile,omitempty"`
+
+	// Format of the data files, one of rdf or json. If empty it is detected
+	// by the live loader from the file extension.
+	Format string `json:"format,omitempty"`
+
+	// BatchSize is the number of N-Quads to send in each mutation.
+	BatchSize int32 `json:"batchSize,omitempty"`
+
+	// Concurrency is the number of concurrent mutations to run.
+	Concurrency int32 `json:"concurrency,omitempty"`
+
+	// ACLSecretName is the name of the secret holding the credentials to log into
+	// a cluster with ACL enabled, under the keys username and password.
+	ACLSecretName string `json:"aclSecretName,omitempty"`
+
+	// TLSSecretName is the name of the secret holding the certificates to connect
+	// to a cluster with TLS enabled, under the keys ca.crt and optionally tls.crt
+	// and tls.key for client authentication.
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// Resource requirements of the live loader job.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// DgraphLiveLoadStatus represents the status of a DgraphLiveLoad, mirrored from
+// the underlying kubernetes job.
+type DgraphLiveLoadStatus struct {
+	// Phase is the current phase of the live load.
+	Phase LiveLoadPhase `json:"phase,omitempty"`
+
+	// Message is a human readable message about the current phase.
+	Message string `json:"message,omitempty"`
+
+	// JobName is the name of the kubernetes job running the live loader.
+	JobName string `json:"jobName,omitempty"`
+
+	// Active is the number of running live loader pods.
+	Active int32 `json:"active,omitempty"`
+
+	// Succeeded is the number of live loader pods which completed successfully.
+	Succeeded int32 `json:"succeeded,omitempty"`
+
+	// Failed is the number of live loader pods which failed.
+	Failed int32 `json:"failed,omitempty"`
+
+	// StartTime is the time the live loader job was started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the live loader job completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// SchemaPhase represents the phase of syncing a DQL schema to a dgraph cluster.
+type SchemaPhase string
+
+var (
+	// SchemaPhasePending represents that the schema is waiting for the dgraph cluster
+	// to be available.
+	SchemaPhasePending SchemaPhase = "pending"
+
+	// SchemaPhaseSynced represents that the live schema of the cluster matches the
+	// specification.
+	SchemaPhaseSynced SchemaPhase = "synced"
+
+	// SchemaPhaseDrifted represents that the live schema of the cluster matches the
+	// specification, but still has previously applied predicates which were removed from
+	// it and can't be dropped as destructive changes are not allowed.
+	SchemaPhaseDrifted SchemaPhase = "drifted"
+
+	// SchemaPhaseFailed represents that the schema could not be applied, either due
+	// to an error or because it requires changes not allowed by the policy.
+	SchemaPhaseFailed SchemaPhase = "failed"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// DgraphSchema is a Kubernetes custom resource which represents the DQL schema
+// of a dgraph cluster.
+type DgraphSchema struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the DQL schema.
+	Spec DgraphSchemaSpec `json:"spec"`
+
+	// Most recently observed status of the schema.
+	Status DgraphSchemaStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +k8s:openapi-gen=true
+// DgraphSchemaList is the list of DgraphSchema in the k8s cluster.
+type DgraphSchemaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items is the list of DgraphSchema
+	Items []DgraphSchema `json:"items"`
+}
+
+// +k8s:openapi-gen=true
+// DgraphSchemaSpec is the underlying specification of the DgraphSchema CRD.
+type DgraphSchemaSpec struct {
+	// ClusterName is the name of the DgraphCluster in the namespace of the schema
+	// to apply the schema to.
+	ClusterName string `json:"clusterName"`
+
+	// Predicates is the list of predicate definitions.
+	Predicates []PredicateSpec `json:"predicates,omitempty"`
+
+	// Types is the list of type definitions.
+	Types []TypeSpec `json:"types,omitempty"`
+
+	// AllowDestructiveChanges allows changing the type of existing predicates and
+	// dropping the predicates previously applied by this DgraphSchema which are no longer
+	// part of the specification. Such changes may lose data and are only reported in
+	// status if not allowed. Predicates this DgraphSchema never applied, such as the ones
+	// of a DgraphGraphQLSchema, are never dropped.
+	AllowDestructiveChanges bool `json:"allowDestructiveChanges,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// PredicateSpec is the definition of a predicate in the DQL schema.
+type PredicateSpec struct {
+	// Name of the predicate.
+	Name string `json:"name"`
+
+	// Type of the predicate value, for example string, int, datetime or uid.
+	Type string `json:"type"`
+
+	// List marks the predicate as a list of values of the type.
+	List bool `json:"list,omitempty"`
+
+	// Index is the list of tokenizers to index the predicate with, for example
+	// exact or term.
+	Index []string `json:"index,omitempty"`
+
+	// Upsert enables upsert checks on the predicate.
+	Upsert bool `json:"upsert,omitempty"`
+
+	// Reverse maintains the reverse edges of a uid predicate.
+	Reverse bool `json:"reverse,omitempty"`
+
+	// Count indexes the number of edges of the predicate.
+	Count bool `json:"count,omitempty"`
+
+	// Lang enables language tags on a string predicate.
+	Lang bool `json:"lang,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// TypeSpec is the definition of a type in the DQL schema.
+type TypeSpec struct {
+	// Name of the type.
+	Name string `json:"name"`
+
+	// Fields is the list of predicates of the type.
+	Fields []string `json:"fields"`
+}
+
+// DgraphSchemaStatus represents the status of a DgraphSchema.
+type DgraphSchemaStatus struct {
+	// Phase is the current phase of the schema sync.
+	Phase SchemaPhase `json:"phase,omitempty"`
+
+	// Message is a human readable message about the current phase.
+	Message string `json:"message,omitempty"`
+
+	// ObservedGeneration is the generation of the specification last synced.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Drift is the list of differences between the specification and the live schema
+	// of the cluster which were not reconciled.
+	Drift []string `json:"drift,omitempty"`
+
+	// LastAppliedTime is the time the schema was last applied to the cluster.
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+
+	// AppliedPredicates are the predicates applied by this DgraphSchema which still exist
+	// in the cluster, the only ones it may drop.
+	AppliedPredicates []string `json:"appliedPredicates,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// DgraphGraphQLSchema is a Kubernetes custom resource which represents the GraphQL
+// schema of a dgraph cluster.
+type DgraphGraphQLSchema struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the GraphQL schema.
+	Spec DgraphGraphQLSchemaSpec `json:"spec"`
+
+	// Most recently observed status of the GraphQL schema.
+	Status DgraphGraphQLSchemaStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +k8s:openapi-gen=true
+// DgraphGraphQLSchemaList is the list of DgraphGraphQLSchema in the k8s cluster.
+type DgraphGraphQLSchemaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items is the list of DgraphGraphQLSchema
+	Items []DgraphGraphQLSchema `json:"items"`
+}
+
+// +k8s:openapi-gen=true
+// DgraphGraphQLSchemaSpec is the underlying specification of the DgraphGraphQLSchema
+// CRD. Exactly one of schema or configMapRef must be specified.
+type DgraphGraphQLSchemaSpec struct {
+	// ClusterName is the name of the DgraphCluster in the namespace of the schema
+	// to apply the schema to.
+	ClusterName string `json:"clusterName"`
+
+	// Schema is the GraphQL SDL of the schema.
+	Schema string `json:"schema,omitempty"`
+
+	// ConfigMapRef selects the key of a config map in the namespace of the schema
+	// holding the GraphQL SDL.
+	ConfigMapRef *corev1.ConfigMapKeySelector `json:"configMapRef,omitempty"`
+}
+
+// DgraphGraphQLSchemaStatus represents the status of a DgraphGraphQLSchema.
+type DgraphGraphQLSchemaStatus struct {
+	// Phase is the current phase of the schema sync.
+	Phase SchemaPhase `json:"phase,omitempty"`
+
+	// Message is a human readable message about the current phase.
+	Message string `json:"message,omitempty"`
+
+	// ObservedGeneration is the generation of the specification last synced.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// AppliedHash is the SHA-256 hash of the GraphQL SDL last applied to the cluster.
+	AppliedHash string `json:"appliedHash,omitempty"`
+
+	// LastAppliedTime is the time the schema was last applied to the cluster.
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+}
+
+// SnapshotPhase represents the phase of taking a DgraphSnapshot.
+type SnapshotPhase string
+
+var (
+	// SnapshotPhasePending represents that the snapshot is waiting for the dgraph cluster
+	// to be available.
+	SnapshotPhasePending SnapshotPhase = "pending"
+
+	// SnapshotPhaseDraining represents that the alpha members are in draining mode while
+	// the volume snapshots are being taken.
+	SnapshotPhaseDraining SnapshotPhase = "draining"
+
+	// SnapshotPhaseSnapshotting represents that the volume snapshots have been taken and
+	// the alpha members resumed, the snapshots are not ready to be used yet.
+	SnapshotPhaseSnapshotting SnapshotPhase = "snapshotting"
+
+	// SnapshotPhaseCompleted represents that all the volume snapshots are ready to be used.
+	SnapshotPhaseCompleted SnapshotPhase = "completed"
+
+	// SnapshotPhaseFailed represents that the snapshot failed and must be taken again
+	// with a new DgraphSnapshot.
+	SnapshotPhaseFailed SnapshotPhase = "failed"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// DgraphSnapshot is a Kubernetes custom resource which represents crash consistent volume
+// snapshots of the persistent volume claims of all the members of a dgraph cluster.
+type DgraphSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the snapshot.
+	Spec DgraphSnapshotSpec `json:"spec"`
+
+	// Most recently observed status of the snapshot.
+	Status DgraphSnapshotStatus `json:"status,omitempty"`
+}
+
+// AsOwnerReference returns the OwnerReference corresponding to DgraphSnapshot
+// which can be used as OwnerReference for other resources in the cluster.
+func (ds *DgraphSnapshot) AsOwnerReference() metav1.OwnerReference {
+	controller := true
+	blockOwnerDeletion := true
+
+	return metav1.OwnerReference{
+		APIVersion:         SchemeGroupVersion.String(),
+		Kind:               DgraphSnapshotKindDefinition,
+		Name:               ds.GetName(),
+		UID:                ds.GetUID(),
+		Controller:         &controller,
+		BlockOwnerDeletion: &blockOwnerDeletion,
+	}
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +k8s:openapi-gen=true
+// DgraphSnapshotList is the list of DgraphSnapshot in the k8s cluster.
+type DgraphSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items is the list of DgraphSnapshot
+	Items []DgraphSnapshot `json:"items"`
+}
+
+// +k8s:openapi-gen=true
+// DgraphSnapshotSpec is the underlying specification of the DgraphSnapshot CRD. The
+// snapshot is taken once, a new DgraphSnapshot must be created to take another one.
+type DgraphSnapshotSpec struct {
+	// ClusterName is the name of the DgraphCluster in the namespace of the snapshot
+	// to take the snapshot of.
+	ClusterName string `json:"clusterName"`
+
+	// VolumeSnapshotClassName is the class of the volume snapshots. The default class
+	// of the CSI driver of each volume is used if empty.
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName,omitempty"`
+}
+
+// DgraphSnapshotStatus represents the status of a DgraphSnapshot.
+type DgraphSnapshotStatus struct {
+	// Phase is the current phase of the snapshot.
+	Phase SnapshotPhase `json:"phase,omitempty"`
+
+	// Message is a human readable message about the current phase.
+	Message string `json:"message,omitempty"`
+
+	// StartTime is the time the alpha members were put into draining mode.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time all the volume snapshots were ready to be used.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Volumes are the volume snapshots of the persistent volume claims of the members.
+	Volumes []SnapshotVolume `json:"volumes,omitempty"`
+
+	// Source identifies the cluster the snapshot was taken of.
+	Source *SnapshotSource `json:"source,omitempty"`
+}
+
+// SnapshotSource identifies the dgraph cluster a DgraphSnapshot was taken of. The raft
+// state in the volumes of the members holds the addresses of the members of that cluster,
+// which depend on its name, cluster ID and cluster domain.
+type SnapshotSource struct {
+	// ClusterName is the name of the DgraphCluster.
+	ClusterName string `json:"clusterName"`
+
+	// ClusterID is the cluster ID of the DgraphCluster.
+	ClusterID string `json:"clusterID"`
+
+	// ClusterDomain is the kubernetes cluster domain of the addresses of the members.
+	ClusterDomain string `json:"clusterDomain"`
+
+	// UID is the UID of the DgraphCluster object.
+	UID types.UID `json:"uid"`
+}
+
+// SnapshotVolume is the volume snapshot of a persistent volume claim of a dgraph member.
+type SnapshotVolume struct {
+	MemberVolume `json:",inline"`
+
+	// Claim is the name of the persistent volume claim.
+	Claim string `json:"claim"`
+
+	// VolumeSnapshot is the name of the volume snapshot of the claim.
+	VolumeSnapshot string `json:"volumeSnapshot"`
+
+	// ReadyToUse is true once the volume snapshot can be used to restore the claim.
+	ReadyToUse bool `json:"readyToUse,omitempty"`
+
+	// RestoreSize is the minimum size of a volume restored from the volume snapshot.
+	RestoreSize string `json:"restoreSize,omitempty"`
+}
+
+// MemberVolume identifies a volume of a dgraph member independently of the name of its
+// cluster, so that volumes of a cluster with the same topology can be matched.
+type MemberVolume struct {
+	// Component is the dgraph component of the member, one of alpha, zero.
+	Component string `json:"component"`
+
+	// Group is the ID of the alpha group of the member, zero unless alpha groups are
+	// specified.
+	Group int32 `json:"group,omitempty"`
+
+	// Volume is the name of the additional volume, empty for the main volume.
+	Volume string `json:"volume,omitempty"`
+
+	// Ordinal is the ordinal of the member pod in its stateful set.
+	Ordinal int32 `json:"ordinal"`
+}
+
+// RestoreSpec is the configuration to restore the data of a dgraph cluster from volume
+// snapshots when it is created.
+type RestoreSpec struct {
+	// SnapshotName is the name of the DgraphSnapshot in the namespace of the cluster to
+	// restore from. The cluster must have the same alpha groups and number of replicas,
+	// and the same additional volumes, as the cluster the snapshot was taken of. As the
+	// members keep the raft addresses of that cluster, it must also have the same name,
+	// cluster ID and cluster domain, and that cluster must have been deleted.
+	SnapshotName string `json:"snapshotName"`
+}
+
+// +k8s:openapi-gen=true
+// AlphaClusterSpec is the specification of the dgraph alpha cluster.
+type AlphaClusterSpec struct {
+	DgraphComponentSpec `json:",inline"`
+
+	// Storage is the configuration for persistent storage for dgraph component.
+	PersistentStorage *ComponentPersistentStorage `json:"persistentStorage,omitempty"`
+
+	// Storage is the type of storage of the alpha members, persistent storage requires
+	// PersistentStorage to be set.
+	Storage *ComponentStorageSpec `json:"storage,omitempty"`
+
+	// Volumes are additional persistent volumes of the alpha members, each holding one of
+	// their data directories (p and w) instead of PersistentStorage.
+	Volumes []ComponentVolume `json:"volumes,omitempty"`
+
+	// StorageMigration enables migrating the data of the existing alpha members when the
+	// storage class of their persistent storage or volumes changes. Without it storage
+	// class changes don't apply to the existing members.
+	StorageMigration *StorageMigrationSpec `json:"storageMigration,omitempty"`
+
+	// AutoExpand enables expanding the persistent volume claims of the alpha members as
+	// their volumes fill up.
+	AutoExpand *VolumeAutoExpandSpec `json:"autoExpand,omitempty"`
+
+	// Number of replicas to run in the cluster. Ignored if groups are specified.
+	Replicas int32 `json:"replicas"`
+
+	// Config is the configuration of the dgraph component.
+	Config *AlphaConfig `json:"config,omitempty"`
+
+	// Groups is the list of alpha groups, each run as a separate stateful set with the
+	// group ID passed to alpha so that group membership is deterministic. If empty all
+	// the alpha members are run in a single stateful set and dgraph zero assigns them
+	// to groups in the order they join. Requires dgraph v21.03 or later. Groups can't
+	// be added to or removed from a cluster whose alpha stateful sets already exist.
+	Groups []AlphaGroupSpec `json:"groups,omitempty"`
+
+	// Autoscaling is the configuration for scaling alpha in steps of whole groups based
+	// on the metrics of the alpha members. Replicas is the initial number of members
+	// when enabled. Not supported along with groups.
+	Autoscaling *AlphaAutoscalingSpec `json:"autoscaling,omitempty"`
+
+	// PodServices is the configuration for exposing each alpha member outside of the
+	// kubernetes cluster with its own service, for clients balancing requests across
+	// the members.
+	PodServices *AlphaPodServicesSpec `json:"podServices,omitempty"`
+
+	// Ingress is the configuration of the ingress exposing the HTTP endpoint of alpha.
+	Ingress *ComponentIngressSpec `json:"ingress,omitempty"`
+
+	// GatewayRoutes is the configuration of the Gateway API routes exposing alpha, only
+	// created if the Gateway API CRDs are installed in the kubernetes cluster.
+	GatewayRoutes *GatewayRoutesSpec `json:"gatewayRoutes,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// AlphaPodServicesSpec is the configuration of the services exposing each alpha member.
+type AlphaPodServicesSpec struct {
+	// Type of the services, one of NodePort, LoadBalancer. Defaults to LoadBalancer.
+	Type string `json:"type,omitempty"`
+
+	// Annotations of the services.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// ExternalTrafficPolicy of the services. One of Cluster, Local.
+	ExternalTrafficPolicy corev1.ServiceExternalTrafficPolicyType `json:"externalTrafficPolicy,omitempty"` // nolint
+}
+
+// ServiceType returns the kubernetes service type of the services exposing each alpha
+// member.
+func (aps *AlphaPodServicesSpec) ServiceType() corev1.ServiceType {
+	if aps.Type == string(corev1.ServiceTypeNodePort) {
+		return corev1.ServiceTypeNodePort
+	}
+
+	return corev1.ServiceTypeLoadBalancer
+}
+
+// TrafficPolicy returns the external traffic policy of the services exposing each alpha
+// member.
+func (aps *AlphaPodServicesSpec) TrafficPolicy() corev1.ServiceExternalTrafficPolicyType {
+	if aps.ExternalTrafficPolicy == "" {
+		return corev1.ServiceExternalTrafficPolicyTypeCluster
+	}
+
+	return aps.ExternalTrafficPolicy
+}
+
+// +k8s:openapi-gen=true
+// AlphaAutoscalingSpec is the configuration for autoscaling the dgraph alpha cluster. A
+// group is added when any of the metrics is above its target, and the last group is
+// drained and removed when all the metrics are below ScaleDownPercent of their targets.
+type AlphaAutoscalingSpec struct {
+	// MinGroups is the minimum number of alpha groups.
+	MinGroups int32 `json:"minGroups"`
+
+	// MaxGroups is the maximum number of alpha groups.
+	MaxGroups int32 `json:"maxGroups"`
+
+	// TargetPendingQueries is the target for the average number of pending queries
+	// of the alpha members.
+	TargetPendingQueries *int64 `json:"targetPendingQueries,omitempty"`
+
+	// TargetMemoryInUse is the target for the memory in use by any alpha member.
+	TargetMemoryInUse *resource.Quantity `json:"targetMemoryInUse,omitempty"`
+
+	// TargetDiskUsage is the target for the disk usage of any alpha group.
+	TargetDiskUsage *resource.Quantity `json:"targetDiskUsage,omitempty"`
+
+	// ScaleDownPercent is the percentage of the targets below which all the metrics
+	// must be to remove a group. Defaults to 50.
+	ScaleDownPercent *int32 `json:"scaleDownPercent,omitempty"`
+
+	// ScaleUpCooldown is the minimum time between a scaling operation and adding a
+	// group. Defaults to 5m.
+	ScaleUpCooldown *metav1.Duration `json:"scaleUpCooldown,omitempty"`
+
+	// ScaleDownCooldown is the minimum time between a scaling operation and removing a
+	// group. Defaults to 30m.
+	ScaleDownCooldown *metav1.Duration `json:"scaleDownCooldown,omitempty"`
+}
+
+// GetScaleDownPercent returns the percentage of the targets below which the alpha
+// cluster is scaled down.
+func (aas *AlphaAutoscalingSpec) GetScaleDownPercent() int32 {
+	if aas.ScaleDownPercent == nil {
+		return defaults.AutoscalingScaleDownPercent
+	}
+	return *aas.ScaleDownPercent
+}
+
+// GetScaleUpCooldown returns the minimum time between a scaling operation and adding
+// a group.
+func (aas *AlphaAutoscalingSpec) GetScaleUpCooldown() time.Duration {
+	if aas.ScaleUpCooldown == nil {
+		return defaults.AutoscalingScaleUpCooldown
+	}
+	return aas.ScaleUpCooldown.Duration
+}
+
+// GetScaleDownCooldown returns the minimum time between a scaling operation and removing
+// a group.
+func (aas *AlphaAutoscalingSpec) GetScaleDownCooldown() time.Duration {
+	if aas.ScaleDownCooldown == nil {
+		return defaults.AutoscalingScaleDownCooldown
+	}
+	return aas.ScaleDownCooldown.Duration
+}
+
+// +k8s:openapi-gen=true
+// AlphaGroupSpec is the specification of a group of dgraph alpha members.
+type AlphaGroupSpec struct {
+	// ID of the dgraph group. Group IDs must be consecutive numbers starting at 1.
+	ID int32 `json:"id"`
+
+	// Number of replicas of the group.
+	Replicas int32 `json:"replicas"`
+
+	// Resource requirements of the group members. Defaults to the resource requirements
+	// of alpha.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// PersistentStorage is the configuration for persistent storage of the group members.
+	// Defaults to the persistent storage configuration of alpha.
+	PersistentStorage *ComponentPersistentStorage `json:"persistentStorage,omitempty"`
+
+	// NodeSelector is the node selector of the group member pods.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations of the group member pods.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity of the group member pods.
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+}
+
+// AlphaGroups returns the alpha groups sorted by group ID.
+func (acs *AlphaClusterSpec) AlphaGroups() []AlphaGroupSpec {
+	groups := make([]AlphaGroupSpec, len(acs.Groups))
+	copy(groups, acs.Groups)
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].ID < groups[j].ID
+	})
+
+	return groups
+}
+
+// ValidateGroups returns an error if the alpha groups are not consecutive group IDs
+// starting at 1.
+func (acs *AlphaClusterSpec) ValidateGroups() error {
+	for i, group := range acs.AlphaGroups() {
+		if group.ID != int32(i+1) {
+			return fmt.Errorf("alpha group IDs must be consecutive numbers starting at 1, "+
+				"expected group %d but got %d", i+1, group.ID)
+		}
+	}
+
+	return nil
+}
+
+// ValidateAutoscaling returns an error if the autoscaling configuration of alpha is
+// not valid.
+func (acs *AlphaClusterSpec) ValidateAutoscaling() error {
+	as := acs.Autoscaling
+	if as == nil {
+		return nil
+	}
+	if len(acs.Groups) > 0 {
+		return fmt.Errorf("alpha autoscaling is not supported along with alpha groups")
+	}
+	if as.MinGroups < 1 || as.MaxGroups < as.MinGroups {
+		return fmt.Errorf("invalid alpha autoscaling bounds: minGroups %d, maxGroups %d",
+			as.MinGroups, as.MaxGroups)
+	}
+	if as.TargetPendingQueries == nil && as.TargetMemoryInUse == nil &&
+		as.TargetDiskUsage == nil {
+		return fmt.Errorf("alpha autoscaling requires at least one target")
+	}
+
+	return nil
+}
+
+// AlphaReplicas returns the number of alpha members to run when alpha groups are not
+// specified, which is decided by the autoscaler if autoscaling is enabled.
+func (dc *DgraphCluster) AlphaReplicas() int32 {
+	status := dc.Status.AlphaCluster.Autoscaling
+	if dc.Spec.AlphaCluster.Autoscaling != nil && status != nil && status.Replicas > 0 {
+		return status.Replicas
+	}
+
+	return dc.Spec.AlphaCluster.Replicas
+}
+
+// LruMB returns the LRU MB configuration for dgraph alpha.
+func (acs *AlphaClusterSpec) LruMB() int32 {
+	if acs.Config == nil {
+		return defaults.LruMBValue
+	}
+	return acs.Config.LruMB
+}
+
+// AlphaClusterStatus represents the cluster status of dgraph alpha components.
+type AlphaClusterStatus struct {
+	// StatefulSet is the status of stateful set associated with the specified
+	// alpha cluster.
+	StatefulSet *apps.StatefulSetStatus `json:"statefulSet,omitempty"`
+
+	// Members is the map of members in the alpha cluster.
+	Members map[string]DgraphComponent `json:"members,omitempty"`
+
+	// Autoscaling is the status of the autoscaler of the alpha cluster.
+	Autoscaling *AlphaAutoscalingStatus `json:"autoscaling,omitempty"`
+
+	// PodServices are the external endpoints of the services exposing each alpha member.
+	PodServices []AlphaPodServiceStatus `json:"podServices,omitempty"`
+
+	// VolumeExpansions is the progress of the expansion of the persistent volume claims of
+	// the alpha members, while storage requests are being increased.
+	VolumeExpansions []VolumeExpansionStatus `json:"volumeExpansions,omitempty"`
+
+	// StorageMigration is the progress of the migration of the alpha members to a new
+	// storage class.
+	StorageMigration *StorageMigrationStatus `json:"storageMigration,omitempty"`
+
+	// VolumeUsage is the usage of the volumes of the alpha members.
+	VolumeUsage []VolumeUsageStatus `json:"volumeUsage,omitempty"`
+}
+
+// AlphaPodServiceStatus is the status of the service exposing an alpha member.
+type AlphaPodServiceStatus struct {
+	// Pod is the name of the pod of the alpha member.
+	Pod string `json:"pod"`
+
+	// Service is the name of the service exposing the alpha member.
+	Service string `json:"service"`
+
+	// GRPCEndpoint is the external address of the gRPC port of the alpha member, empty
+	// until kubernetes has assigned it.
+	GRPCEndpoint string `json:"grpcEndpoint,omitempty"`
+
+	// HTTPEndpoint is the external address of the HTTP port of the alpha member, empty
+	// until kubernetes has assigned it.
+	HTTPEndpoint string `json:"httpEndpoint,omitempty"`
+}
+
+// AutoscalingPhase represents the phase of the autoscaler of the alpha cluster.
+type AutoscalingPhase string
+
+var (
+	// AutoscalingPhaseStable represents that no scaling operation is in progress.
+	AutoscalingPhaseStable AutoscalingPhase = "stable"
+
+	// AutoscalingPhaseDraining represents that the tablets of the last group are being
+	// moved to the other groups before the group is removed.
+	AutoscalingPhaseDraining AutoscalingPhase = "draining"
+
+	// AutoscalingPhaseRemoving represents that the members of the drained group are being
+	// stopped and removed from the cluster.
+	AutoscalingPhaseRemoving AutoscalingPhase = "removing"
+)
+
+// AlphaAutoscalingStatus represents the status of the autoscaler of the alpha cluster.
+type AlphaAutoscalingStatus struct {
+	// Phase is the current phase of the autoscaler.
+	Phase AutoscalingPhase `json:"phase,omitempty"`
+
+	// Replicas is the number of alpha members decided by the autoscaler.
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// DrainingGroup is the ID of the group being drained or removed.
+	DrainingGroup uint32 `json:"drainingGroup,omitempty"`
+
+	// RemovedMembers are the raft IDs of the members of the drained group which are
+	// removed from the cluster once stopped.
+	RemovedMembers []string `json:"removedMembers,omitempty"`
+
+	// LastScaleTime is the time of the last scaling operation.
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+
+	// Metrics are the metrics of the alpha cluster observed on the last sync.
+	Metrics *AlphaMetrics `json:"metrics,omitempty"`
+
+	// Message is a human readable message about the last decision of the autoscaler.
+	Message string `json:"message,omitempty"`
+}
+
+// AlphaMetrics are the metrics of the alpha cluster used for autoscaling.
+type AlphaMetrics struct {
+	// PendingQueries is the average number of pending queries of the alpha members.
+	PendingQueries int64 `json:"pendingQueries"`
+
+	// MemoryInUseBytes is the highest memory in use by an alpha member.
+	MemoryInUseBytes int64 `json:"memoryInUseBytes"`
+
+	// DiskUsageBytes is the highest disk usage of an alpha group.
+	DiskUsageBytes int64 `json:"diskUsageBytes"`
+}
+
+// +k8s:openapi-gen=true
+// ZeroClusterSpec is the specification of the dgraph alpha cluster.
+type ZeroClusterSpec struct {
+	DgraphComponentSpec `json:",inline"`
+
+	// PersistentStorage is the configuration for persistent storage for dgraph component.
+	PersistentStorage *ComponentPersistentStorage `json:"persistentStorage,omitempty"`
+
+	// Storage is the type of storage of the zero members, persistent storage requires
+	// PersistentStorage to be set.
+	Storage *ComponentStorageSpec `json:"storage,omitempty"`
+
+	// Volumes are additional persistent volumes of the zero members, each holding one of
+	// their data directories (zw) instead of PersistentStorage.
+	Volumes []ComponentVolume `json:"volumes,omitempty"`
+
+	// AutoExpand enables expanding the persistent volume claims of the zero members as
+	// their volumes fill up.
+	AutoExpand *VolumeAutoExpandSpec `json:"autoExpand,omitempty"`
+
+	// Number of replicas to run in the cluster.
+	Replicas int32 `json:"replicas"`
+
+	// Config is the configuration of the dgraph zero.
+	Config *ZeroConfig `json:"config,omitempty"`
+}
+
+// ShardReplicaCount returns the zero replica count to be used for deployment
+// of the dgraph component.
+func (zcs *ZeroClusterSpec) ShardReplicaCount() int32 {
+	if zcs.Config == nil {
+		return zcs.Replicas
+	}
+	return zcs.Config.ShardReplicaCount
+}
+
+// ZeroClusterStatus represents the cluster status of dgraph alpha components.
+type ZeroClusterStatus struct {
+	// StatefulSet is the status of stateful set associated with the specified
+	// zero cluster.
+	StatefulSet *apps.StatefulSetStatus `json:"statefulSet,omitempty"`
+
+	// Members is the map of members in the zero cluster.
+	Members map[string]DgraphComponent `json:"members,omitempty"`
+
+	// VolumeExpansions is the progress of the expansion of the persistent volume claims of
+	// the zero members, while storage requests are being increased.
+	VolumeExpansions []VolumeExpansionStatus `json:"volumeExpansions,omitempty"`
+
+	// VolumeUsage is the usage of the volumes of the zero members.
+	VolumeUsage []VolumeUsageStatus `json:"volumeUsage,omitempty"`
+}
+
+// VolumeExpansionPhase represents the phase of the expansion of a persistent volume claim.
+type VolumeExpansionPhase string
+
+var (
+	// VolumeExpansionPhaseExpanding represents that the storage request of the claim has
+	// been increased and the volume is being expanded.
+	VolumeExpansionPhaseExpanding VolumeExpansionPhase = "expanding"
+
+	// VolumeExpansionPhaseFileSystemResizePending represents that the volume has been
+	// expanded and its file system is waiting to be resized on the node.
+	VolumeExpansionPhaseFileSystemResizePending VolumeExpansionPhase = "fileSystemResizePending"
+
+	// VolumeExpansionPhaseExpanded represents that the capacity of the claim matches the
+	// requested storage.
+	VolumeExpansionPhaseExpanded VolumeExpansionPhase = "expanded"
+
+	// VolumeExpansionPhaseUnsupported represents that the storage class of the claim doesn't
+	// allow volume expansion, the storage request must be reverted.
+	VolumeExpansionPhaseUnsupported VolumeExpansionPhase = "unsupported"
+)
+
+// VolumeExpansionStatus represents the status of the expansion of a persistent volume claim
+// of a dgraph member.
+type VolumeExpansionStatus struct {
+	// Claim is the name of the persistent volume claim.
+	Claim string `json:"claim"`
+
+	// Phase is the current phase of the expansion.
+	Phase VolumeExpansionPhase `json:"phase"`
+
+	// Requested is the storage requested for the claim.
+	Requested string `json:"requested"`
+
+	// Capacity is the actual capacity of the volume bound to the claim.
+	Capacity string `json:"capacity,omitempty"`
+
+	// Message is a human readable message about the expansion.
+	Message string `json:"message,omitempty"`
+}
+
+// VolumeUsageStatus represents the usage of the file system of a persistent volume of a
+// dgraph member, as reported by the kubelet of its node.
+type VolumeUsageStatus struct {
+	// Pod is the name of the pod of the member.
+	Pod string `json:"pod"`
+
+	// Claim is the name of the persistent volume claim of the volume.
+	Claim string `json:"claim"`
+
+	// UsedBytes is the number of bytes in use on the volume.
+	UsedBytes int64 `json:"usedBytes"`
+
+	// AvailableBytes is the number of bytes still available on the volume.
+	AvailableBytes int64 `json:"availableBytes"`
+
+	// CapacityBytes is the capacity of the file system of the volume in bytes.
+	CapacityBytes int64 `json:"capacityBytes"`
+
+	// UsedPercent is the percentage of the capacity of the volume in use.
+	UsedPercent int32 `json:"usedPercent"`
+}
+
+// +k8s:openapi-gen=true
+// VolumeAutoExpandSpec is the policy for expanding the persistent volume claims of the
+// members of a dgraph component as their volumes fill up. The storage request of a claim
+// is increased by Increment once the usage of its volume reaches ThresholdPercent of its
+// capacity, up to MaxSize. The storage class of the claims must allow volume expansion.
+type VolumeAutoExpandSpec struct {
+	// ThresholdPercent is the percentage of the capacity of a volume in use above which
+	// its claim is expanded. Defaults to 80.
+	ThresholdPercent *int32 `json:"thresholdPercent,omitempty"`
+
+	// Increment is the storage added to the request of a claim each time it's expanded.
+	Increment resource.Quantity `json:"increment"`
+
+	// MaxSize is the maximum storage request of a claim.
+	MaxSize resource.Quantity `json:"maxSize"`
+}
+
+// GetThresholdPercent returns the percentage of the capacity of a volume in use above
+// which its claim is expanded.
+func (vas *VolumeAutoExpandSpec) GetThresholdPercent() int32 {
+	if vas.ThresholdPercent == nil {
+		return defaults.VolumeAutoExpandThresholdPercent
+	}
+	return *vas.ThresholdPercent
+}
+
+// validateAutoExpand returns an error if the provided auto expansion policy of a component
+// with the provided storage is invalid.
+func validateAutoExpand(autoExpand *VolumeAutoExpandSpec, storage *ComponentStorageSpec) error {
+	if autoExpand == nil {
+		return nil
+	}
+	if storage.Ephemeral() {
+		return fmt.Errorf("autoExpand can't be set with ephemeral storage")
+	}
+	if threshold := autoExpand.GetThresholdPercent(); threshold <= 0 || threshold > 100 {
+		return fmt.Errorf("autoExpand: thresholdPercent must be between 1 and 100")
+	}
+	if autoExpand.Increment.Sign() <= 0 {
+		return fmt.Errorf("autoExpand: increment must be positive")
+	}
+	if autoExpand.MaxSize.Sign() <= 0 {
+		return fmt.Errorf("autoExpand: maxSize must be positive")
+	}
+
+	return nil
+}
+
+// +k8s:openapi-gen=true
+// StorageMigrationSpec is the configuration of the migration of the alpha members to a new
+// storage class.
+//
+// Members are migrated one at a time: the member is stopped, the data of its persistent
+// volume claims is copied to temporary claims of the new storage class, the claims are
+// recreated with the new storage class and the data copied back, and the member is started
+// again. The next member is only migrated once all the alpha members are ready. The stateful
+// set of the member is deleted while it's stopped, leaving the other members running.
+type StorageMigrationSpec struct {
+	// Resources are the compute resources of the jobs copying the data of the members.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// StorageMigrationPhase represents the phase of the migration of an alpha member to a new
+// storage class.
+type StorageMigrationPhase string
+
+var (
+	// StorageMigrationPhaseStopping represents that the stateful set and the pod of the
+	// member are being deleted.
+	StorageMigrationPhaseStopping StorageMigrationPhase = "stopping"
+
+	// StorageMigrationPhaseCopying represents that the data of the member is being copied
+	// to the temporary claims of the new storage class.
+	StorageMigrationPhaseCopying StorageMigrationPhase = "copying"
+
+	// StorageMigrationPhaseRestoring represents that the claims of the member are being
+	// recreated with the new storage class and the data copied back to them.
+	StorageMigrationPhaseRestoring StorageMigrationPhase = "restoring"
+
+	// StorageMigrationPhaseStarting represents that the member has been migrated and is
+	// being started again.
+	StorageMigrationPhaseStarting StorageMigrationPhase = "starting"
+
+	// StorageMigrationPhaseCompleted represents that all the alpha members use the storage
+	// classes of the specification.
+	StorageMigrationPhaseCompleted StorageMigrationPhase = "completed"
+)
+
+// StorageMigrationStatus represents the status of the migration of the alpha members to a
+// new storage class.
+type StorageMigrationStatus struct {
+	// Phase is the current phase of the migration of the member.
+	Phase StorageMigrationPhase `json:"phase,omitempty"`
+
+	// StatefulSet is the name of the stateful set of the member being migrated.
+	StatefulSet string `json:"statefulSet,omitempty"`
+
+	// Member is the name of the pod of the member being migrated.
+	Member string `json:"member,omitempty"`
+
+	// Claims are the names of the persistent volume claims of the member being migrated.
+	Claims []string `json:"claims,omitempty"`
+
+	// MigratedMembers are the names of the pods of the members already migrated.
+	MigratedMembers []string `json:"migratedMembers,omitempty"`
+
+	// Message is a human readable message about the migration.
+	Message string `json:"message,omitempty"`
+}
+
+// AlphaStorageMigrationStopped returns true if the member of the alpha stateful set with
+// the provided name is stopped for its storage to be migrated, the stateful set must not
+// be created until it has been.
+func (dc *DgraphCluster) AlphaStorageMigrationStopped(statefulSet string) bool {
+	status := dc.Status.AlphaCluster.StorageMigration
+	if status == nil || status.StatefulSet != statefulSet {
+		return false
+	}
+
+	switch status.Phase {
+	case StorageMigrationPhaseStopping, StorageMigrationPhaseCopying,
+		StorageMigrationPhaseRestoring:
+		return true
+	}
+
+	return false
+}
+
+// +k8s:openapi-gen=true
+// RatelSpec holds the configuration of dgraph ratel components.
+type RatelSpec struct {
+	DgraphComponentSpec `json:",inline"`
+
+	// Number of replicas of ratel to run in the cluster.
+	Replicas int32 `json:"replicas"`
+
+	// ServerURL is the URL of dgraph alpha ratel connects to by default. Defaults to the
+	// host of the alpha ingress if configured, the alpha service otherwise.
+	ServerURL string `json:"serverURL,omitempty"`
+
+	// NodeSelector is the node selector of the ratel pods.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations of the ratel pods.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Ingress is the configuration of the ingress exposing ratel.
+	Ingress *ComponentIngressSpec `json:"ingress,omitempty"`
+
+	// GatewayRoutes is the configuration of the Gateway API routes exposing ratel, only
+	// created if the Gateway API CRDs are installed in the kubernetes cluster.
+	GatewayRoutes *GatewayRoutesSpec `json:"gatewayRoutes,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// ComponentIngressSpec is the configuration of the ingress exposing the HTTP endpoint of a
+// dgraph component.
+type ComponentIngressSpec struct {
+	// Host is the host routed to the component. All the hosts are routed if empty.
+	Host string `json:"host,omitempty"`
+
+	// Path is the path prefix routed to the component. Defaults to /.
+	Path string `json:"path,omitempty"`
+
+	// TLSSecretName is the name of the secret holding the TLS certificate of the host.
+	// TLS is not terminated at the ingress if empty.
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// ClassName is the class of the ingress controller serving the ingress, set with the
+	// kubernetes.io/ingress.class annotation.
+	ClassName string `json:"className,omitempty"`
+
+	// Annotations of the ingress.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// IngressPath returns the path prefix routed to the component.
+func (cis *ComponentIngressSpec) IngressPath() string {
+	if cis.Path == "" {
+		return "/"
+	}
+
+	return cis.Path
+}
+
+// +k8s:openapi-gen=true
+// GatewayRoutesSpec is the configuration of the Gateway API routes exposing a dgraph
+// component.
+type GatewayRoutesSpec struct {
+	// ParentRefs are the gateways the routes are attached to.
+	ParentRefs []GatewayParentRef `json:"parentRefs"`
+
+	// Hostnames are the hosts routed to the component. All the hosts of the gateways are
+	// routed if empty.
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// Path is the path prefix of the HTTP route. Defaults to /.
+	Path string `json:"path,omitempty"`
+
+	// GRPC creates a GRPCRoute for the gRPC endpoint of the component along with the
+	// HTTPRoute, ignored for ratel.
+	GRPC bool `json:"grpc,omitempty"`
+
+	// Annotations of the routes.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// RoutePath returns the path prefix of the HTTP route of the component.
+func (grs *GatewayRoutesSpec) RoutePath() string {
+	if grs.Path == "" {
+		return "/"
+	}
+
+	return grs.Path
+}
+
+// +k8s:openapi-gen=true
+// GatewayParentRef is a reference to a gateway the routes of a dgraph component are
+// attached to.
+type GatewayParentRef struct {
+	// Name of the gateway.
+	Name string `json:"name"`
+
+	// Namespace of the gateway. Defaults to the namespace of the DgraphCluster.
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName is the name of the listener of the gateway the routes are attached to.
+	// The routes are attached to all the listeners if empty.
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// RatelStatus holds the status of dgraph ratel component.
+type RatelStatus struct {
+	// Deployment is the status of stateful set associated with the specified
+	// ratel cluster.
+	Deployment *apps.DeploymentStatus `json:"deployment,omitempty"`
+
+	// Members is the map of members in the zero cluster.
+	Members map[string]DgraphComponent `json:"members,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// DgraphComponentSpec is the common configuration values shared among different
+// dgraph components.
+type DgraphComponentSpec struct {
+	// Resource requirements of the components.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Base image of the component
+	BaseImage string `json:"baseImage,omitempty"`
+
+	// ServiceType is type of service to create for the component.
+	// One of NodePort, ClusterIP, LoadBalancer. Defaults to ClusterIP.
+	ServiceType string `json:"serviceType,omitempty"`
+
+	// Service is the configuration of the service of the component.
+	Service *ComponentServiceSpec `json:"service,omitempty"`
+
+	// Version of the component. Override the cluster-level version if non-empty
+	Version string `json:"version,omitempty"`
+
+	// ImagePullPolicy of the dgraph component.
+	ImagePullPolicy *corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// Annotations of the component, applied to its pods.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// PodAnnotations are the annotations of the pods of the component, applied after
+	// Annotations.
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// PodLabels are the labels of the pods of the component. Labels managed by the
+	// operator take precedence.
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// ServiceAnnotations are the annotations of the services of the component.
+	ServiceAnnotations map[string]string `json:"serviceAnnotations,omitempty"`
+
+	// ServiceLabels are the labels of the services of the component. Labels managed by
+	// the operator take precedence.
+	ServiceLabels map[string]string `json:"serviceLabels,omitempty"`
+
+	// PVCLabels are the labels of the persistent volume claims of the component, only
+	// applied when the claims are created. Labels managed by the operator take precedence.
+	PVCLabels map[string]string `json:"pvcLabels,omitempty"`
+
+	// Tracing of the component. Override the cluster-level tracing if non-nil, it is
+	// ignored for ratel.
+	Tracing *TracingSpec `json:"tracing,omitempty"`
+}
+
+// Image returns the image to be used for deployment of the dgraph component.
+func (dcs *DgraphComponentSpec) Image() string {
+	return fmt.Sprintf("%s:%s", dcs.BaseImage, dcs.Version)
+}
+
+// +k8s:openapi-gen=true
+// ComponentServiceSpec is the configuration of the service of a dgraph component. It doesn't
+// apply to the headless service of the component, which always exposes all the ports.
+type ComponentServiceSpec struct {
+	// LoadBalancerIP is the IP requested for the service, only used for LoadBalancer
+	// services.
+	LoadBalancerIP string `json:"loadBalancerIP,omitempty"`
+
+	// LoadBalancerSourceRanges restricts the client IPs allowed to access the service, only
+	// used for LoadBalancer services.
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+
+	// ExternalTrafficPolicy of the service, only used for NodePort and LoadBalancer
+	// services. One of Cluster, Local.
+	ExternalTrafficPolicy corev1.ServiceExternalTrafficPolicyType `json:"externalTrafficPolicy,omitempty"` // nolint
+
+	// SessionAffinity of the service. One of None, ClientIP.
+	SessionAffinity corev1.ServiceAffinity `json:"sessionAffinity,omitempty"`
+
+	// SessionAffinityTimeoutSeconds is the maximum session sticky time of ClientIP
+	// session affinity.
+	SessionAffinityTimeoutSeconds *int32 `json:"sessionAffinityTimeoutSeconds,omitempty"`
+
+	// GRPC is the configuration of the gRPC port of the service, ignored for ratel.
+	GRPC *ServicePortSpec `json:"grpc,omitempty"`
+
+	// HTTP is the configuration of the HTTP port of the service.
+	HTTP *ServicePortSpec `json:"http,omitempty"`
+}
+
+// Validate returns an error if the service configuration leaves no port exposed on the
+// service of a component, hasGRPC tells whether the component has a gRPC port.
+func (css *ComponentServiceSpec) Validate(hasGRPC bool) error {
+	if css == nil {
+		return nil
+	}
+
+	grpcDisabled := !hasGRPC || (css.GRPC != nil && css.GRPC.Disabled)
+	if grpcDisabled && css.HTTP != nil && css.HTTP.Disabled {
+		return fmt.Errorf("service exposes no ports")
+	}
+
+	return nil
+}
+
+// GetGRPC returns the configuration of the gRPC port of the service, or nil if it's not
+// specified.
+func (css *ComponentServiceSpec) GetGRPC() *ServicePortSpec {
+	if css == nil {
+		return nil
+	}
+	return css.GRPC
+}
+
+// GetHTTP returns the configuration of the HTTP port of the service, or nil if it's not
+// specified.
+func (css *ComponentServiceSpec) GetHTTP() *ServicePortSpec {
+	if css == nil {
+		return nil
+	}
+	return css.HTTP
+}
+
+// +k8s:openapi-gen=true
+// ServicePortSpec is the configuration of a port of the service of a dgraph component.
+type ServicePortSpec struct {
+	// Disabled removes the port from the service.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// NodePort is the fixed node port of the port, only used for NodePort and
+	// LoadBalancer services. Kubernetes allocates one if not specified.
+	NodePort int32 `json:"nodePort,omitempty"`
+}
+
+// PodTemplateAnnotations returns the annotations of the pods of the component.
+func (dcs *DgraphComponentSpec) PodTemplateAnnotations() map[string]string {
+	return k8sutils.MergeStringMaps(dcs.Annotations, dcs.PodAnnotations)
+}
+
+// TracingConfig returns the tracing configuration of the component, falling back to
+// the jaeger collector of the provided component configuration if no collector is
+// specified. It returns nil if tracing is not configured.
+func (dcs *DgraphComponentSpec) TracingConfig(config *DgraphConfig) *TracingSpec {
+	tracing := dcs.Tracing.DeepCopy()
+	if config != nil && config.JaegerCollector != "" {
+		if tracing == nil {
+			tracing = &TracingSpec{}
+		}
+		if tracing.JaegerCollector == "" {
+			tracing.JaegerCollector = config.JaegerCollector
+		}
+	}
+
+	return tracing
+}
+
+// +k8s:openapi-gen=true
+// TracingSpec is the configuration of the tracing of a dgraph component.
+type TracingSpec struct {
+	// JaegerCollector is the URL of the jaeger collector receiving the traces, for
+	// example http://jaeger-collector.tracing.svc:14268/api/traces.
+	JaegerCollector string `json:"jaegerCollector,omitempty"`
+
+	// SamplingRatio is the ratio of requests traced, between 0 and 1. Defaults to the
+	// dgraph default of 0.01.
+	SamplingRatio string `json:"samplingRatio,omitempty"`
+
+	// Agent injects an OpenTelemetry collector sidecar in the pods of the component,
+	// receiving the jaeger traces of dgraph and forwarding them to an OTLP endpoint.
+	// JaegerCollector is ignored if the agent is specified.
+	Agent *TracingAgentSpec `json:"agent,omitempty"`
+}
+
+// CollectorURL returns the URL of the jaeger collector dgraph sends its traces to, which
+// is the agent sidecar if specified.
+func (ts *TracingSpec) CollectorURL() string {
+	if ts == nil {
+		return ""
+	}
+	if ts.Agent != nil {
+		return fmt.Sprintf("http://localhost:%d/api/traces", defaults.TracingAgentJaegerPort)
+	}
+
+	return ts.JaegerCollector
+}
+
+// +k8s:openapi-gen=true
+// TracingAgentSpec is the configuration of the tracing agent sidecar of a dgraph component.
+type TracingAgentSpec struct {
+	// Endpoint is the OTLP gRPC endpoint the traces are forwarded to, for example
+	// jaeger-collector.tracing.svc:4317.
+	Endpoint string `json:"endpoint"`
+
+	// Insecure disables TLS for the connection to the endpoint.
+	Insecure bool `json:"insecure,omitempty"`
+
+	// Image of the agent. Defaults to the OpenTelemetry collector image.
+	Image string `json:"image,omitempty"`
+
+	// ImagePullPolicy of the agent.
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// Resource requirements of the agent.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// AgentImage returns the image of the tracing agent.
+func (tas *TracingAgentSpec) AgentImage() string {
+	if tas.Image == "" {
+		return defaults.TracingAgentImage
+	}
+
+	return tas.Image
+}
+
+// PodImagePullPolicy returns the image pull policy to be used for deployment
+// of the dgraph component.
+func (dcs *DgraphComponentSpec) PodImagePullPolicy() corev1.PullPolicy {
+	return *dcs.ImagePullPolicy
+}
+
+// ResourceRequirements returns the resource requirements to be used for deployment
+// of the dgraph component.
+func (dcs *DgraphComponentSpec) ResourceRequirements() corev1.ResourceRequirements {
+	if dcs.Resources == nil {
+		return corev1.ResourceRequirements{}
+	}
+
+	return *dcs.Resources.DeepCopy()
+}
+
+// DgraphComponent represents a single member of the alpha, zero or ratel cluster.
+type DgraphComponent struct {
+	// Name is the name of the pod running the member.
+	Name string `json:"name"`
+
+	// ID is the ordinal of the member in its stateful set, empty for ratel members.
+	ID string `json:"id"`
+	// ComponentURL is the address of the member within the kubernetes cluster, the gRPC
+	// endpoint of alpha and zero members and the ratel service for ratel members.
+	ComponentURL string `json:"componentURL"`
+	// Healthy is true if the pod running the member is ready.
+	Healthy bool `json:"health"`
+}
+
+// PVCRetentionPolicyType is what happens to the persistent volume claims of dgraph members.
+type PVCRetentionPolicyType string
+
+const (
+	// PVCRetentionRetain keeps the persistent volume claims, they are reused if the members
+	// are created again.
+	PVCRetentionRetain PVCRetentionPolicyType = "Retain"
+
+	// PVCRetentionDelete deletes the persistent volume claims along with their data.
+	PVCRetentionDelete PVCRetentionPolicyType = "Delete"
+)
+
+// +k8s:openapi-gen=true
+// PVCRetentionPolicy is the policy for the persistent volume claims of the alpha and zero
+// members created from the volume claim templates of their stateful sets.
+type PVCRetentionPolicy struct {
+	// WhenDeleted is what happens to the claims when the DgraphCluster is deleted, one of
+	// Retain, Delete. Defaults to Retain.
+	WhenDeleted PVCRetentionPolicyType `json:"whenDeleted,omitempty"`
+
+	// WhenScaled is what happens to the claims of the members removed when alpha or zero
+	// is scaled down, one of Retain, Delete. Defaults to Retain.
+	WhenScaled PVCRetentionPolicyType `json:"whenScaled,omitempty"`
+}
+
+// DeleteWhenDeleted returns true if the claims must be deleted along with the DgraphCluster.
+func (p *PVCRetentionPolicy) DeleteWhenDeleted() bool {
+	return p != nil && p.WhenDeleted == PVCRetentionDelete
+}
+
+// DeleteWhenScaled returns true if the claims of the removed members must be deleted when
+// scaling down.
+func (p *PVCRetentionPolicy) DeleteWhenScaled() bool {
+	return p != nil && p.WhenScaled == PVCRetentionDelete
+}
+
+// StorageType is the type of storage of a dgraph component.
+type StorageType string
+
+const (
+	// StoragePersistent keeps the data of the component members on persistent volumes.
+	StoragePersistent StorageType = "persistent"
+
+	// StorageEphemeral keeps the data of the component members on emptyDir volumes, which
+	// are lost whenever the member pods are deleted. Meant for throwaway clusters.
+	StorageEphemeral StorageType = "ephemeral"
+)
+
+// +k8s:openapi-gen=true
+// ComponentStorageSpec is the configuration of the type of storage of a dgraph component.
+type ComponentStorageSpec struct {
+	// Type of the storage, one of persistent, ephemeral. Defaults to persistent.
+	Type StorageType `json:"type,omitempty"`
+
+	// Medium of the emptyDir volumes of ephemeral storage. Memory backs the volumes with
+	// tmpfs, which counts against the memory limits of the members.
+	Medium corev1.StorageMedium `json:"medium,omitempty"`
+
+	// SizeLimit of the emptyDir volumes of ephemeral storage.
+	SizeLimit *resource.Quantity `json:"sizeLimit,omitempty"`
+}
+
+// Ephemeral returns true if the storage is ephemeral.
+func (css *ComponentStorageSpec) Ephemeral() bool {
+	return css != nil && css.Type == StorageEphemeral
+}
+
+// validateStorage returns an error unless persistent storage is configured for a
+// component with persistent storage, and only for it.
+func validateStorage(storage *ComponentStorageSpec,
+	persistentStorage *ComponentPersistentStorage) error {
+	if storage.Ephemeral() {
+		if persistentStorage != nil {
+			return fmt.Errorf("persistentStorage can't be set with ephemeral storage")
+		}
+		return nil
+	}
+	if persistentStorage == nil {
+		return fmt.Errorf("persistentStorage is required unless storage is ephemeral")
+	}
+
+	return nil
+}
+
+// ValidateStorage returns an error if the storage configuration of the alpha and zero
+// members of the DgraphCluster is ambiguous, incomplete or invalid.
+func (dc *DgraphCluster) ValidateStorage() error {
+	if err := validateStorage(dc.Spec.ZeroCluster.Storage,
+		dc.Spec.ZeroCluster.PersistentStorage); err != nil {
+		return fmt.Errorf("zero: %s", err)
+	}
+
+	alpha := dc.Spec.AlphaCluster
+	if len(alpha.Groups) == 0 || alpha.PersistentStorage != nil || alpha.Storage.Ephemeral() {
+		if err := validateStorage(alpha.Storage, alpha.PersistentStorage); err != nil {
+			return fmt.Errorf("alpha: %s", err)
+		}
+	}
+	for _, group := range alpha.Groups {
+		persistentStorage := group.PersistentStorage
+		if persistentStorage == nil {
+			persistentStorage = alpha.PersistentStorage
+		}
+		if err := validateStorage(alpha.Storage, persistentStorage); err != nil {
+			return fmt.Errorf("alpha group %d: %s", group.ID, err)
+		}
+	}
+
+	if err := validateVolumes(dc.Spec.ZeroCluster.Volumes, dc.Spec.ZeroCluster.Storage,
+		[]string{DirectoryZeroWAL}, defaults.ZeroPersistentVolumeMountPath); err != nil {
+		return fmt.Errorf("zero: %s", err)
+	}
+	if err := validateVolumes(alpha.Volumes, alpha.Storage,
+		[]string{DirectoryPostings, DirectoryWAL},
+		defaults.AlphaPersistentVolumeMountPath); err != nil {
+		return fmt.Errorf("alpha: %s", err)
+	}
+
+	if alpha.Storage.Ephemeral() && dc.Spec.Bootstrap != nil &&
+		dc.Spec.Bootstrap.BulkLoad != nil {
+		return fmt.Errorf("alpha: bulk load requires persistent storage")
+	}
+
+	if err := validateAutoExpand(dc.Spec.ZeroCluster.AutoExpand,
+		dc.Spec.ZeroCluster.Storage); err != nil {
+		return fmt.Errorf("zero: %s", err)
+	}
+	if err := validateAutoExpand(alpha.AutoExpand, alpha.Storage); err != nil {
+		return fmt.Errorf("alpha: %s", err)
+	}
+
+	if alpha.StorageMigration != nil && alpha.Storage.Ephemeral() {
+		return fmt.Errorf("alpha: storageMigration can't be set with ephemeral storage")
+	}
+
+	if dc.Spec.RestoreFrom != nil {
+		if alpha.Storage.Ephemeral() {
+			return fmt.Errorf("alpha: restoring from a snapshot requires persistent storage")
+		}
+		if dc.Spec.Bootstrap != nil && dc.Spec.Bootstrap.BulkLoad != nil {
+			return fmt.Errorf("restoreFrom can't be set with bulk load")
+		}
+	}
+
+	return nil
+}
+
+// Data directories of dgraph components which can be held by their additional volumes.
+const (
+	// DirectoryPostings is the directory of the postings of alpha.
+	DirectoryPostings = "p"
+
+	// DirectoryWAL is the directory of the write-ahead log of alpha.
+	DirectoryWAL = "w"
+
+	// DirectoryZeroWAL is the directory of the write-ahead log of zero.
+	DirectoryZeroWAL = "zw"
+)
+
+// +k8s:openapi-gen=true
+// ComponentVolume is an additional persistent volume of the members of a dgraph component,
+// holding one of their data directories.
+type ComponentVolume struct {
+	ComponentPersistentStorage `json:",inline"`
+
+	// Name of the volume, part of the names of its persistent volume claims.
+	Name string `json:"name"`
+
+	// Directory held by the volume, p or w for alpha and zw for zero.
+	Directory string `json:"directory"`
+
+	// MountPath of the volume in the member containers. Defaults to /dgraph-<name>.
+	MountPath string `json:"mountPath,omitempty"`
+}
+
+// VolumeMountPath returns the mount path of the volume in the member containers.
+func (cv *ComponentVolume) VolumeMountPath() string {
+	if cv.MountPath == "" {
+		return fmt.Sprintf("/dgraph%s%s", defaults.K8SDelimeter, cv.Name)
+	}
+
+	return cv.MountPath
+}
+
+// DirectoryPath returns the path of the directory held by the volume in the member
+// containers. The directory is created within the volume rather than at its root, which
+// may hold files of the filesystem like lost+found.
+func (cv *ComponentVolume) DirectoryPath() string {
+	return path.Join(cv.VolumeMountPath(), cv.Directory)
+}
+
+// validateVolumes returns an error if the additional volumes of a component are invalid.
+// Directories are the data directories of the component which volumes can hold, and
+// mountPath is the mount path of its persistent storage.
+func validateVolumes(volumes []ComponentVolume, storage *ComponentStorageSpec,
+	directories []string, mountPath string) error {
+	if len(volumes) > 0 && storage.Ephemeral() {
+		return fmt.Errorf("volumes can't be set with ephemeral storage")
+	}
+
+	names := make(map[string]bool)
+	dirs := make(map[string]bool)
+	mountPaths := map[string]bool{mountPath: true}
+	for _, volume := range volumes {
+		if errs := validation.IsDNS1123Label(volume.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid volume name %q: %s", volume.Name, errs[0])
+		}
+		if names[volume.Name] {
+			return fmt.Errorf("duplicate volume name %q", volume.Name)
+		}
+		names[volume.Name] = true
+
+		valid := false
+		for _, dir := range directories {
+			valid = valid || volume.Directory == dir
+		}
+		if !valid {
+			return fmt.Errorf("volume %s: directory must be one of %v, got %q",
+				volume.Name, directories, volume.Directory)
+		}
+		if dirs[volume.Directory] {
+			return fmt.Errorf("directory %s is held by more than one volume",
+				volume.Directory)
+		}
+		dirs[volume.Directory] = true
+
+		volumeMountPath := path.Clean(volume.VolumeMountPath())
+		if !path.IsAbs(volumeMountPath) || mountPaths[volumeMountPath] {
+			return fmt.Errorf("volume %s: mount path %s is not absolute or already in use",
+				volume.Name, volumeMountPath)
+		}
+		mountPaths[volumeMountPath] = true
+	}
+
+	return nil
+}
+
+// ComponentPersistentStorage is the common type for storing configuration for
+// persistent storage to associate with the dgraph component.
+type ComponentPersistentStorage struct {
+	// StorageClassName is the name of the storage class to use for the
+	// persistent volumes for the dgraph component.
+	StorageClassName string `json:"storageClassName,omitempty"`
+
+	// Resource requirements for dgraph persistent storage.
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+}
+
+func (cps *ComponentPersistentStorage) StorageRequest() corev1.ResourceList {
+	if cps.Requests == nil {
+		return corev1.ResourceList{}
+	}
+
+	return cps.Requests.DeepCopy()
+}
+
+// +k8s:openapi-gen=true
+// AlphaConfig is the configuration for dgraph alpha component.
+type AlphaConfig struct {
+	DgraphConfig `json:",inline"`
+
+	// LruMB is the value of lrumb flag for dgraph alpha.
+	LruMB int32 `json:"lruMB,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// ZeroConfig is the configuration of dgraph zero component.
+type ZeroConfig struct {
+	DgraphConfig `json:",inline"`
+
+	// ShardReplicaCount is the max number of replicas per data shard.
+	ShardReplicaCount int32 `json:"shardReplicaCount,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// DgraphConfig is the common configuration for dgraph components.
+type DgraphConfig struct {
+	// URL of the jaeger collector for dgraph alpha and zero components. Deprecated, use
+	// the jaeger collector of the tracing configuration instead.
+	JaegerCollector string `json:"jaegerCollector,omitempty"`
+
+	// ExtraFlags are additional command line flags passed to the dgraph component keyed
+	// by flag name without the leading dashes, for example pending_proposals: "256".
+	// They take precedence over the flags set by the operator.
+	ExtraFlags map[string]string `json:"extraFlags,omitempty"`
+
+	// ConfigFile are the options of the config file passed to the dgraph component with
+	// --config keyed by option name, for example badger: "compression=zstd:1". Changes
+	// to the options restart the members of the component one at a time.
+	ConfigFile map[string]string `json:"configFile,omitempty"`
+}
+
+// ComponentConfig returns the common configuration of dgraph alpha, or nil if there is
+// no configuration.
+func (acs *AlphaClusterSpec) ComponentConfig() *DgraphConfig {
+	if acs.Config == nil {
+		return nil
+	}
+	return &acs.Config.DgraphConfig
+}
+
+// ComponentConfig returns the common configuration of dgraph zero, or nil if there is
+// no configuration.
+func (zcs *ZeroClusterSpec) ComponentConfig() *DgraphConfig {
+	if zcs.Config == nil {
+		return nil
+	}
+	return &zcs.Config.DgraphConfig
 }
@@ -25,25 +25,100 @@ var (
 		OpenAPIV3Schema: &apiextv1.JSONSchemaProps{
 			Type: "object",
 			Properties: map[string]apiextv1.JSONSchemaProps{
-				"spec": dgraphClusterSchema,
+				"spec":   dgraphClusterSchema,
+				"status": dgraphClusterStatusSchema,
 			},
 			Required: []string{"spec"},
 		},
 	}
 
+	dgraphLiveLoadCRV = &apiextv1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextv1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextv1.JSONSchemaProps{
+				"spec":   dgraphLiveLoadSchema,
+				"status": dgraphLiveLoadStatusSchema,
+			},
+			Required: []string{"spec"},
+		},
+	}
+
+	dgraphSchemaCRV = &apiextv1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextv1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextv1.JSONSchemaProps{
+				"spec":   dgraphSchemaSchema,
+				"status": dgraphSchemaStatusSchema,
+			},
+			Required: []string{"spec"},
+		},
+	}
+
+	dgraphGraphQLSchemaCRV = &apiextv1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextv1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextv1.JSONSchemaProps{
+				"spec":   dgraphGraphQLSchemaSchema,
+				"status": dgraphGraphQLSchemaStatusSchema,
+			},
+			Required: []string{"spec"},
+		},
+	}
+
+	dgraphSnapshotCRV = &apiextv1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextv1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextv1.JSONSchemaProps{
+				"spec":   dgraphSnapshotSchema,
+				"status": dgraphSnapshotStatusSchema,
+			},
+			Required: []string{"spec"},
+		},
+	}
+
+	preserveUnknownFields = true
+
+	// Status of the DgraphCluster is only written by the operator, so we don't
+	// validate it and just make sure that kubernetes doesn't prune it.
+	dgraphClusterStatusSchema = apiextv1.JSONSchemaProps{
+		Description:            "Most recently observed status of the dgraph cluster.",
+		Type:                   "object",
+		XPreserveUnknownFields: &preserveUnknownFields,
+	}
+
 	dgraphClusterSchema = apiextv1.JSONSchemaProps{
 		Type: "object",
 		Properties: map[string]apiextv1.JSONSchemaProps{
-			"clusterID":       clusterIDSchema,
-			"alpha":           alphaClusterSchema,
-			"zero":            zeroClusterSchema,
-			"ratel":           ratelClusterSchema,
-			"serviceType":     dgraphComponentProperties["serviceType"],
-			"baseImage":       dgraphComponentProperties["baseImage"],
-			"version":         dgraphComponentProperties["version"],
-			"imagePullPolicy": dgraphComponentProperties["imagePullPolicy"],
-			"annotations":     dgraphComponentProperties["annotations"],
-			"resources":       resourceRequirementsSchema,
+			"clusterID":          clusterIDSchema,
+			"alpha":              alphaClusterSchema,
+			"zero":               zeroClusterSchema,
+			"ratel":              ratelClusterSchema,
+			"serviceType":        dgraphComponentProperties["serviceType"],
+			"baseImage":          dgraphComponentProperties["baseImage"],
+			"version":            dgraphComponentProperties["version"],
+			"imagePullPolicy":    dgraphComponentProperties["imagePullPolicy"],
+			"annotations":        dgraphComponentProperties["annotations"],
+			"podAnnotations":     dgraphComponentProperties["podAnnotations"],
+			"podLabels":          dgraphComponentProperties["podLabels"],
+			"serviceAnnotations": dgraphComponentProperties["serviceAnnotations"],
+			"serviceLabels":      dgraphComponentProperties["serviceLabels"],
+			"pvcLabels":          dgraphComponentProperties["pvcLabels"],
+			"resources":          resourceRequirementsSchema,
+			"clusterDomain": {
+				Description: "DNS domain of the kubernetes cluster.",
+				Type:        "string",
+			},
+			"bootstrap":          bootstrapSchema,
+			"restoreFrom":        restoreFromSchema,
+			"tablets":            tabletsSchema,
+			"tracing":            tracingSchema,
+			"pvcRetentionPolicy": pvcRetentionPolicySchema,
+			"paused": {
+				Description: "Hibernates the cluster, scaling all the components down to " +
+					"zero while keeping the persistent volume claims of the members.",
+				Type: "boolean",
+			},
+			"hibernationSchedule": hibernationScheduleSchema,
 		},
 		Required: []string{
 			"clusterID",
@@ -54,6 +129,253 @@ var (
 		},
 	}
 
+	dgraphLiveLoadStatusSchema = apiextv1.JSONSchemaProps{
+		Description:            "Most recently observed status of the live load.",
+		Type:                   "object",
+		XPreserveUnknownFields: &preserveUnknownFields,
+	}
+
+	dgraphLiveLoadSchema = apiextv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"clusterName": {
+				Description: "Name of the DgraphCluster to load the data into.",
+				Type:        "string",
+			},
+			"source": dataSourceSchema,
+			"dataFiles": {
+				Description: "Path of RDF/JSON data file or directory relative to the source.",
+				Type:        "string",
+			},
+			"schemaFile": {
+				Description: "Path of the DQL schema file relative to the source.",
+				Type:        "string",
+			},
+			"format": loaderFormatSchema,
+			"batchSize": {
+				Description: "Number of N-Quads to send in each mutation.",
+				Type:        "integer",
+			},
+			"concurrency": {
+				Description: "Number of concurrent mutations to run.",
+				Type:        "integer",
+			},
+			"aclSecretName": {
+				Description: "Secret holding the username and password to log into the cluster.",
+				Type:        "string",
+			},
+			"tlsSecretName": {
+				Description: "Secret holding the certificates to connect to the cluster.",
+				Type:        "string",
+			},
+			"resources": resourceRequirementsSchema,
+		},
+		Required: []string{
+			"clusterName",
+			"source",
+			"dataFiles",
+		},
+	}
+
+	dgraphSchemaStatusSchema = apiextv1.JSONSchemaProps{
+		Description:            "Most recently observed status of the schema.",
+		Type:                   "object",
+		XPreserveUnknownFields: &preserveUnknownFields,
+	}
+
+	dgraphSchemaSchema = apiextv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"clusterName": {
+				Description: "Name of the DgraphCluster to apply the schema to.",
+				Type:        "string",
+			},
+			"predicates": {
+				Description: "List of predicate definitions.",
+				Type:        "array",
+				Items: &apiextv1.JSONSchemaPropsOrArray{
+					Schema: &predicateSchema,
+				},
+			},
+			"types": {
+				Description: "List of type definitions.",
+				Type:        "array",
+				Items: &apiextv1.JSONSchemaPropsOrArray{
+					Schema: &typeSchema,
+				},
+			},
+			"allowDestructiveChanges": {
+				Description: "Allow changing predicate types and dropping the predicates " +
+					"previously applied by the schema.",
+				Type: "boolean",
+			},
+		},
+		Required: []string{
+			"clusterName",
+		},
+	}
+
+	predicateSchema = apiextv1.JSONSchemaProps{
+		Description: "Definition of a predicate in the DQL schema.",
+		Type:        "object",
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"name": {
+				Description: "Name of the predicate.",
+				Type:        "string",
+			},
+			"type": {
+				Description: "Type of the predicate value.",
+				Type:        "string",
+				Enum: []apiextv1.JSON{
+					{Raw: []byte(`"default"`)},
+					{Raw: []byte(`"bool"`)},
+					{Raw: []byte(`"datetime"`)},
+					{Raw: []byte(`"float"`)},
+					{Raw: []byte(`"geo"`)},
+					{Raw: []byte(`"int"`)},
+					{Raw: []byte(`"password"`)},
+					{Raw: []byte(`"string"`)},
+					{Raw: []byte(`"uid"`)},
+				},
+			},
+			"list": {
+				Description: "Predicate is a list of values of the type.",
+				Type:        "boolean",
+			},
+			"index": {
+				Description: "Tokenizers to index the predicate with.",
+				Type:        "array",
+				Items: &apiextv1.JSONSchemaPropsOrArray{
+					Schema: &apiextv1.JSONSchemaProps{
+						Type: "string",
+					},
+				},
+			},
+			"upsert": {
+				Description: "Enable upsert checks on the predicate.",
+				Type:        "boolean",
+			},
+			"reverse": {
+				Description: "Maintain reverse edges of the predicate.",
+				Type:        "boolean",
+			},
+			"count": {
+				Description: "Index the number of edges of the predicate.",
+				Type:        "boolean",
+			},
+			"lang": {
+				Description: "Enable language tags on the predicate.",
+				Type:        "boolean",
+			},
+		},
+		Required: []string{
+			"name",
+			"type",
+		},
+	}
+
+	typeSchema = apiextv1.JSONSchemaProps{
+		Description: "Definition of a type in the DQL schema.",
+		Type:        "object",
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"name": {
+				Description: "Name of the type.",
+				Type:        "string",
+			},
+			"fields": {
+				Description: "Predicates of the type.",
+				Type:        "array",
+				Items: &apiextv1.JSONSchemaPropsOrArray{
+					Schema: &apiextv1.JSONSchemaProps{
+						Type: "string",
+					},
+				},
+			},
+		},
+		Required: []string{
+			"name",
+			"fields",
+		},
+	}
+
+	dgraphGraphQLSchemaStatusSchema = apiextv1.JSONSchemaProps{
+		Description:            "Most recently observed status of the GraphQL schema.",
+		Type:                   "object",
+		XPreserveUnknownFields: &preserveUnknownFields,
+	}
+
+	dgraphGraphQLSchemaSchema = apiextv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"clusterName": {
+				Description: "Name of the DgraphCluster to apply the schema to.",
+				Type:        "string",
+			},
+			"schema": {
+				Description: "GraphQL SDL of the schema.",
+				Type:        "string",
+			},
+			"configMapRef": {
+				Description: "Key of a config map holding the GraphQL SDL.",
+				Type:        "object",
+				Required: []string{
+					"name",
+					"key",
+				},
+				Properties: map[string]apiextv1.JSONSchemaProps{
+					"name": {
+						Description: "Name of the config map.",
+						Type:        "string",
+					},
+					"key": {
+						Description: "Key of the config map holding the schema.",
+						Type:        "string",
+					},
+				},
+			},
+		},
+		Required: []string{
+			"clusterName",
+		},
+	}
+
+	dgraphSnapshotStatusSchema = apiextv1.JSONSchemaProps{
+		Description:            "Most recently observed status of the snapshot.",
+		Type:                   "object",
+		XPreserveUnknownFields: &preserveUnknownFields,
+	}
+
+	dgraphSnapshotSchema = apiextv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"clusterName": {
+				Description: "Name of the DgraphCluster to take the snapshot of.",
+				Type:        "string",
+			},
+			"volumeSnapshotClassName": {
+				Description: "Class of the volume snapshots.",
+				Type:        "string",
+			},
+		},
+		Required: []string{
+			"clusterName",
+		},
+	}
+
+	restoreFromSchema = apiextv1.JSONSchemaProps{
+		Description: "Volume snapshots to restore the cluster from when it is created.",
+		Type:        "object",
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"snapshotName": {
+				Description: "Name of the DgraphSnapshot to restore from.",
+				Type:        "string",
+			},
+		},
+		Required: []string{
+			"snapshotName",
+		},
+	}
+
 	maxClusterIDLen int64 = 64
 	clusterIDSchema       = apiextv1.JSONSchemaProps{
 		Description: "Unique ID of the dgraph cluster deployment.",
@@ -68,17 +390,35 @@ var (
 			"replicas",
 		},
 		Properties: map[string]apiextv1.JSONSchemaProps{
-			"serviceType":     dgraphComponentProperties["serviceType"],
-			"baseImage":       dgraphComponentProperties["baseImage"],
-			"version":         dgraphComponentProperties["version"],
-			"imagePullPolicy": dgraphComponentProperties["imagePullPolicy"],
-			"annotations":     dgraphComponentProperties["annotations"],
-			"resources":       resourceRequirementsSchema,
+			"serviceType":        dgraphComponentProperties["serviceType"],
+			"service":            componentServiceSchema,
+			"baseImage":          dgraphComponentProperties["baseImage"],
+			"version":            dgraphComponentProperties["version"],
+			"imagePullPolicy":    dgraphComponentProperties["imagePullPolicy"],
+			"annotations":        dgraphComponentProperties["annotations"],
+			"podAnnotations":     dgraphComponentProperties["podAnnotations"],
+			"podLabels":          dgraphComponentProperties["podLabels"],
+			"serviceAnnotations": dgraphComponentProperties["serviceAnnotations"],
+			"serviceLabels":      dgraphComponentProperties["serviceLabels"],
+			"pvcLabels":          dgraphComponentProperties["pvcLabels"],
+			"resources":          resourceRequirementsSchema,
+			"tracing":            tracingSchema,
 
 			"replicas": {
 				Description: "Number of replicas to run for alpha in the cluster.",
 				Type:        "number",
 			},
+			"volumes": alphaVolumesSchema,
+			"storage": componentStorageSchema,
+			"storageMigration": {
+				Description: "Enables migrating the data of the existing alpha members " +
+					"when the storage class of their volumes changes.",
+				Type: "object",
+				Properties: map[string]apiextv1.JSONSchemaProps{
+					"resources": resourceRequirementsSchema,
+				},
+			},
+			"autoExpand": volumeAutoExpandSchema,
 			"persistentStorage": {
 				Description: "Storage configuration for the persistent volume for the component.",
 				Type:        "object",
@@ -91,6 +431,137 @@ var (
 			"config": {
 				Description: "Config for dgraph alpha.",
 				Type:        "object",
+				Properties: map[string]apiextv1.JSONSchemaProps{
+					"jaegerCollector": dgraphConfigProperties["jaegerCollector"],
+					"extraFlags":      dgraphConfigProperties["extraFlags"],
+					"configFile":      dgraphConfigProperties["configFile"],
+
+					"lruMB": {
+						Description: "Size of the alpha cache in MB.",
+						Type:        "integer",
+					},
+				},
+			},
+			"autoscaling": alphaAutoscalingSchema,
+			"podServices": alphaPodServicesSchema,
+			"ingress": withDescription(componentIngressSchema,
+				"Configuration of the ingress exposing the HTTP endpoint of alpha."),
+			"gatewayRoutes": withDescription(gatewayRoutesSchema,
+				"Configuration of the Gateway API routes exposing alpha."),
+			"groups": {
+				Description: "Alpha groups to run, each as its own stateful set. " +
+					"Requires dgraph v21.03 or later.",
+				Type: "array",
+				Items: &apiextv1.JSONSchemaPropsOrArray{
+					Schema: &alphaGroupSchema,
+				},
+			},
+		},
+	}
+
+	alphaAutoscalingSchema = apiextv1.JSONSchemaProps{
+		Description: "Configuration for scaling alpha in steps of whole groups based on " +
+			"the metrics of the alpha members.",
+		Type: "object",
+		Required: []string{
+			"minGroups",
+			"maxGroups",
+		},
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"minGroups": {
+				Description: "Minimum number of alpha groups.",
+				Type:        "integer",
+				Minimum:     &minAutoscalingGroups,
+			},
+			"maxGroups": {
+				Description: "Maximum number of alpha groups.",
+				Type:        "integer",
+				Minimum:     &minAutoscalingGroups,
+			},
+			"targetPendingQueries": {
+				Description: "Target for the average number of pending queries of the " +
+					"alpha members.",
+				Type: "integer",
+			},
+			"targetMemoryInUse": {
+				Description:  "Target for the memory in use by any alpha member.",
+				XIntOrString: true,
+			},
+			"targetDiskUsage": {
+				Description:  "Target for the disk usage of any alpha group.",
+				XIntOrString: true,
+			},
+			"scaleDownPercent": {
+				Description: "Percentage of the targets below which all the metrics must " +
+					"be to remove a group.",
+				Type:    "integer",
+				Minimum: &minScaleDownPercent,
+				Maximum: &maxScaleDownPercent,
+			},
+			"scaleUpCooldown": {
+				Description: "Minimum time between a scaling operation and adding a group.",
+				Type:        "string",
+			},
+			"scaleDownCooldown": {
+				Description: "Minimum time between a scaling operation and removing a group.",
+				Type:        "string",
+			},
+		},
+	}
+	minAutoscalingGroups float64 = 1
+	minScaleDownPercent  float64 = 1
+	maxScaleDownPercent  float64 = 100
+
+	alphaGroupSchema = apiextv1.JSONSchemaProps{
+		Description: "Configuration for a dgraph alpha group.",
+		Type:        "object",
+		Required: []string{
+			"id",
+			"replicas",
+		},
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"resources": resourceRequirementsSchema,
+
+			"id": {
+				Description: "ID of the group, group IDs must be consecutive numbers starting at 1.",
+				Type:        "integer",
+			},
+			"replicas": {
+				Description: "Number of replicas of the group.",
+				Type:        "number",
+			},
+			"persistentStorage": {
+				Description: "Storage configuration for the persistent volume of the group.",
+				Type:        "object",
+				Required: []string{
+					"storageClassName",
+					"requests",
+				},
+				Properties: dgraphPersistentStorageProperties,
+			},
+			"nodeSelector": {
+				Description: "Node selector for the pods of the group.",
+				Type:        "object",
+				AdditionalProperties: &apiextv1.JSONSchemaPropsOrBool{
+					Schema: &apiextv1.JSONSchemaProps{
+						Type: "string",
+					},
+				},
+			},
+			"tolerations": {
+				Description: "Tolerations for the pods of the group.",
+				Type:        "array",
+				Items: &apiextv1.JSONSchemaPropsOrArray{
+					Schema: &apiextv1.JSONSchemaProps{
+						Type:                   "object",
+						XPreserveUnknownFields: &preserveUnknownFields,
+					},
+				},
+			},
+			"affinity": {
+				Description:            "Affinity for the pods of the group.",
+				Type:                   "object",
+				XPreserveUnknownFields: &preserveUnknownFields,
 			},
 		},
 	}
@@ -102,17 +573,27 @@ var (
 			"replicas",
 		},
 		Properties: map[string]apiextv1.JSONSchemaProps{
-			"serviceType":     dgraphComponentProperties["serviceType"],
-			"baseImage":       dgraphComponentProperties["baseImage"],
-			"version":         dgraphComponentProperties["version"],
-			"imagePullPolicy": dgraphComponentProperties["imagePullPolicy"],
-			"annotations":     dgraphComponentProperties["annotations"],
-			"resources":       resourceRequirementsSchema,
+			"serviceType":        dgraphComponentProperties["serviceType"],
+			"service":            componentServiceSchema,
+			"baseImage":          dgraphComponentProperties["baseImage"],
+			"version":            dgraphComponentProperties["version"],
+			"imagePullPolicy":    dgraphComponentProperties["imagePullPolicy"],
+			"annotations":        dgraphComponentProperties["annotations"],
+			"podAnnotations":     dgraphComponentProperties["podAnnotations"],
+			"podLabels":          dgraphComponentProperties["podLabels"],
+			"serviceAnnotations": dgraphComponentProperties["serviceAnnotations"],
+			"serviceLabels":      dgraphComponentProperties["serviceLabels"],
+			"pvcLabels":          dgraphComponentProperties["pvcLabels"],
+			"resources":          resourceRequirementsSchema,
+			"tracing":            tracingSchema,
 
 			"replicas": {
 				Description: "Number of replicas to run for alpha in the cluster.",
 				Type:        "number",
 			},
+			"volumes":    zeroVolumesSchema,
+			"storage":    componentStorageSchema,
+			"autoExpand": volumeAutoExpandSchema,
 			"persistentStorage": {
 				Description: "Storage configuration for the persistent volume for the component.",
 				Type:        "object",
@@ -123,8 +604,18 @@ var (
 				Properties: dgraphPersistentStorageProperties,
 			},
 			"config": {
-				Description: "Config for dgraph alpha.",
+				Description: "Config for dgraph zero.",
 				Type:        "object",
+				Properties: map[string]apiextv1.JSONSchemaProps{
+					"jaegerCollector": dgraphConfigProperties["jaegerCollector"],
+					"extraFlags":      dgraphConfigProperties["extraFlags"],
+					"configFile":      dgraphConfigProperties["configFile"],
+
+					"shardReplicaCount": {
+						Description: "Max number of replicas per data shard.",
+						Type:        "integer",
+					},
+				},
 			},
 		},
 	}
@@ -136,17 +627,316 @@ var (
 			"replicas",
 		},
 		Properties: map[string]apiextv1.JSONSchemaProps{
-			"serviceType":     dgraphComponentProperties["serviceType"],
-			"baseImage":       dgraphComponentProperties["baseImage"],
-			"version":         dgraphComponentProperties["version"],
-			"imagePullPolicy": dgraphComponentProperties["imagePullPolicy"],
-			"annotations":     dgraphComponentProperties["annotations"],
-			"resources":       resourceRequirementsSchema,
+			"serviceType":        dgraphComponentProperties["serviceType"],
+			"service":            componentServiceSchema,
+			"baseImage":          dgraphComponentProperties["baseImage"],
+			"version":            dgraphComponentProperties["version"],
+			"imagePullPolicy":    dgraphComponentProperties["imagePullPolicy"],
+			"annotations":        dgraphComponentProperties["annotations"],
+			"podAnnotations":     dgraphComponentProperties["podAnnotations"],
+			"podLabels":          dgraphComponentProperties["podLabels"],
+			"serviceAnnotations": dgraphComponentProperties["serviceAnnotations"],
+			"serviceLabels":      dgraphComponentProperties["serviceLabels"],
+			"resources":          resourceRequirementsSchema,
 
 			"replicas": {
 				Description: "Number of replicas to run for ratel in the cluster.",
 				Type:        "number",
 			},
+			"serverURL": {
+				Description: "URL of dgraph alpha ratel connects to by default.",
+				Type:        "string",
+			},
+			"nodeSelector": withDescription(alphaGroupSchema.Properties["nodeSelector"],
+				"Node selector for the ratel pods."),
+			"tolerations": withDescription(alphaGroupSchema.Properties["tolerations"],
+				"Tolerations for the ratel pods."),
+			"ingress": withDescription(componentIngressSchema,
+				"Configuration of the ingress exposing ratel."),
+			"gatewayRoutes": withDescription(gatewayRoutesSchema,
+				"Configuration of the Gateway API routes exposing ratel."),
+		},
+	}
+
+	alphaPodServicesSchema = apiextv1.JSONSchemaProps{
+		Description: "Configuration of the services exposing each alpha member.",
+		Type:        "object",
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"type": {
+				Description: "Type of the services, one of NodePort, LoadBalancer.",
+				Type:        "string",
+				Enum: []apiextv1.JSON{
+					{Raw: []byte(`"NodePort"`)},
+					{Raw: []byte(`"LoadBalancer"`)},
+				},
+			},
+			"annotations": stringMapSchema("Annotations of the services."),
+			"externalTrafficPolicy": componentServiceSchema.
+				Properties["externalTrafficPolicy"],
+		},
+	}
+
+	alphaVolumesSchema = componentVolumesSchema(DirectoryPostings, DirectoryWAL)
+	zeroVolumesSchema  = componentVolumesSchema(DirectoryZeroWAL)
+
+	componentStorageSchema = apiextv1.JSONSchemaProps{
+		Description: "Type of storage of the component members.",
+		Type:        "object",
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"type": {
+				Description: "Type of the storage, one of persistent, ephemeral.",
+				Type:        "string",
+				Enum: []apiextv1.JSON{
+					{Raw: []byte(`"persistent"`)},
+					{Raw: []byte(`"ephemeral"`)},
+				},
+			},
+			"medium": {
+				Description: "Medium of the emptyDir volumes of ephemeral storage.",
+				Type:        "string",
+				Enum: []apiextv1.JSON{
+					{Raw: []byte(`""`)},
+					{Raw: []byte(`"Memory"`)},
+				},
+			},
+			"sizeLimit": {
+				Description: "Size limit of the emptyDir volumes of ephemeral storage.",
+				Type:        "string",
+			},
+		},
+	}
+
+	volumeAutoExpandSchema = apiextv1.JSONSchemaProps{
+		Description: "Policy for expanding the persistent volume claims of the component " +
+			"members as their volumes fill up.",
+		Type: "object",
+		Required: []string{
+			"increment",
+			"maxSize",
+		},
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"thresholdPercent": {
+				Description: "Percentage of the capacity of a volume in use above which " +
+					"its claim is expanded.",
+				Type:    "integer",
+				Minimum: &minThresholdPercent,
+				Maximum: &maxThresholdPercent,
+			},
+			"increment": {
+				Description:  "Storage added to the request of a claim each time it's expanded.",
+				XIntOrString: true,
+			},
+			"maxSize": {
+				Description:  "Maximum storage request of a claim.",
+				XIntOrString: true,
+			},
+		},
+	}
+	minThresholdPercent float64 = 1
+	maxThresholdPercent float64 = 100
+
+	hibernationScheduleSchema = apiextv1.JSONSchemaProps{
+		Description: "Weekly schedule of the times the cluster runs, it's hibernated the " +
+			"rest of the time.",
+		Type: "object",
+		Required: []string{
+			"start",
+			"stop",
+		},
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"start": {
+				Description: "Time of the day the cluster is resumed, in the HH:MM format.",
+				Type:        "string",
+				Pattern:     hibernationTimePattern,
+			},
+			"stop": {
+				Description: "Time of the day the cluster is hibernated, in the HH:MM format.",
+				Type:        "string",
+				Pattern:     hibernationTimePattern,
+			},
+			"days": {
+				Description: "Days of the week the cluster is resumed on, every day if empty.",
+				Type:        "array",
+				Items: &apiextv1.JSONSchemaPropsOrArray{
+					Schema: &apiextv1.JSONSchemaProps{
+						Type: "string",
+						Enum: []apiextv1.JSON{
+							{Raw: []byte(`"Mon"`)},
+							{Raw: []byte(`"Tue"`)},
+							{Raw: []byte(`"Wed"`)},
+							{Raw: []byte(`"Thu"`)},
+							{Raw: []byte(`"Fri"`)},
+							{Raw: []byte(`"Sat"`)},
+							{Raw: []byte(`"Sun"`)},
+						},
+					},
+				},
+			},
+			"timeZone": {
+				Description: "Name of the time zone of the schedule in the IANA time zone " +
+					"database.",
+				Type: "string",
+			},
+		},
+	}
+	hibernationTimePattern = `^([01][0-9]|2[0-3]):[0-5][0-9]$`
+
+	pvcRetentionPolicyTypeEnum = []apiextv1.JSON{
+		{Raw: []byte(`"Retain"`)},
+		{Raw: []byte(`"Delete"`)},
+	}
+
+	pvcRetentionPolicySchema = apiextv1.JSONSchemaProps{
+		Description: "Policy for the persistent volume claims of the alpha and zero members.",
+		Type:        "object",
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"whenDeleted": {
+				Description: "What happens to the claims when the cluster is deleted.",
+				Type:        "string",
+				Enum:        pvcRetentionPolicyTypeEnum,
+			},
+			"whenScaled": {
+				Description: "What happens to the claims of the members removed by scaling down.",
+				Type:        "string",
+				Enum:        pvcRetentionPolicyTypeEnum,
+			},
+		},
+	}
+
+	componentIngressSchema = apiextv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"host": {
+				Description: "Host routed to the component, all the hosts if empty.",
+				Type:        "string",
+			},
+			"path": {
+				Description: "Path prefix routed to the component.",
+				Type:        "string",
+			},
+			"tlsSecretName": {
+				Description: "Name of the secret holding the TLS certificate of the host.",
+				Type:        "string",
+			},
+			"className": {
+				Description: "Class of the ingress controller serving the ingress.",
+				Type:        "string",
+			},
+			"annotations": stringMapSchema("Annotations of the ingress."),
+		},
+	}
+
+	gatewayRoutesSchema = apiextv1.JSONSchemaProps{
+		Type: "object",
+		Required: []string{
+			"parentRefs",
+		},
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"parentRefs": {
+				Description: "Gateways the routes are attached to.",
+				Type:        "array",
+				Items: &apiextv1.JSONSchemaPropsOrArray{
+					Schema: &apiextv1.JSONSchemaProps{
+						Type: "object",
+						Required: []string{
+							"name",
+						},
+						Properties: map[string]apiextv1.JSONSchemaProps{
+							"name": {
+								Description: "Name of the gateway.",
+								Type:        "string",
+							},
+							"namespace": {
+								Description: "Namespace of the gateway.",
+								Type:        "string",
+							},
+							"sectionName": {
+								Description: "Name of the listener of the gateway.",
+								Type:        "string",
+							},
+						},
+					},
+				},
+			},
+			"hostnames": {
+				Description: "Hosts routed to the component.",
+				Type:        "array",
+				Items: &apiextv1.JSONSchemaPropsOrArray{
+					Schema: &apiextv1.JSONSchemaProps{
+						Type: "string",
+					},
+				},
+			},
+			"path": {
+				Description: "Path prefix of the HTTP route.",
+				Type:        "string",
+			},
+			"grpc": {
+				Description: "Create a GRPCRoute for the gRPC endpoint, ignored for ratel.",
+				Type:        "boolean",
+			},
+			"annotations": stringMapSchema("Annotations of the routes."),
+		},
+	}
+
+	minNodePort = float64(1)
+	maxNodePort = float64(65535)
+
+	servicePortSchema = apiextv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"disabled": {
+				Description: "Remove the port from the service.",
+				Type:        "boolean",
+			},
+			"nodePort": {
+				Description: "Fixed node port of the port for NodePort and LoadBalancer services.",
+				Type:        "integer",
+				Minimum:     &minNodePort,
+				Maximum:     &maxNodePort,
+			},
+		},
+	}
+
+	componentServiceSchema = apiextv1.JSONSchemaProps{
+		Description: "Configuration of the service of the component.",
+		Type:        "object",
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"loadBalancerIP": {
+				Description: "IP requested for LoadBalancer services.",
+				Type:        "string",
+			},
+			"loadBalancerSourceRanges": {
+				Description: "Client IP ranges allowed to access LoadBalancer services.",
+				Type:        "array",
+				Items: &apiextv1.JSONSchemaPropsOrArray{
+					Schema: &apiextv1.JSONSchemaProps{
+						Type: "string",
+					},
+				},
+			},
+			"externalTrafficPolicy": {
+				Description: "External traffic policy of NodePort and LoadBalancer services.",
+				Type:        "string",
+				Enum: []apiextv1.JSON{
+					{Raw: []byte(`"Cluster"`)},
+					{Raw: []byte(`"Local"`)},
+				},
+			},
+			"sessionAffinity": {
+				Description: "Session affinity of the service.",
+				Type:        "string",
+				Enum: []apiextv1.JSON{
+					{Raw: []byte(`"None"`)},
+					{Raw: []byte(`"ClientIP"`)},
+				},
+			},
+			"sessionAffinityTimeoutSeconds": {
+				Description: "Maximum session sticky time of ClientIP session affinity.",
+				Type:        "integer",
+			},
+			"grpc": withDescription(servicePortSchema, "Configuration of the gRPC port."),
+			"http": withDescription(servicePortSchema, "Configuration of the HTTP port."),
 		},
 	}
 
@@ -172,6 +962,76 @@ var (
 			Description: "Annotations to apply on the kubernetes container object.",
 			Type:        "object",
 		},
+		"podAnnotations":     stringMapSchema("Annotations of the pods of the component."),
+		"podLabels":          stringMapSchema("Labels of the pods of the component."),
+		"serviceAnnotations": stringMapSchema("Annotations of the services of the component."),
+		"serviceLabels":      stringMapSchema("Labels of the services of the component."),
+		"pvcLabels": stringMapSchema("Labels of the persistent volume claims of the " +
+			"component."),
+	}
+
+	tracingSchema = apiextv1.JSONSchemaProps{
+		Description: "Tracing configuration of the dgraph component.",
+		Type:        "object",
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"jaegerCollector": {
+				Description: "URL of the jaeger collector receiving the traces.",
+				Type:        "string",
+			},
+			"samplingRatio": {
+				Description: "Ratio of requests traced, between 0 and 1.",
+				Type:        "string",
+				Pattern:     `^(0(\.[0-9]+)?|1(\.0+)?)$`,
+			},
+			"agent": {
+				Description: "OpenTelemetry collector sidecar forwarding the traces with OTLP.",
+				Type:        "object",
+				Required: []string{
+					"endpoint",
+				},
+				Properties: map[string]apiextv1.JSONSchemaProps{
+					"endpoint": {
+						Description: "OTLP gRPC endpoint the traces are forwarded to.",
+						Type:        "string",
+					},
+					"insecure": {
+						Description: "Disable TLS for the connection to the endpoint.",
+						Type:        "boolean",
+					},
+					"image": {
+						Description: "Image of the agent, including the tag.",
+						Type:        "string",
+					},
+					"imagePullPolicy": dgraphComponentProperties["imagePullPolicy"],
+					"resources":       resourceRequirementsSchema,
+				},
+			},
+		},
+	}
+
+	dgraphConfigProperties = map[string]apiextv1.JSONSchemaProps{
+		"jaegerCollector": {
+			Description: "URL of the jaeger collector.",
+			Type:        "string",
+		},
+		"extraFlags": {
+			Description: "Additional command line flags keyed by flag name.",
+			Type:        "object",
+			AdditionalProperties: &apiextv1.JSONSchemaPropsOrBool{
+				Schema: &apiextv1.JSONSchemaProps{
+					Type: "string",
+				},
+			},
+		},
+		"configFile": {
+			Description: "Options of the config file passed with --config keyed by option name.",
+			Type:        "object",
+			AdditionalProperties: &apiextv1.JSONSchemaPropsOrBool{
+				Schema: &apiextv1.JSONSchemaProps{
+					Type: "string",
+				},
+			},
+		},
 	}
 
 	resourceRequirementsSchema = apiextv1.JSONSchemaProps{
@@ -195,6 +1055,182 @@ var (
 		},
 	}
 
+	bootstrapSchema = apiextv1.JSONSchemaProps{
+		Description: "Configuration to populate the cluster with initial data when it is created.",
+		Type:        "object",
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"bulkLoad": {
+				Description: "Load data using dgraph bulk loader before alpha is started.",
+				Type:        "object",
+				Required: []string{
+					"source",
+					"dataFiles",
+					"schemaFile",
+					"persistentStorage",
+				},
+				Properties: map[string]apiextv1.JSONSchemaProps{
+					"source": dataSourceSchema,
+					"dataFiles": {
+						Description: "Path of RDF/JSON data file or directory relative to the source.",
+						Type:        "string",
+					},
+					"schemaFile": {
+						Description: "Path of the DQL schema file relative to the source.",
+						Type:        "string",
+					},
+					"format": loaderFormatSchema,
+					"mapShards": {
+						Description: "Number of map shards to use for bulk loader.",
+						Type:        "integer",
+					},
+					"persistentStorage": {
+						Description: "Storage configuration for the bulk loader output volume.",
+						Type:        "object",
+						Required: []string{
+							"storageClassName",
+							"requests",
+						},
+						Properties: dgraphPersistentStorageProperties,
+					},
+					"resources": resourceRequirementsSchema,
+				},
+			},
+		},
+	}
+
+	tabletsSchema = apiextv1.JSONSchemaProps{
+		Description: "Configuration of the placement of predicate tablets across alpha groups.",
+		Type:        "object",
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"placement": {
+				Description: "Predicates whose tablet is pinned to an alpha group.",
+				Type:        "array",
+				Items: &apiextv1.JSONSchemaPropsOrArray{
+					Schema: &apiextv1.JSONSchemaProps{
+						Type: "object",
+						Required: []string{
+							"predicate",
+							"group",
+						},
+						Properties: map[string]apiextv1.JSONSchemaProps{
+							"predicate": {
+								Description: "Name of the predicate.",
+								Type:        "string",
+							},
+							"group": {
+								Description: "ID of the alpha group serving the predicate.",
+								Type:        "integer",
+								Minimum:     &minTabletGroup,
+							},
+						},
+					},
+				},
+			},
+			"rebalanceInterval": {
+				Description: "Interval at which zero rebalances tablets across groups, e.g. 8m.",
+				Type:        "string",
+			},
+			"disableRebalance": {
+				Description: "Turn off the automatic rebalancing of tablets by zero.",
+				Type:        "boolean",
+			},
+			"maintenanceWindow": {
+				Description: "Daily window during which the operator moves tablets.",
+				Type:        "object",
+				Required: []string{
+					"start",
+					"duration",
+				},
+				Properties: map[string]apiextv1.JSONSchemaProps{
+					"start": {
+						Description: "Time of the day in UTC at which the window starts (HH:MM).",
+						Type:        "string",
+						Pattern:     "^([01][0-9]|2[0-3]):[0-5][0-9]$",
+					},
+					"duration": {
+						Description: "Length of the window, e.g. 2h.",
+						Type:        "string",
+					},
+				},
+			},
+		},
+	}
+	minTabletGroup float64 = 1
+
+	loaderFormatSchema = apiextv1.JSONSchemaProps{
+		Description: "Format of the data files, one of rdf or json.",
+		Type:        "string",
+		Enum: []apiextv1.JSON{
+			{Raw: []byte(`"rdf"`)},
+			{Raw: []byte(`"json"`)},
+		},
+	}
+
+	dataSourceSchema = apiextv1.JSONSchemaProps{
+		Description: "Location of the files to load, one of configMap, persistentVolumeClaim " +
+			"or objectStore.",
+		Type: "object",
+		Properties: map[string]apiextv1.JSONSchemaProps{
+			"configMap": {
+				Description: "Existing config map whose keys are the files.",
+				Type:        "object",
+				Required: []string{
+					"name",
+				},
+				Properties: map[string]apiextv1.JSONSchemaProps{
+					"name": {
+						Description: "Name of the config map.",
+						Type:        "string",
+					},
+					"items": {
+						Description: "Keys of the config map to project as files.",
+						Type:        "array",
+						Items: &apiextv1.JSONSchemaPropsOrArray{
+							Schema: &apiextv1.JSONSchemaProps{
+								Type:                   "object",
+								XPreserveUnknownFields: &preserveUnknownFields,
+							},
+						},
+					},
+				},
+			},
+			"persistentVolumeClaim": {
+				Description: "Existing persistent volume claim containing the files.",
+				Type:        "object",
+				Required: []string{
+					"claimName",
+				},
+				Properties: map[string]apiextv1.JSONSchemaProps{
+					"claimName": {
+						Description: "Name of the persistent volume claim.",
+						Type:        "string",
+					},
+					"readOnly": {
+						Description: "Mount the volume read only.",
+						Type:        "boolean",
+					},
+				},
+			},
+			"objectStore": {
+				Description: "Object storage location containing the files.",
+				Type:        "object",
+				Required: []string{
+					"url",
+				},
+				Properties: map[string]apiextv1.JSONSchemaProps{
+					"url": {
+						Description: "URL of the directory, for example s3:///bucket/path.",
+						Type:        "string",
+					},
+					"credentialsSecretName": {
+						Description: "Secret whose keys are exported as environment variables.",
+						Type:        "string",
+					},
+				},
+			},
+		},
+	}
+
 	dgraphPersistentStorageProperties = map[string]apiextv1.JSONSchemaProps{
 		"requests": {
 			Description: resourceRequirementsSchema.Properties["requests"].Description,
@@ -215,3 +1251,65 @@ var (
 		},
 	}
 )
+
+// componentVolumesSchema returns the schema of the additional volumes of a dgraph
+// component holding the provided data directories.
+func componentVolumesSchema(directories ...string) apiextv1.JSONSchemaProps {
+	enum := make([]apiextv1.JSON, 0, len(directories))
+	for _, dir := range directories {
+		enum = append(enum, apiextv1.JSON{Raw: []byte(`"` + dir + `"`)})
+	}
+
+	return apiextv1.JSONSchemaProps{
+		Description: "Additional persistent volumes of the members, each holding one of " +
+			"their data directories.",
+		Type: "array",
+		Items: &apiextv1.JSONSchemaPropsOrArray{
+			Schema: &apiextv1.JSONSchemaProps{
+				Type: "object",
+				Required: []string{
+					"name",
+					"directory",
+					"storageClassName",
+					"requests",
+				},
+				Properties: map[string]apiextv1.JSONSchemaProps{
+					"name": {
+						Description: "Name of the volume.",
+						Type:        "string",
+					},
+					"directory": {
+						Description: "Data directory held by the volume.",
+						Type:        "string",
+						Enum:        enum,
+					},
+					"mountPath": {
+						Description: "Mount path of the volume in the member containers.",
+						Type:        "string",
+					},
+					"storageClassName": dgraphPersistentStorageProperties["storageClassName"],
+					"requests":         dgraphPersistentStorageProperties["requests"],
+				},
+			},
+		},
+	}
+}
+
+// stringMapSchema returns the schema of an object mapping keys to string values.
+func stringMapSchema(description string) apiextv1.JSONSchemaProps {
+	return apiextv1.JSONSchemaProps{
+		Description: description,
+		Type:        "object",
+		AdditionalProperties: &apiextv1.JSONSchemaPropsOrBool{
+			Schema: &apiextv1.JSONSchemaProps{
+				Type: "string",
+			},
+		},
+	}
+}
+
+// withDescription returns a copy of the provided schema with the provided description.
+func withDescription(schema apiextv1.JSONSchemaProps, description string) apiextv1.JSONSchemaProps {
+	schema.Description = description
+	return schema
+}
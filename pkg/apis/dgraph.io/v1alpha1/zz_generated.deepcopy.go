@@ -21,11 +21,88 @@
 package v1alpha1
 
 import (
-	v1 "k8s.io/api/apps/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlphaAutoscalingSpec) DeepCopyInto(out *AlphaAutoscalingSpec) {
+	*out = *in
+	if in.TargetPendingQueries != nil {
+		in, out := &in.TargetPendingQueries, &out.TargetPendingQueries
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TargetMemoryInUse != nil {
+		in, out := &in.TargetMemoryInUse, &out.TargetMemoryInUse
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.TargetDiskUsage != nil {
+		in, out := &in.TargetDiskUsage, &out.TargetDiskUsage
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ScaleDownPercent != nil {
+		in, out := &in.ScaleDownPercent, &out.ScaleDownPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleUpCooldown != nil {
+		in, out := &in.ScaleUpCooldown, &out.ScaleUpCooldown
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ScaleDownCooldown != nil {
+		in, out := &in.ScaleDownCooldown, &out.ScaleDownCooldown
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlphaAutoscalingSpec.
+func (in *AlphaAutoscalingSpec) DeepCopy() *AlphaAutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlphaAutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlphaAutoscalingStatus) DeepCopyInto(out *AlphaAutoscalingStatus) {
+	*out = *in
+	if in.RemovedMembers != nil {
+		in, out := &in.RemovedMembers, &out.RemovedMembers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(AlphaMetrics)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlphaAutoscalingStatus.
+func (in *AlphaAutoscalingStatus) DeepCopy() *AlphaAutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AlphaAutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AlphaClusterSpec) DeepCopyInto(out *AlphaClusterSpec) {
 	*out = *in
@@ -35,10 +112,59 @@ func (in *AlphaClusterSpec) DeepCopyInto(out *AlphaClusterSpec) {
 		*out = new(ComponentPersistentStorage)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(ComponentStorageSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]ComponentVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StorageMigration != nil {
+		in, out := &in.StorageMigration, &out.StorageMigration
+		*out = new(StorageMigrationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoExpand != nil {
+		in, out := &in.AutoExpand, &out.AutoExpand
+		*out = new(VolumeAutoExpandSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
 		*out = new(AlphaConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]AlphaGroupSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AlphaAutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodServices != nil {
+		in, out := &in.PodServices, &out.PodServices
+		*out = new(AlphaPodServicesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(ComponentIngressSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GatewayRoutes != nil {
+		in, out := &in.GatewayRoutes, &out.GatewayRoutes
+		*out = new(GatewayRoutesSpec)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -58,7 +184,7 @@ func (in *AlphaClusterStatus) DeepCopyInto(out *AlphaClusterStatus) {
 	*out = *in
 	if in.StatefulSet != nil {
 		in, out := &in.StatefulSet, &out.StatefulSet
-		*out = new(v1.StatefulSetStatus)
+		*out = new(appsv1.StatefulSetStatus)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Members != nil {
@@ -68,6 +194,31 @@ func (in *AlphaClusterStatus) DeepCopyInto(out *AlphaClusterStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AlphaAutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodServices != nil {
+		in, out := &in.PodServices, &out.PodServices
+		*out = make([]AlphaPodServiceStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.VolumeExpansions != nil {
+		in, out := &in.VolumeExpansions, &out.VolumeExpansions
+		*out = make([]VolumeExpansionStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.StorageMigration != nil {
+		in, out := &in.StorageMigration, &out.StorageMigration
+		*out = new(StorageMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeUsage != nil {
+		in, out := &in.VolumeUsage, &out.VolumeUsage
+		*out = make([]VolumeUsageStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -84,7 +235,7 @@ func (in *AlphaClusterStatus) DeepCopy() *AlphaClusterStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AlphaConfig) DeepCopyInto(out *AlphaConfig) {
 	*out = *in
-	out.DgraphConfig = in.DgraphConfig
+	in.DgraphConfig.DeepCopyInto(&out.DgraphConfig)
 	return
 }
 
@@ -99,112 +250,85 @@ func (in *AlphaConfig) DeepCopy() *AlphaConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComponentPersistentStorage) DeepCopyInto(out *ComponentPersistentStorage) {
+func (in *AlphaGroupSpec) DeepCopyInto(out *AlphaGroupSpec) {
 	*out = *in
-	if in.Requests != nil {
-		in, out := &in.Requests, &out.Requests
-		*out = make(corev1.ResourceList, len(*in))
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PersistentStorage != nil {
+		in, out := &in.PersistentStorage, &out.PersistentStorage
+		*out = new(ComponentPersistentStorage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val.DeepCopy()
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentPersistentStorage.
-func (in *ComponentPersistentStorage) DeepCopy() *ComponentPersistentStorage {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlphaGroupSpec.
+func (in *AlphaGroupSpec) DeepCopy() *AlphaGroupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ComponentPersistentStorage)
+	out := new(AlphaGroupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DgraphCluster) DeepCopyInto(out *DgraphCluster) {
+func (in *AlphaMetrics) DeepCopyInto(out *AlphaMetrics) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphCluster.
-func (in *DgraphCluster) DeepCopy() *DgraphCluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlphaMetrics.
+func (in *AlphaMetrics) DeepCopy() *AlphaMetrics {
 	if in == nil {
 		return nil
 	}
-	out := new(DgraphCluster)
+	out := new(AlphaMetrics)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DgraphCluster) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DgraphClusterList) DeepCopyInto(out *DgraphClusterList) {
+func (in *AlphaPodServiceStatus) DeepCopyInto(out *AlphaPodServiceStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]DgraphCluster, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphClusterList.
-func (in *DgraphClusterList) DeepCopy() *DgraphClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlphaPodServiceStatus.
+func (in *AlphaPodServiceStatus) DeepCopy() *AlphaPodServiceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DgraphClusterList)
+	out := new(AlphaPodServiceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DgraphClusterList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DgraphClusterSpec) DeepCopyInto(out *DgraphClusterSpec) {
+func (in *AlphaPodServicesSpec) DeepCopyInto(out *AlphaPodServicesSpec) {
 	*out = *in
-	if in.AlphaCluster != nil {
-		in, out := &in.AlphaCluster, &out.AlphaCluster
-		*out = new(AlphaClusterSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ZeroCluster != nil {
-		in, out := &in.ZeroCluster, &out.ZeroCluster
-		*out = new(ZeroClusterSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Ratel != nil {
-		in, out := &in.Ratel, &out.Ratel
-		*out = new(RatelSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ImagePullPolicy != nil {
-		in, out := &in.ImagePullPolicy, &out.ImagePullPolicy
-		*out = new(corev1.PullPolicy)
-		**out = **in
-	}
 	if in.Annotations != nil {
 		in, out := &in.Annotations, &out.Annotations
 		*out = make(map[string]string, len(*in))
@@ -212,72 +336,86 @@ func (in *DgraphClusterSpec) DeepCopyInto(out *DgraphClusterSpec) {
 			(*out)[key] = val
 		}
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphClusterSpec.
-func (in *DgraphClusterSpec) DeepCopy() *DgraphClusterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlphaPodServicesSpec.
+func (in *AlphaPodServicesSpec) DeepCopy() *AlphaPodServicesSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DgraphClusterSpec)
+	out := new(AlphaPodServicesSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DgraphClusterStatus) DeepCopyInto(out *DgraphClusterStatus) {
+func (in *BootstrapSpec) DeepCopyInto(out *BootstrapSpec) {
 	*out = *in
-	in.AlphaCluster.DeepCopyInto(&out.AlphaCluster)
-	in.ZeroCluster.DeepCopyInto(&out.ZeroCluster)
-	in.Ratel.DeepCopyInto(&out.Ratel)
+	if in.BulkLoad != nil {
+		in, out := &in.BulkLoad, &out.BulkLoad
+		*out = new(BulkLoadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphClusterStatus.
-func (in *DgraphClusterStatus) DeepCopy() *DgraphClusterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapSpec.
+func (in *BootstrapSpec) DeepCopy() *BootstrapSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DgraphClusterStatus)
+	out := new(BootstrapSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DgraphComponent) DeepCopyInto(out *DgraphComponent) {
+func (in *BootstrapStatus) DeepCopyInto(out *BootstrapStatus) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphComponent.
-func (in *DgraphComponent) DeepCopy() *DgraphComponent {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapStatus.
+func (in *BootstrapStatus) DeepCopy() *BootstrapStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DgraphComponent)
+	out := new(BootstrapStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DgraphComponentSpec) DeepCopyInto(out *DgraphComponentSpec) {
+func (in *BulkLoadSpec) DeepCopyInto(out *BulkLoadSpec) {
 	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	if in.PersistentStorage != nil {
+		in, out := &in.PersistentStorage, &out.PersistentStorage
+		*out = new(ComponentPersistentStorage)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ImagePullPolicy != nil {
-		in, out := &in.ImagePullPolicy, &out.ImagePullPolicy
-		*out = new(corev1.PullPolicy)
-		**out = **in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkLoadSpec.
+func (in *BulkLoadSpec) DeepCopy() *BulkLoadSpec {
+	if in == nil {
+		return nil
 	}
+	out := new(BulkLoadSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentIngressSpec) DeepCopyInto(out *ComponentIngressSpec) {
+	*out = *in
 	if in.Annotations != nil {
 		in, out := &in.Annotations, &out.Annotations
 		*out = make(map[string]string, len(*in))
@@ -288,110 +426,1542 @@ func (in *DgraphComponentSpec) DeepCopyInto(out *DgraphComponentSpec) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphComponentSpec.
-func (in *DgraphComponentSpec) DeepCopy() *DgraphComponentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentIngressSpec.
+func (in *ComponentIngressSpec) DeepCopy() *ComponentIngressSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DgraphComponentSpec)
+	out := new(ComponentIngressSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DgraphConfig) DeepCopyInto(out *DgraphConfig) {
+func (in *ComponentPersistentStorage) DeepCopyInto(out *ComponentPersistentStorage) {
 	*out = *in
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphConfig.
-func (in *DgraphConfig) DeepCopy() *DgraphConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentPersistentStorage.
+func (in *ComponentPersistentStorage) DeepCopy() *ComponentPersistentStorage {
 	if in == nil {
 		return nil
 	}
-	out := new(DgraphConfig)
+	out := new(ComponentPersistentStorage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RatelSpec) DeepCopyInto(out *RatelSpec) {
+func (in *ComponentServiceSpec) DeepCopyInto(out *ComponentServiceSpec) {
 	*out = *in
-	in.DgraphComponentSpec.DeepCopyInto(&out.DgraphComponentSpec)
+	if in.LoadBalancerSourceRanges != nil {
+		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SessionAffinityTimeoutSeconds != nil {
+		in, out := &in.SessionAffinityTimeoutSeconds, &out.SessionAffinityTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(ServicePortSpec)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(ServicePortSpec)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RatelSpec.
-func (in *RatelSpec) DeepCopy() *RatelSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentServiceSpec.
+func (in *ComponentServiceSpec) DeepCopy() *ComponentServiceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RatelSpec)
+	out := new(ComponentServiceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RatelStatus) DeepCopyInto(out *RatelStatus) {
+func (in *ComponentStorageSpec) DeepCopyInto(out *ComponentStorageSpec) {
 	*out = *in
-	if in.Deployment != nil {
-		in, out := &in.Deployment, &out.Deployment
-		*out = new(v1.DeploymentStatus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Members != nil {
-		in, out := &in.Members, &out.Members
-		*out = make(map[string]DgraphComponent, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.SizeLimit != nil {
+		in, out := &in.SizeLimit, &out.SizeLimit
+		x := (*in).DeepCopy()
+		*out = &x
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RatelStatus.
-func (in *RatelStatus) DeepCopy() *RatelStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStorageSpec.
+func (in *ComponentStorageSpec) DeepCopy() *ComponentStorageSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RatelStatus)
+	out := new(ComponentStorageSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ZeroClusterSpec) DeepCopyInto(out *ZeroClusterSpec) {
+func (in *ComponentVolume) DeepCopyInto(out *ComponentVolume) {
 	*out = *in
-	in.DgraphComponentSpec.DeepCopyInto(&out.DgraphComponentSpec)
-	if in.PersistentStorage != nil {
-		in, out := &in.PersistentStorage, &out.PersistentStorage
-		*out = new(ComponentPersistentStorage)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = new(ZeroConfig)
-		**out = **in
-	}
+	in.ComponentPersistentStorage.DeepCopyInto(&out.ComponentPersistentStorage)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZeroClusterSpec.
-func (in *ZeroClusterSpec) DeepCopy() *ZeroClusterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentVolume.
+func (in *ComponentVolume) DeepCopy() *ComponentVolume {
 	if in == nil {
 		return nil
 	}
-	out := new(ZeroClusterSpec)
+	out := new(ComponentVolume)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ZeroClusterStatus) DeepCopyInto(out *ZeroClusterStatus) {
+func (in *DataSource) DeepCopyInto(out *DataSource) {
 	*out = *in
-	if in.StatefulSet != nil {
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(corev1.ConfigMapVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(corev1.PersistentVolumeClaimVolumeSource)
+		**out = **in
+	}
+	if in.ObjectStore != nil {
+		in, out := &in.ObjectStore, &out.ObjectStore
+		*out = new(ObjectStoreSource)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSource.
+func (in *DataSource) DeepCopy() *DataSource {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphCluster) DeepCopyInto(out *DgraphCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphCluster.
+func (in *DgraphCluster) DeepCopy() *DgraphCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DgraphCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphClusterList) DeepCopyInto(out *DgraphClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DgraphCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphClusterList.
+func (in *DgraphClusterList) DeepCopy() *DgraphClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DgraphClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphClusterSpec) DeepCopyInto(out *DgraphClusterSpec) {
+	*out = *in
+	if in.AlphaCluster != nil {
+		in, out := &in.AlphaCluster, &out.AlphaCluster
+		*out = new(AlphaClusterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ZeroCluster != nil {
+		in, out := &in.ZeroCluster, &out.ZeroCluster
+		*out = new(ZeroClusterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ratel != nil {
+		in, out := &in.Ratel, &out.Ratel
+		*out = new(RatelSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullPolicy != nil {
+		in, out := &in.ImagePullPolicy, &out.ImagePullPolicy
+		*out = new(corev1.PullPolicy)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceAnnotations != nil {
+		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceLabels != nil {
+		in, out := &in.ServiceLabels, &out.ServiceLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PVCLabels != nil {
+		in, out := &in.PVCLabels, &out.PVCLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bootstrap != nil {
+		in, out := &in.Bootstrap, &out.Bootstrap
+		*out = new(BootstrapSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestoreFrom != nil {
+		in, out := &in.RestoreFrom, &out.RestoreFrom
+		*out = new(RestoreSpec)
+		**out = **in
+	}
+	if in.Tablets != nil {
+		in, out := &in.Tablets, &out.Tablets
+		*out = new(TabletsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(TracingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PVCRetentionPolicy != nil {
+		in, out := &in.PVCRetentionPolicy, &out.PVCRetentionPolicy
+		*out = new(PVCRetentionPolicy)
+		**out = **in
+	}
+	if in.HibernationSchedule != nil {
+		in, out := &in.HibernationSchedule, &out.HibernationSchedule
+		*out = new(HibernationScheduleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphClusterSpec.
+func (in *DgraphClusterSpec) DeepCopy() *DgraphClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphClusterStatus) DeepCopyInto(out *DgraphClusterStatus) {
+	*out = *in
+	in.AlphaCluster.DeepCopyInto(&out.AlphaCluster)
+	in.ZeroCluster.DeepCopyInto(&out.ZeroCluster)
+	in.Ratel.DeepCopyInto(&out.Ratel)
+	if in.Bootstrap != nil {
+		in, out := &in.Bootstrap, &out.Bootstrap
+		*out = new(BootstrapStatus)
+		**out = **in
+	}
+	if in.Tablets != nil {
+		in, out := &in.Tablets, &out.Tablets
+		*out = new(TabletsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeUsageTime != nil {
+		in, out := &in.VolumeUsageTime, &out.VolumeUsageTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Hibernation != nil {
+		in, out := &in.Hibernation, &out.Hibernation
+		*out = new(HibernationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphClusterStatus.
+func (in *DgraphClusterStatus) DeepCopy() *DgraphClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphComponent) DeepCopyInto(out *DgraphComponent) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphComponent.
+func (in *DgraphComponent) DeepCopy() *DgraphComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphComponentSpec) DeepCopyInto(out *DgraphComponentSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ComponentServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullPolicy != nil {
+		in, out := &in.ImagePullPolicy, &out.ImagePullPolicy
+		*out = new(corev1.PullPolicy)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceAnnotations != nil {
+		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceLabels != nil {
+		in, out := &in.ServiceLabels, &out.ServiceLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PVCLabels != nil {
+		in, out := &in.PVCLabels, &out.PVCLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(TracingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphComponentSpec.
+func (in *DgraphComponentSpec) DeepCopy() *DgraphComponentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphComponentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphConfig) DeepCopyInto(out *DgraphConfig) {
+	*out = *in
+	if in.ExtraFlags != nil {
+		in, out := &in.ExtraFlags, &out.ExtraFlags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ConfigFile != nil {
+		in, out := &in.ConfigFile, &out.ConfigFile
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphConfig.
+func (in *DgraphConfig) DeepCopy() *DgraphConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphGraphQLSchema) DeepCopyInto(out *DgraphGraphQLSchema) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphGraphQLSchema.
+func (in *DgraphGraphQLSchema) DeepCopy() *DgraphGraphQLSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphGraphQLSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DgraphGraphQLSchema) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphGraphQLSchemaList) DeepCopyInto(out *DgraphGraphQLSchemaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DgraphGraphQLSchema, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphGraphQLSchemaList.
+func (in *DgraphGraphQLSchemaList) DeepCopy() *DgraphGraphQLSchemaList {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphGraphQLSchemaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DgraphGraphQLSchemaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphGraphQLSchemaSpec) DeepCopyInto(out *DgraphGraphQLSchemaSpec) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphGraphQLSchemaSpec.
+func (in *DgraphGraphQLSchemaSpec) DeepCopy() *DgraphGraphQLSchemaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphGraphQLSchemaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphGraphQLSchemaStatus) DeepCopyInto(out *DgraphGraphQLSchemaStatus) {
+	*out = *in
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphGraphQLSchemaStatus.
+func (in *DgraphGraphQLSchemaStatus) DeepCopy() *DgraphGraphQLSchemaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphGraphQLSchemaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphLiveLoad) DeepCopyInto(out *DgraphLiveLoad) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphLiveLoad.
+func (in *DgraphLiveLoad) DeepCopy() *DgraphLiveLoad {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphLiveLoad)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DgraphLiveLoad) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphLiveLoadList) DeepCopyInto(out *DgraphLiveLoadList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DgraphLiveLoad, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphLiveLoadList.
+func (in *DgraphLiveLoadList) DeepCopy() *DgraphLiveLoadList {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphLiveLoadList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DgraphLiveLoadList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphLiveLoadSpec) DeepCopyInto(out *DgraphLiveLoadSpec) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphLiveLoadSpec.
+func (in *DgraphLiveLoadSpec) DeepCopy() *DgraphLiveLoadSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphLiveLoadSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphLiveLoadStatus) DeepCopyInto(out *DgraphLiveLoadStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphLiveLoadStatus.
+func (in *DgraphLiveLoadStatus) DeepCopy() *DgraphLiveLoadStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphLiveLoadStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphSchema) DeepCopyInto(out *DgraphSchema) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphSchema.
+func (in *DgraphSchema) DeepCopy() *DgraphSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DgraphSchema) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphSchemaList) DeepCopyInto(out *DgraphSchemaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DgraphSchema, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphSchemaList.
+func (in *DgraphSchemaList) DeepCopy() *DgraphSchemaList {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphSchemaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DgraphSchemaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphSchemaSpec) DeepCopyInto(out *DgraphSchemaSpec) {
+	*out = *in
+	if in.Predicates != nil {
+		in, out := &in.Predicates, &out.Predicates
+		*out = make([]PredicateSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]TypeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphSchemaSpec.
+func (in *DgraphSchemaSpec) DeepCopy() *DgraphSchemaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphSchemaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphSchemaStatus) DeepCopyInto(out *DgraphSchemaStatus) {
+	*out = *in
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.AppliedPredicates != nil {
+		in, out := &in.AppliedPredicates, &out.AppliedPredicates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphSchemaStatus.
+func (in *DgraphSchemaStatus) DeepCopy() *DgraphSchemaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphSchemaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphSnapshot) DeepCopyInto(out *DgraphSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphSnapshot.
+func (in *DgraphSnapshot) DeepCopy() *DgraphSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DgraphSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphSnapshotList) DeepCopyInto(out *DgraphSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DgraphSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphSnapshotList.
+func (in *DgraphSnapshotList) DeepCopy() *DgraphSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DgraphSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphSnapshotSpec) DeepCopyInto(out *DgraphSnapshotSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphSnapshotSpec.
+func (in *DgraphSnapshotSpec) DeepCopy() *DgraphSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DgraphSnapshotStatus) DeepCopyInto(out *DgraphSnapshotStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]SnapshotVolume, len(*in))
+		copy(*out, *in)
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(SnapshotSource)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DgraphSnapshotStatus.
+func (in *DgraphSnapshotStatus) DeepCopy() *DgraphSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DgraphSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayParentRef) DeepCopyInto(out *GatewayParentRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParentRef.
+func (in *GatewayParentRef) DeepCopy() *GatewayParentRef {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayParentRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayRoutesSpec) DeepCopyInto(out *GatewayRoutesSpec) {
+	*out = *in
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]GatewayParentRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayRoutesSpec.
+func (in *GatewayRoutesSpec) DeepCopy() *GatewayRoutesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayRoutesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationScheduleSpec) DeepCopyInto(out *HibernationScheduleSpec) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationScheduleSpec.
+func (in *HibernationScheduleSpec) DeepCopy() *HibernationScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationStatus) DeepCopyInto(out *HibernationStatus) {
+	*out = *in
+	if in.HibernationTime != nil {
+		in, out := &in.HibernationTime, &out.HibernationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.AlphaReplicas != nil {
+		in, out := &in.AlphaReplicas, &out.AlphaReplicas
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationStatus.
+func (in *HibernationStatus) DeepCopy() *HibernationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemberVolume) DeepCopyInto(out *MemberVolume) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberVolume.
+func (in *MemberVolume) DeepCopy() *MemberVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(MemberVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreSource) DeepCopyInto(out *ObjectStoreSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreSource.
+func (in *ObjectStoreSource) DeepCopy() *ObjectStoreSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PVCRetentionPolicy) DeepCopyInto(out *PVCRetentionPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PVCRetentionPolicy.
+func (in *PVCRetentionPolicy) DeepCopy() *PVCRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PVCRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PredicateSpec) DeepCopyInto(out *PredicateSpec) {
+	*out = *in
+	if in.Index != nil {
+		in, out := &in.Index, &out.Index
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PredicateSpec.
+func (in *PredicateSpec) DeepCopy() *PredicateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PredicateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RatelSpec) DeepCopyInto(out *RatelSpec) {
+	*out = *in
+	in.DgraphComponentSpec.DeepCopyInto(&out.DgraphComponentSpec)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(ComponentIngressSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GatewayRoutes != nil {
+		in, out := &in.GatewayRoutes, &out.GatewayRoutes
+		*out = new(GatewayRoutesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RatelSpec.
+func (in *RatelSpec) DeepCopy() *RatelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RatelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RatelStatus) DeepCopyInto(out *RatelStatus) {
+	*out = *in
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(appsv1.DeploymentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make(map[string]DgraphComponent, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RatelStatus.
+func (in *RatelStatus) DeepCopy() *RatelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RatelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreSpec) DeepCopyInto(out *RestoreSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreSpec.
+func (in *RestoreSpec) DeepCopy() *RestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicePortSpec) DeepCopyInto(out *ServicePortSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePortSpec.
+func (in *ServicePortSpec) DeepCopy() *ServicePortSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicePortSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotSource) DeepCopyInto(out *SnapshotSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotSource.
+func (in *SnapshotSource) DeepCopy() *SnapshotSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotVolume) DeepCopyInto(out *SnapshotVolume) {
+	*out = *in
+	out.MemberVolume = in.MemberVolume
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotVolume.
+func (in *SnapshotVolume) DeepCopy() *SnapshotVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageMigrationSpec) DeepCopyInto(out *StorageMigrationSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageMigrationSpec.
+func (in *StorageMigrationSpec) DeepCopy() *StorageMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageMigrationStatus) DeepCopyInto(out *StorageMigrationStatus) {
+	*out = *in
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MigratedMembers != nil {
+		in, out := &in.MigratedMembers, &out.MigratedMembers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageMigrationStatus.
+func (in *StorageMigrationStatus) DeepCopy() *StorageMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TabletPlacement) DeepCopyInto(out *TabletPlacement) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TabletPlacement.
+func (in *TabletPlacement) DeepCopy() *TabletPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(TabletPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TabletStatus) DeepCopyInto(out *TabletStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TabletStatus.
+func (in *TabletStatus) DeepCopy() *TabletStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TabletStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TabletsSpec) DeepCopyInto(out *TabletsSpec) {
+	*out = *in
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = make([]TabletPlacement, len(*in))
+		copy(*out, *in)
+	}
+	if in.RebalanceInterval != nil {
+		in, out := &in.RebalanceInterval, &out.RebalanceInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TabletsSpec.
+func (in *TabletsSpec) DeepCopy() *TabletsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TabletsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TabletsStatus) DeepCopyInto(out *TabletsStatus) {
+	*out = *in
+	if in.Tablets != nil {
+		in, out := &in.Tablets, &out.Tablets
+		*out = make([]TabletStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingMoves != nil {
+		in, out := &in.PendingMoves, &out.PendingMoves
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TabletsStatus.
+func (in *TabletsStatus) DeepCopy() *TabletsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TabletsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingAgentSpec) DeepCopyInto(out *TracingAgentSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingAgentSpec.
+func (in *TracingAgentSpec) DeepCopy() *TracingAgentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingAgentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingSpec) DeepCopyInto(out *TracingSpec) {
+	*out = *in
+	if in.Agent != nil {
+		in, out := &in.Agent, &out.Agent
+		*out = new(TracingAgentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingSpec.
+func (in *TracingSpec) DeepCopy() *TracingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TypeSpec) DeepCopyInto(out *TypeSpec) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TypeSpec.
+func (in *TypeSpec) DeepCopy() *TypeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TypeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeAutoExpandSpec) DeepCopyInto(out *VolumeAutoExpandSpec) {
+	*out = *in
+	if in.ThresholdPercent != nil {
+		in, out := &in.ThresholdPercent, &out.ThresholdPercent
+		*out = new(int32)
+		**out = **in
+	}
+	out.Increment = in.Increment.DeepCopy()
+	out.MaxSize = in.MaxSize.DeepCopy()
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeAutoExpandSpec.
+func (in *VolumeAutoExpandSpec) DeepCopy() *VolumeAutoExpandSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeAutoExpandSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeExpansionStatus) DeepCopyInto(out *VolumeExpansionStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeExpansionStatus.
+func (in *VolumeExpansionStatus) DeepCopy() *VolumeExpansionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeExpansionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeUsageStatus) DeepCopyInto(out *VolumeUsageStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeUsageStatus.
+func (in *VolumeUsageStatus) DeepCopy() *VolumeUsageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeUsageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZeroClusterSpec) DeepCopyInto(out *ZeroClusterSpec) {
+	*out = *in
+	in.DgraphComponentSpec.DeepCopyInto(&out.DgraphComponentSpec)
+	if in.PersistentStorage != nil {
+		in, out := &in.PersistentStorage, &out.PersistentStorage
+		*out = new(ComponentPersistentStorage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(ComponentStorageSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]ComponentVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AutoExpand != nil {
+		in, out := &in.AutoExpand, &out.AutoExpand
+		*out = new(VolumeAutoExpandSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(ZeroConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZeroClusterSpec.
+func (in *ZeroClusterSpec) DeepCopy() *ZeroClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ZeroClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZeroClusterStatus) DeepCopyInto(out *ZeroClusterStatus) {
+	*out = *in
+	if in.StatefulSet != nil {
 		in, out := &in.StatefulSet, &out.StatefulSet
-		*out = new(v1.StatefulSetStatus)
+		*out = new(appsv1.StatefulSetStatus)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Members != nil {
@@ -401,6 +1971,16 @@ func (in *ZeroClusterStatus) DeepCopyInto(out *ZeroClusterStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.VolumeExpansions != nil {
+		in, out := &in.VolumeExpansions, &out.VolumeExpansions
+		*out = make([]VolumeExpansionStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.VolumeUsage != nil {
+		in, out := &in.VolumeUsage, &out.VolumeUsage
+		*out = make([]VolumeUsageStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -417,7 +1997,7 @@ func (in *ZeroClusterStatus) DeepCopy() *ZeroClusterStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ZeroConfig) DeepCopyInto(out *ZeroConfig) {
 	*out = *in
-	out.DgraphConfig = in.DgraphConfig
+	in.DgraphConfig.DeepCopyInto(&out.DgraphConfig)
 	return
 }
 
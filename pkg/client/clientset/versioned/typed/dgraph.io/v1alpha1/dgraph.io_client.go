@@ -27,6 +27,10 @@ import (
 type DgraphV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	DgraphClustersGetter
+	DgraphGraphQLSchemasGetter
+	DgraphLiveLoadsGetter
+	DgraphSchemasGetter
+	DgraphSnapshotsGetter
 }
 
 // DgraphV1alpha1Client is used to interact with features provided by the dgraph.io group.
@@ -38,6 +42,22 @@ func (c *DgraphV1alpha1Client) DgraphClusters(namespace string) DgraphClusterInt
 	return newDgraphClusters(c, namespace)
 }
 
+func (c *DgraphV1alpha1Client) DgraphGraphQLSchemas(namespace string) DgraphGraphQLSchemaInterface {
+	return newDgraphGraphQLSchemas(c, namespace)
+}
+
+func (c *DgraphV1alpha1Client) DgraphLiveLoads(namespace string) DgraphLiveLoadInterface {
+	return newDgraphLiveLoads(c, namespace)
+}
+
+func (c *DgraphV1alpha1Client) DgraphSchemas(namespace string) DgraphSchemaInterface {
+	return newDgraphSchemas(c, namespace)
+}
+
+func (c *DgraphV1alpha1Client) DgraphSnapshots(namespace string) DgraphSnapshotInterface {
+	return newDgraphSnapshots(c, namespace)
+}
+
 // NewForConfig creates a new DgraphV1alpha1Client for the given config.
 func NewForConfig(c *rest.Config) (*DgraphV1alpha1Client, error) {
 	config := *c
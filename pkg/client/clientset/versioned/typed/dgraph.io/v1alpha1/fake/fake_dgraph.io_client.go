@@ -32,6 +32,22 @@ func (c *FakeDgraphV1alpha1) DgraphClusters(namespace string) v1alpha1.DgraphClu
 	return &FakeDgraphClusters{c, namespace}
 }
 
+func (c *FakeDgraphV1alpha1) DgraphGraphQLSchemas(namespace string) v1alpha1.DgraphGraphQLSchemaInterface {
+	return &FakeDgraphGraphQLSchemas{c, namespace}
+}
+
+func (c *FakeDgraphV1alpha1) DgraphLiveLoads(namespace string) v1alpha1.DgraphLiveLoadInterface {
+	return &FakeDgraphLiveLoads{c, namespace}
+}
+
+func (c *FakeDgraphV1alpha1) DgraphSchemas(namespace string) v1alpha1.DgraphSchemaInterface {
+	return &FakeDgraphSchemas{c, namespace}
+}
+
+func (c *FakeDgraphV1alpha1) DgraphSnapshots(namespace string) v1alpha1.DgraphSnapshotInterface {
+	return &FakeDgraphSnapshots{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeDgraphV1alpha1) RESTClient() rest.Interface {
@@ -19,3 +19,11 @@
 package v1alpha1
 
 type DgraphClusterExpansion interface{}
+
+type DgraphGraphQLSchemaExpansion interface{}
+
+type DgraphLiveLoadExpansion interface{}
+
+type DgraphSchemaExpansion interface{}
+
+type DgraphSnapshotExpansion interface{}
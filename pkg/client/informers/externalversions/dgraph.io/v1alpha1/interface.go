@@ -26,6 +26,14 @@ import (
 type Interface interface {
 	// DgraphClusters returns a DgraphClusterInformer.
 	DgraphClusters() DgraphClusterInformer
+	// DgraphGraphQLSchemas returns a DgraphGraphQLSchemaInformer.
+	DgraphGraphQLSchemas() DgraphGraphQLSchemaInformer
+	// DgraphLiveLoads returns a DgraphLiveLoadInformer.
+	DgraphLiveLoads() DgraphLiveLoadInformer
+	// DgraphSchemas returns a DgraphSchemaInformer.
+	DgraphSchemas() DgraphSchemaInformer
+	// DgraphSnapshots returns a DgraphSnapshotInformer.
+	DgraphSnapshots() DgraphSnapshotInformer
 }
 
 type version struct {
@@ -43,3 +51,23 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) DgraphClusters() DgraphClusterInformer {
 	return &dgraphClusterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// DgraphGraphQLSchemas returns a DgraphGraphQLSchemaInformer.
+func (v *version) DgraphGraphQLSchemas() DgraphGraphQLSchemaInformer {
+	return &dgraphGraphQLSchemaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// DgraphLiveLoads returns a DgraphLiveLoadInformer.
+func (v *version) DgraphLiveLoads() DgraphLiveLoadInformer {
+	return &dgraphLiveLoadInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// DgraphSchemas returns a DgraphSchemaInformer.
+func (v *version) DgraphSchemas() DgraphSchemaInformer {
+	return &dgraphSchemaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// DgraphSnapshots returns a DgraphSnapshotInformer.
+func (v *version) DgraphSnapshots() DgraphSnapshotInformer {
+	return &dgraphSnapshotInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -55,6 +55,14 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=dgraph.io, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithResource("dgraphclusters"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Dgraph().V1alpha1().DgraphClusters().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("dgraphgraphqlschemas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Dgraph().V1alpha1().DgraphGraphQLSchemas().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("dgraphliveloads"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Dgraph().V1alpha1().DgraphLiveLoads().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("dgraphschemas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Dgraph().V1alpha1().DgraphSchemas().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("dgraphsnapshots"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Dgraph().V1alpha1().DgraphSnapshots().Informer()}, nil
 
 	}
 
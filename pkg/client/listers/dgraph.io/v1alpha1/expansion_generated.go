@@ -25,3 +25,35 @@ type DgraphClusterListerExpansion interface{}
 // DgraphClusterNamespaceListerExpansion allows custom methods to be added to
 // DgraphClusterNamespaceLister.
 type DgraphClusterNamespaceListerExpansion interface{}
+
+// DgraphGraphQLSchemaListerExpansion allows custom methods to be added to
+// DgraphGraphQLSchemaLister.
+type DgraphGraphQLSchemaListerExpansion interface{}
+
+// DgraphGraphQLSchemaNamespaceListerExpansion allows custom methods to be added to
+// DgraphGraphQLSchemaNamespaceLister.
+type DgraphGraphQLSchemaNamespaceListerExpansion interface{}
+
+// DgraphLiveLoadListerExpansion allows custom methods to be added to
+// DgraphLiveLoadLister.
+type DgraphLiveLoadListerExpansion interface{}
+
+// DgraphLiveLoadNamespaceListerExpansion allows custom methods to be added to
+// DgraphLiveLoadNamespaceLister.
+type DgraphLiveLoadNamespaceListerExpansion interface{}
+
+// DgraphSchemaListerExpansion allows custom methods to be added to
+// DgraphSchemaLister.
+type DgraphSchemaListerExpansion interface{}
+
+// DgraphSchemaNamespaceListerExpansion allows custom methods to be added to
+// DgraphSchemaNamespaceLister.
+type DgraphSchemaNamespaceListerExpansion interface{}
+
+// DgraphSnapshotListerExpansion allows custom methods to be added to
+// DgraphSnapshotLister.
+type DgraphSnapshotListerExpansion interface{}
+
+// DgraphSnapshotNamespaceListerExpansion allows custom methods to be added to
+// DgraphSnapshotNamespaceLister.
+type DgraphSnapshotNamespaceListerExpansion interface{}
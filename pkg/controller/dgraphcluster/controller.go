@@ -37,6 +37,7 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
 	k8sinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -79,22 +80,37 @@ type Controller struct {
 	// These manager shares the same kubernetes listers to interact with the API server.
 	// These managers are run sequentially and thus must be present in the order required
 	// for underlying resources.
-	// For example in case of DgraphCluster we have three resources managers:
-	// * AlphaManager
+	// For example in case of DgraphCluster we have these resources managers:
+	// * RestoreManager
+	// * HibernationManager
 	// * ZeroManager
+	// * BootstrapManager
+	// * AutoscalingManager
+	// * AlphaManager
+	// * PVCRetentionManager
+	// * VolumeUsageManager
 	// * RatelManager
+	// * IngressManager
+	// * TabletManager
 	//
 	// For a proper dgraph cluster provisioning we assume to ensure the following
 	// order in their individual syncs:
-	// Zero -> Alpha -> Ratel
+	// Restore -> Hibernation -> Zero -> Bootstrap -> Autoscaling -> Alpha -> PVCRetention ->
+	// VolumeUsage -> Ratel -> Ingress -> Tablets
 	// and they should be present in this particular order in the managers list.
 	managers []manager.Manager
+
+	// pvcRetentionManager is also used to delete the persistent volume claims of the
+	// DgraphCluster objects being deleted, before their finalizer is removed.
+	pvcRetentionManager *manager.PVCRetentionManager
 }
 
 // NewController returns a new DgraphCluster controller.
 func NewController(k8sClient kubernetes.Interface,
 	dgraphClient versioned.Interface,
+	dynamicClient dynamic.Interface,
 	dgraphClusterInformer dgraphinformer.DgraphClusterInformer,
+	dgraphSnapshotInformer dgraphinformer.DgraphSnapshotInformer,
 	k8sInformerFactory k8sinformers.SharedInformerFactory) *Controller {
 
 	utilruntime.Must(dgraphscheme.AddToScheme(scheme.Scheme))
@@ -132,6 +148,17 @@ func NewController(k8sClient kubernetes.Interface,
 		},
 		UpdateFunc: func(old, cur interface{}) {
 			glog.Info("dgraph-cluster-controller: update on DgraphClsuter CRD invoked.")
+			// Status is updated by the controller itself on every sync and carries
+			// metrics which change all the time, syncing on status updates would keep
+			// the cluster syncing in a loop. Periodic resyncs have the same resource
+			// version and are not skipped.
+			oldDC := old.(*dgraphio.DgraphCluster)
+			curDC := cur.(*dgraphio.DgraphCluster)
+			if oldDC.ResourceVersion != curDC.ResourceVersion &&
+				oldDC.Generation == curDC.Generation &&
+				oldDC.DeletionTimestamp.Equal(curDC.DeletionTimestamp) {
+				return
+			}
 			ctrl.enqueueObj(cur)
 		},
 		DeleteFunc: func(obj interface{}) {
@@ -145,22 +172,72 @@ func NewController(k8sClient kubernetes.Interface,
 	svcLister := k8sInformerFactory.Core().V1().Services().Lister()
 	statefulSetLister := k8sInformerFactory.Apps().V1().StatefulSets().Lister()
 	deploymentLister := k8sInformerFactory.Apps().V1().Deployments().Lister()
+	pvcLister := k8sInformerFactory.Core().V1().PersistentVolumeClaims().Lister()
+	jobLister := k8sInformerFactory.Batch().V1().Jobs().Lister()
+	configMapLister := k8sInformerFactory.Core().V1().ConfigMaps().Lister()
+	ingressLister := k8sInformerFactory.Networking().V1beta1().Ingresses().Lister()
+	storageClassLister := k8sInformerFactory.Storage().V1().StorageClasses().Lister()
 
 	// setup managers for DgraphCluster resources.
 	// These managers must be synced in this particular order only.
-	// Zero -> Alpha -> Ratel
+	// Restore -> Hibernation -> Zero -> Bootstrap -> Autoscaling -> Alpha -> PVCRetention ->
+	// VolumeUsage -> Ratel -> Ingress -> Tablets
 	managers := make([]manager.Manager, 0)
+	managers = append(managers, manager.NewRestoreManager(
+		k8sClient,
+		pvcLister,
+		statefulSetLister,
+		dgraphSnapshotInformer.Lister(),
+	))
+	managers = append(managers, manager.NewHibernationManager(
+		podsLister,
+		statefulSetLister,
+		deploymentLister,
+	))
 	managers = append(managers, manager.NewZeroManager(
 		k8sClient,
 		podsLister,
 		svcLister,
 		statefulSetLister,
+		configMapLister,
+		pvcLister,
+		storageClassLister,
+	))
+	managers = append(managers, manager.NewBootstrapManager(
+		k8sClient,
+		pvcLister,
+		statefulSetLister,
+		jobLister,
+	))
+	managers = append(managers, manager.NewAutoscalingManager(
+		k8sClient,
+		podsLister,
+		pvcLister,
+		statefulSetLister,
 	))
 	managers = append(managers, manager.NewAlphaManager(
 		k8sClient,
 		podsLister,
 		svcLister,
 		statefulSetLister,
+		configMapLister,
+		pvcLister,
+		storageClassLister,
+		jobLister,
+	))
+	ctrl.pvcRetentionManager = manager.NewPVCRetentionManager(
+		k8sClient,
+		podsLister,
+		pvcLister,
+		statefulSetLister,
+	)
+	managers = append(managers, ctrl.pvcRetentionManager)
+	managers = append(managers, manager.NewVolumeUsageManager(
+		k8sClient,
+		recorder,
+		podsLister,
+		pvcLister,
+		storageClassLister,
 	))
 	managers = append(managers, manager.NewRatelManager(
 		k8sClient,
@@ -168,6 +245,14 @@ func NewController(k8sClient kubernetes.Interface,
 		svcLister,
 		deploymentLister,
 	))
+	managers = append(managers, manager.NewIngressManager(
+		k8sClient,
+		dynamicClient,
+		ingressLister,
+	))
+	managers = append(managers, manager.NewTabletManager(
+		statefulSetLister,
+	))
 
 	ctrl.managers = managers
 
@@ -268,9 +353,11 @@ func (dc *Controller) processNextWorkItem() bool {
 // Syncs the DgraphCluster resource represented by `key`
 func (dc *Controller) sync(key string) error {
 	startTime := time.Now()
-	defer glog.Infof("dgraph-cluster-controller: DgraphCluster sync done %q (%v)",
-		key,
-		time.Since(startTime))
+	defer func() {
+		glog.Infof("dgraph-cluster-controller: DgraphCluster sync done %q (%v)",
+			key,
+			time.Since(startTime))
+	}()
 
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
@@ -292,6 +379,12 @@ func (dc *Controller) sync(key string) error {
 		return err
 	}
 
+	// Persistent volume claims are not owned by the DgraphCluster, the cluster is only
+	// deleted once they have been taken care of according to its retention policy.
+	if cluster.GetDeletionTimestamp() != nil {
+		return dc.FinalizeDgraphCluster(cluster.DeepCopy())
+	}
+
 	// Update the dgraph cluster based on the latest object we got from the
 	// kubernetes API.
 	// Each controller similar to DgraphCluster one must implement an update function which
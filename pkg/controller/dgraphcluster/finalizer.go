@@ -0,0 +1,108 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dgraphcluster
+
+import (
+	dgraphio "github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+	"github.com/golang/glog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// FinalizeDgraphCluster handles the deletion of the DgraphCluster object represented by
+// dcObj. The persistent volume claims of its members are deleted if its retention policy
+// asks for it, then its finalizer is removed so that kubernetes deletes it along with the
+// resources it owns.
+func (dc *Controller) FinalizeDgraphCluster(dcObj *dgraphio.DgraphCluster) error {
+	if !hasPVCRetentionFinalizer(dcObj) {
+		return nil
+	}
+
+	glog.Infof("dgraph-cluster-controller: finalizing deleted DgraphCluster %s",
+		dcObj.GetName())
+	if err := dc.pvcRetentionManager.Finalize(dcObj); err != nil {
+		return err
+	}
+
+	return dc.updatePVCRetentionFinalizer(dcObj, false)
+}
+
+// syncPVCRetentionFinalizer adds the finalizer of the DgraphCluster object represented by
+// dcObj if the persistent volume claims of its members must be deleted along with it, and
+// removes it otherwise so that deleting the cluster isn't held back.
+func (dc *Controller) syncPVCRetentionFinalizer(dcObj *dgraphio.DgraphCluster) error {
+	required := dcObj.Spec.PVCRetentionPolicy.DeleteWhenDeleted()
+	if hasPVCRetentionFinalizer(dcObj) == required {
+		return nil
+	}
+
+	return dc.updatePVCRetentionFinalizer(dcObj, required)
+}
+
+// updatePVCRetentionFinalizer adds or removes the finalizer of the DgraphCluster object
+// represented by dcObj, which is updated with the resulting metadata.
+func (dc *Controller) updatePVCRetentionFinalizer(dcObj *dgraphio.DgraphCluster,
+	add bool) error {
+	glog.Infof("dgraph-cluster-controller: updating DgraphCluster %s finalizers",
+		dcObj.GetName())
+	ns := dcObj.GetNamespace()
+	name := dcObj.GetName()
+
+	latest := dcObj.DeepCopy()
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		update := latest.DeepCopy()
+		update.Finalizers = make([]string, 0, len(latest.Finalizers)+1)
+		for _, finalizer := range latest.Finalizers {
+			if finalizer != defaults.PVCRetentionFinalizer {
+				update.Finalizers = append(update.Finalizers, finalizer)
+			}
+		}
+		if add {
+			update.Finalizers = append(update.Finalizers, defaults.PVCRetentionFinalizer)
+		}
+
+		updated, updateErr := dc.dgraphClient.DgraphV1alpha1().DgraphClusters(ns).Update(update)
+		if updateErr == nil {
+			dcObj.SetResourceVersion(updated.GetResourceVersion())
+			dcObj.SetFinalizers(updated.GetFinalizers())
+			return nil
+		}
+
+		// Fetch the latest version of the object on conflict and retry with it.
+		fetched, err := dc.dgraphClient.DgraphV1alpha1().DgraphClusters(ns).
+			Get(name, metav1.GetOptions{})
+		if err != nil {
+			glog.Errorf("dgraph-cluster-controller: error getting DgraphCluster %s: %s", name, err)
+			return updateErr
+		}
+		latest = fetched
+		return updateErr
+	})
+}
+
+// hasPVCRetentionFinalizer returns true if the provided DgraphCluster object has the
+// finalizer deleting the persistent volume claims of its members.
+func hasPVCRetentionFinalizer(dcObj *dgraphio.DgraphCluster) bool {
+	for _, finalizer := range dcObj.GetFinalizers() {
+		if finalizer == defaults.PVCRetentionFinalizer {
+			return true
+		}
+	}
+
+	return false
+}
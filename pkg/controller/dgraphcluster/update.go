@@ -20,7 +20,10 @@ import (
 	"reflect"
 
 	dgraphio "github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	dgraphk8s "github.com/dgraph-io/dgraph-operator/pkg/k8s/dgraph"
 	"github.com/golang/glog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
 )
 
 // UpdateDgraphCluster function handles an udpate event on dgraph cluster object.
@@ -28,6 +31,23 @@ func (dc *Controller) UpdateDgraphCluster(dcObj *dgraphio.DgraphCluster) error {
 	// We preserve the oldStatus to use later if we need to update it.
 	oldStatus := dcObj.Status.DeepCopy()
 
+	if err := dc.syncPVCRetentionFinalizer(dcObj); err != nil {
+		return err
+	}
+
+	// Resources can only be built for the dgraph versions we know the command line
+	// flags of.
+	if err := dgraphk8s.ValidateVersions(dcObj); err != nil {
+		glog.Errorf("dgraph-cluster-controller: invalid DgraphCluster %s: %s",
+			dcObj.GetName(), err)
+		return err
+	}
+	if err := dcObj.ValidateStorage(); err != nil {
+		glog.Errorf("dgraph-cluster-controller: invalid DgraphCluster %s: %s",
+			dcObj.GetName(), err)
+		return err
+	}
+
 	// During update we relay the logic of update to the respective managers which
 	// are the managers for individual top level resource as understood by DgraphCluster
 	// This is because they may have different strategies for update being different in
@@ -41,21 +61,24 @@ func (dc *Controller) UpdateDgraphCluster(dcObj *dgraphio.DgraphCluster) error {
 	// Each manager individually syncs the underlying kubernetes resources it manages.
 	// These sync are performed sequentially, so order in controller managers list
 	// must be taken care of.
+	var syncErr error
 	for _, manager := range dc.managers {
-		if err := manager.Sync(dcObj); err != nil {
-			return err
+		if syncErr = manager.Sync(dcObj); syncErr != nil {
+			break
 		}
 	}
 
 	// Check if the status is same as the old status or not, if not then udpate the
 	// status of the DgraphCluster object.
-	if !reflect.DeepEqual(dcObj.Status, oldStatus) {
+	// Status is updated even if a manager failed, as managers record the progress
+	// of multi step operations in it.
+	if !reflect.DeepEqual(dcObj.Status, *oldStatus) {
 		if err := dc.UpdateDgraphClusterStatus(dcObj, &dcObj.Status); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return syncErr
 }
 
 // UpdateDgraphClusterStatus updates the status of the DgraphCluster object represented by dcObj
@@ -65,5 +88,25 @@ func (dc *Controller) UpdateDgraphClusterStatus(
 	dcStatus *dgraphio.DgraphClusterStatus) error {
 
 	glog.Infof("dgraph-cluster-controller: updating DgraphCluster %s status", dcObj.GetName())
-	return nil
+	ns := dcObj.GetNamespace()
+	name := dcObj.GetName()
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		update := dcObj.DeepCopy()
+		update.Status = *dcStatus.DeepCopy()
+		_, updateErr := dc.dgraphClient.DgraphV1alpha1().DgraphClusters(ns).UpdateStatus(update)
+		if updateErr == nil {
+			return nil
+		}
+
+		// Fetch the latest version of the object on conflict and retry with it.
+		latest, err := dc.dgraphClient.DgraphV1alpha1().DgraphClusters(ns).
+			Get(name, metav1.GetOptions{})
+		if err != nil {
+			glog.Errorf("dgraph-cluster-controller: error getting DgraphCluster %s: %s", name, err)
+			return updateErr
+		}
+		dcObj = latest
+		return updateErr
+	})
 }
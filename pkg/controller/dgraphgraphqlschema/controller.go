@@ -0,0 +1,269 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dgraphgraphqlschema
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dgraphio "github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/client/clientset/versioned"
+	dgraphscheme "github.com/dgraph-io/dgraph-operator/pkg/client/clientset/versioned/scheme"
+	// nolint
+	dgraphinformer "github.com/dgraph-io/dgraph-operator/pkg/client/informers/externalversions/dgraph.io/v1alpha1"
+	listers "github.com/dgraph-io/dgraph-operator/pkg/client/listers/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/k8s"
+	"github.com/dgraph-io/dgraph-operator/pkg/option"
+
+	"github.com/golang/glog"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8sinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// Controller is the controller to manage the DgraphGraphQLSchema custom
+// resource created in the Kubernetes cluster.
+//
+// The controller pushes the GraphQL schema of each DgraphGraphQLSchema to the
+// /admin endpoint of the alpha of the referenced DgraphCluster. The live schema
+// is compared with the specification on every resync of the informer, so changes
+// made out of band are reverted.
+type Controller struct {
+	// k8sClient is the client interface to connect to the kube API server.
+	k8sClient kubernetes.Interface
+
+	// dgraphClient is the client interface to interacting with dgraph related
+	// custom resources.
+	dgraphClient versioned.Interface
+
+	dgraphGraphQLSchemaLister listers.DgraphGraphQLSchemaLister
+	dgraphGraphQLSchemaSynced cache.InformerSynced
+
+	dgraphClusterLister listers.DgraphClusterLister
+	dgraphClusterSynced cache.InformerSynced
+
+	statefulSetLister appslisters.StatefulSetLister
+	statefulSetSynced cache.InformerSynced
+
+	configMapLister corelisters.ConfigMapLister
+	configMapSynced cache.InformerSynced
+
+	// workqueue is a rate limited work queue of DgraphGraphQLSchema keys to sync.
+	workqueue workqueue.RateLimitingInterface
+}
+
+// NewController returns a new DgraphGraphQLSchema controller.
+func NewController(k8sClient kubernetes.Interface,
+	dgraphClient versioned.Interface,
+	dgraphGraphQLSchemaInformer dgraphinformer.DgraphGraphQLSchemaInformer,
+	dgraphClusterInformer dgraphinformer.DgraphClusterInformer,
+	k8sInformerFactory k8sinformers.SharedInformerFactory) *Controller {
+
+	utilruntime.Must(dgraphscheme.AddToScheme(scheme.Scheme))
+
+	workqueue := workqueue.NewNamedRateLimitingQueue(
+		workqueue.DefaultControllerRateLimiter(),
+		"DgraphGraphQLSchemas")
+
+	statefulSetInformer := k8sInformerFactory.Apps().V1().StatefulSets()
+	configMapInformer := k8sInformerFactory.Core().V1().ConfigMaps()
+
+	ctrl := &Controller{
+		k8sClient:    k8sClient,
+		dgraphClient: dgraphClient,
+
+		dgraphGraphQLSchemaLister: dgraphGraphQLSchemaInformer.Lister(),
+		dgraphGraphQLSchemaSynced: dgraphGraphQLSchemaInformer.Informer().HasSynced,
+
+		dgraphClusterLister: dgraphClusterInformer.Lister(),
+		dgraphClusterSynced: dgraphClusterInformer.Informer().HasSynced,
+
+		statefulSetLister: statefulSetInformer.Lister(),
+		statefulSetSynced: statefulSetInformer.Informer().HasSynced,
+
+		configMapLister: configMapInformer.Lister(),
+		configMapSynced: configMapInformer.Informer().HasSynced,
+
+		workqueue: workqueue,
+	}
+
+	// event handlers for DgraphGraphQLSchema custom kubernetes resource.
+	dgraphGraphQLSchemaInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			glog.Info("dgraph-graphql-schema-controller: add on DgraphGraphQLSchema " +
+				"CRD invoked.")
+			ctrl.enqueueObj(obj)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			glog.Info("dgraph-graphql-schema-controller: update on DgraphGraphQLSchema " +
+				"CRD invoked.")
+			ctrl.enqueueObj(cur)
+		},
+	})
+
+	// Config maps are watched to apply the changes of the schemas they hold.
+	configMapInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: ctrl.enqueueConfigMapSchemas,
+		UpdateFunc: func(old, cur interface{}) {
+			ctrl.enqueueConfigMapSchemas(cur)
+		},
+	})
+
+	return ctrl
+}
+
+// Run runs the actual underlying DgraphGraphQLSchema controller.
+func (dg *Controller) Run(ctx context.Context) {
+	glog.Info("dgraph-graphql-schema-controller: starting to run DgraphGraphQLSchema controller")
+
+	// Kubernetes specific controller teardown logic.
+	defer utilruntime.HandleCrash()
+	defer dg.workqueue.ShutDown()
+
+	// Wait for CRD to be ready, skip if any error occurs.
+	if err := k8s.WaitForCRD(dgraphio.DgraphGraphQLSchemaCRDName); err != nil {
+		glog.Warningf("dgraph-graphql-schema-controller: error while waiting for CRD "+
+			"to be ready: %s\nignoring failure", err)
+	}
+
+	glog.Info("dgraph-graphql-schema-controller: waiting for informer cache to sync")
+	if ok := cache.WaitForCacheSync(ctx.Done(),
+		dg.dgraphGraphQLSchemaSynced,
+		dg.dgraphClusterSynced,
+		dg.statefulSetSynced,
+		dg.configMapSynced); !ok {
+		glog.Fatalf("dgraph-graphql-schema-controller: error while syncing informer " +
+			"cache, exitting")
+	}
+	glog.Info("dgraph-graphql-schema-controller: informer cache synced.")
+
+	// Run WorkersCount number of workers to process the work from the queue.
+	for i := 0; i < option.OperatorConfig.WorkersCount; i++ {
+		go wait.Until(dg.runWorker, time.Second, ctx.Done())
+	}
+
+	glog.Info("dgraph-graphql-schema-controller: started workers")
+	<-ctx.Done()
+	glog.Info("dgraph-graphql-schema-controller: shutting down workers")
+}
+
+func (dg *Controller) runWorker() {
+	for dg.processNextWorkItem() {
+	}
+}
+
+// process a work item from the workqueue.
+func (dg *Controller) processNextWorkItem() bool {
+	obj, shutdown := dg.workqueue.Get()
+	if shutdown {
+		return false
+	}
+	defer dg.workqueue.Done(obj)
+
+	objKey, ok := obj.(string)
+	if !ok {
+		dg.workqueue.Forget(obj)
+		utilruntime.HandleError(fmt.Errorf("dgraph-graphql-schema-controller: expected "+
+			"string in workqueue but got %#v", obj))
+		return true
+	}
+
+	if err := dg.sync(objKey); err != nil {
+		// Put the item back on the workqueue to handle any transient errors.
+		dg.workqueue.AddRateLimited(objKey)
+		glog.Errorf("dgraph-graphql-schema-controller: error syncing '%s': %s, requeuing",
+			objKey, err)
+		return true
+	}
+
+	dg.workqueue.Forget(obj)
+	glog.Infof("dgraph-graphql-schema-controller: successfully synced '%s'", objKey)
+
+	return true
+}
+
+// Syncs the DgraphGraphQLSchema resource represented by `key`
+func (dg *Controller) sync(key string) error {
+	startTime := time.Now()
+	defer func() {
+		glog.Infof("dgraph-graphql-schema-controller: DgraphGraphQLSchema sync done %q (%v)",
+			key,
+			time.Since(startTime))
+	}()
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	schema, err := dg.dgraphGraphQLSchemaLister.DgraphGraphQLSchemas(namespace).Get(name)
+	if kerrors.IsNotFound(err) {
+		// Deleting a DgraphGraphQLSchema leaves the schema of the cluster untouched.
+		glog.Infof("dgraph-graphql-schema-controller: DgraphGraphQLSchema(%q) has already "+
+			"been deleted", key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return dg.UpdateDgraphGraphQLSchema(schema.DeepCopy())
+}
+
+// enqueueObj enqueues the object to the work queue.
+func (dg *Controller) enqueueObj(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("dgraph-graphql-schema-controller: cound't get "+
+			"key for object %+v: %v", obj, err))
+		return
+	}
+	glog.Infof("dgraph-graphql-schema-controller: enqueuing %q in workqueue", key)
+	dg.workqueue.Add(key)
+}
+
+// enqueueConfigMapSchemas enqueues the DgraphGraphQLSchemas referencing the config map.
+func (dg *Controller) enqueueConfigMapSchemas(obj interface{}) {
+	cm, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return
+	}
+
+	schemas, err := dg.dgraphGraphQLSchemaLister.DgraphGraphQLSchemas(cm.GetNamespace()).
+		List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("dgraph-graphql-schema-controller: error "+
+			"listing DgraphGraphQLSchemas: %v", err))
+		return
+	}
+	for _, schema := range schemas {
+		ref := schema.Spec.ConfigMapRef
+		if ref != nil && ref.Name == cm.GetName() {
+			dg.enqueueObj(schema)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dgraphgraphqlschema
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"reflect"
+
+	dgraphio "github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/dgraph"
+	dgraphk8s "github.com/dgraph-io/dgraph-operator/pkg/k8s/dgraph"
+	"github.com/dgraph-io/dgraph-operator/pkg/manager"
+
+	"github.com/golang/glog"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// UpdateDgraphGraphQLSchema function handles an update event on dgraph GraphQL schema
+// object. It applies the GraphQL schema to the cluster if the live schema differs from
+// the specification.
+func (dg *Controller) UpdateDgraphGraphQLSchema(dgObj *dgraphio.DgraphGraphQLSchema) error {
+	oldStatus := dgObj.Status.DeepCopy()
+
+	syncErr := dg.syncGraphQLSchema(dgObj)
+	if syncErr != nil {
+		dgObj.Status.Phase = dgraphio.SchemaPhaseFailed
+		dgObj.Status.Message = syncErr.Error()
+	}
+
+	if !reflect.DeepEqual(dgObj.Status, *oldStatus) {
+		if err := dg.UpdateDgraphGraphQLSchemaStatus(dgObj, &dgObj.Status); err != nil {
+			return err
+		}
+	}
+
+	return syncErr
+}
+
+// syncGraphQLSchema syncs the live GraphQL schema of the cluster with the
+// DgraphGraphQLSchema specification and records the result in its status.
+func (dg *Controller) syncGraphQLSchema(dgObj *dgraphio.DgraphGraphQLSchema) error {
+	ns := dgObj.GetNamespace()
+	status := &dgObj.Status
+	spec := &dgObj.Spec
+
+	schema, err := dg.schemaSDL(dgObj)
+	if err != nil {
+		return err
+	}
+
+	dc, err := dg.dgraphClusterLister.DgraphClusters(ns).Get(spec.ClusterName)
+	if kerrors.IsNotFound(err) {
+		status.Phase = dgraphio.SchemaPhasePending
+		status.Message = fmt.Sprintf("waiting for DgraphCluster %s to be created",
+			spec.ClusterName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	ready, err := manager.AlphaReady(dg.statefulSetLister, dc)
+	if err != nil {
+		return err
+	}
+	if !ready {
+		status.Phase = dgraphio.SchemaPhasePending
+		status.Message = fmt.Sprintf("waiting for alpha of DgraphCluster %s to be ready",
+			dc.GetName())
+		return nil
+	}
+
+	client := dgraph.NewClient(dgraphk8s.AlphaHTTPAddress(dc))
+	live, err := client.GraphQLSchema()
+	if err != nil {
+		return err
+	}
+
+	status.ObservedGeneration = dgObj.GetGeneration()
+	hash := schemaHash(schema)
+	if schemaHash(live) == hash {
+		status.Phase = dgraphio.SchemaPhaseSynced
+		status.Message = "live GraphQL schema matches the specification"
+		status.AppliedHash = hash
+		return nil
+	}
+
+	if status.AppliedHash == hash {
+		glog.Infof("dgraph-graphql-schema-controller: GraphQL schema of cluster %s was "+
+			"changed out of band, re-applying %s", dc.GetName(), dgObj.GetName())
+	} else {
+		glog.Infof("dgraph-graphql-schema-controller: applying GraphQL schema %s to "+
+			"cluster %s", dgObj.GetName(), dc.GetName())
+	}
+	if err := client.UpdateGraphQLSchema(schema); err != nil {
+		return err
+	}
+
+	now := metav1.Now()
+	status.LastAppliedTime = &now
+	status.AppliedHash = hash
+	status.Phase = dgraphio.SchemaPhaseSynced
+	status.Message = "GraphQL schema applied"
+	return nil
+}
+
+// schemaSDL returns the GraphQL SDL of the DgraphGraphQLSchema, either inline or from
+// the referenced config map.
+func (dg *Controller) schemaSDL(dgObj *dgraphio.DgraphGraphQLSchema) (string, error) {
+	ref := dgObj.Spec.ConfigMapRef
+	if ref == nil {
+		if dgObj.Spec.Schema == "" {
+			return "", fmt.Errorf("one of schema or configMapRef must be specified")
+		}
+		return dgObj.Spec.Schema, nil
+	}
+
+	cm, err := dg.configMapLister.ConfigMaps(dgObj.GetNamespace()).Get(ref.Name)
+	if err != nil {
+		return "", fmt.Errorf("error getting config map %s: %s", ref.Name, err)
+	}
+	schema, ok := cm.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("config map %s has no key %s", ref.Name, ref.Key)
+	}
+
+	return schema, nil
+}
+
+// schemaHash returns the hex encoded SHA-256 hash of the GraphQL SDL.
+func schemaHash(schema string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(schema)))
+}
+
+// UpdateDgraphGraphQLSchemaStatus updates the status of the DgraphGraphQLSchema object
+// represented by dgObj with the status represented in dgStatus.
+func (dg *Controller) UpdateDgraphGraphQLSchemaStatus(
+	dgObj *dgraphio.DgraphGraphQLSchema,
+	dgStatus *dgraphio.DgraphGraphQLSchemaStatus) error {
+
+	glog.Infof("dgraph-graphql-schema-controller: updating DgraphGraphQLSchema %s status",
+		dgObj.GetName())
+	ns := dgObj.GetNamespace()
+	name := dgObj.GetName()
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		update := dgObj.DeepCopy()
+		update.Status = *dgStatus.DeepCopy()
+		_, updateErr := dg.dgraphClient.DgraphV1alpha1().DgraphGraphQLSchemas(ns).
+			UpdateStatus(update)
+		if updateErr == nil {
+			return nil
+		}
+
+		// Fetch the latest version of the object on conflict and retry with it.
+		latest, err := dg.dgraphClient.DgraphV1alpha1().DgraphGraphQLSchemas(ns).
+			Get(name, metav1.GetOptions{})
+		if err != nil {
+			glog.Errorf("dgraph-graphql-schema-controller: error getting "+
+				"DgraphGraphQLSchema %s: %s", name, err)
+			return updateErr
+		}
+		dgObj = latest
+		return updateErr
+	})
+}
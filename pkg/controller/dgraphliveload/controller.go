@@ -0,0 +1,259 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dgraphliveload
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dgraphio "github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/client/clientset/versioned"
+	dgraphscheme "github.com/dgraph-io/dgraph-operator/pkg/client/clientset/versioned/scheme"
+	// nolint
+	dgraphinformer "github.com/dgraph-io/dgraph-operator/pkg/client/informers/externalversions/dgraph.io/v1alpha1"
+	listers "github.com/dgraph-io/dgraph-operator/pkg/client/listers/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/k8s"
+	"github.com/dgraph-io/dgraph-operator/pkg/option"
+
+	"github.com/golang/glog"
+	batchv1 "k8s.io/api/batch/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8sinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// Controller is the controller to manage the DgraphLiveLoad custom
+// resource created in the Kubernetes cluster.
+//
+// For each DgraphLiveLoad the controller runs a single dgraph live loader job
+// against the referenced DgraphCluster and mirrors the status of the job in
+// the status of the DgraphLiveLoad.
+type Controller struct {
+	// k8sClient is the client interface to connect to the kube API server.
+	k8sClient kubernetes.Interface
+
+	// dgraphClient is the client interface to interacting with dgraph related
+	// custom resources.
+	dgraphClient versioned.Interface
+
+	dgraphLiveLoadLister listers.DgraphLiveLoadLister
+	dgraphLiveLoadSynced cache.InformerSynced
+
+	dgraphClusterLister listers.DgraphClusterLister
+	dgraphClusterSynced cache.InformerSynced
+
+	statefulSetLister appslisters.StatefulSetLister
+	statefulSetSynced cache.InformerSynced
+
+	jobLister batchlisters.JobLister
+	jobSynced cache.InformerSynced
+
+	// workqueue is a rate limited work queue of DgraphLiveLoad keys to sync.
+	workqueue workqueue.RateLimitingInterface
+}
+
+// NewController returns a new DgraphLiveLoad controller.
+func NewController(k8sClient kubernetes.Interface,
+	dgraphClient versioned.Interface,
+	dgraphLiveLoadInformer dgraphinformer.DgraphLiveLoadInformer,
+	dgraphClusterInformer dgraphinformer.DgraphClusterInformer,
+	k8sInformerFactory k8sinformers.SharedInformerFactory) *Controller {
+
+	utilruntime.Must(dgraphscheme.AddToScheme(scheme.Scheme))
+
+	workqueue := workqueue.NewNamedRateLimitingQueue(
+		workqueue.DefaultControllerRateLimiter(),
+		"DgraphLiveLoads")
+
+	statefulSetInformer := k8sInformerFactory.Apps().V1().StatefulSets()
+	jobInformer := k8sInformerFactory.Batch().V1().Jobs()
+
+	ctrl := &Controller{
+		k8sClient:    k8sClient,
+		dgraphClient: dgraphClient,
+
+		dgraphLiveLoadLister: dgraphLiveLoadInformer.Lister(),
+		dgraphLiveLoadSynced: dgraphLiveLoadInformer.Informer().HasSynced,
+
+		dgraphClusterLister: dgraphClusterInformer.Lister(),
+		dgraphClusterSynced: dgraphClusterInformer.Informer().HasSynced,
+
+		statefulSetLister: statefulSetInformer.Lister(),
+		statefulSetSynced: statefulSetInformer.Informer().HasSynced,
+
+		jobLister: jobInformer.Lister(),
+		jobSynced: jobInformer.Informer().HasSynced,
+
+		workqueue: workqueue,
+	}
+
+	// event handlers for DgraphLiveLoad custom kubernetes resource.
+	dgraphLiveLoadInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			glog.Info("dgraph-live-load-controller: add on DgraphLiveLoad CRD invoked.")
+			ctrl.enqueueObj(obj)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			glog.Info("dgraph-live-load-controller: update on DgraphLiveLoad CRD invoked.")
+			ctrl.enqueueObj(cur)
+		},
+	})
+
+	// Live loader jobs are watched to mirror their status in the owning DgraphLiveLoad.
+	jobInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: ctrl.enqueueJobOwner,
+		UpdateFunc: func(old, cur interface{}) {
+			ctrl.enqueueJobOwner(cur)
+		},
+	})
+
+	return ctrl
+}
+
+// Run runs the actual underlying DgraphLiveLoad controller.
+func (dl *Controller) Run(ctx context.Context) {
+	glog.Info("dgraph-live-load-controller: starting to run DgraphLiveLoad controller")
+
+	// Kubernetes specific controller teardown logic.
+	defer utilruntime.HandleCrash()
+	defer dl.workqueue.ShutDown()
+
+	// Wait for CRD to be ready, skip if any error occurs.
+	if err := k8s.WaitForCRD(dgraphio.DgraphLiveLoadCRDName); err != nil {
+		glog.Warningf("dgraph-live-load-controller: error while waiting for CRD "+
+			"to be ready: %s\nignoring failure", err)
+	}
+
+	glog.Info("dgraph-live-load-controller: waiting for informer cache to sync")
+	if ok := cache.WaitForCacheSync(ctx.Done(),
+		dl.dgraphLiveLoadSynced,
+		dl.dgraphClusterSynced,
+		dl.statefulSetSynced,
+		dl.jobSynced); !ok {
+		glog.Fatalf("dgraph-live-load-controller: error while syncing informer cache, exitting")
+	}
+	glog.Info("dgraph-live-load-controller: informer cache synced.")
+
+	// Run WorkersCount number of workers to process the work from the queue.
+	for i := 0; i < option.OperatorConfig.WorkersCount; i++ {
+		go wait.Until(dl.runWorker, time.Second, ctx.Done())
+	}
+
+	glog.Info("dgraph-live-load-controller: started workers")
+	<-ctx.Done()
+	glog.Info("dgraph-live-load-controller: shutting down workers")
+}
+
+func (dl *Controller) runWorker() {
+	for dl.processNextWorkItem() {
+	}
+}
+
+// process a work item from the workqueue.
+func (dl *Controller) processNextWorkItem() bool {
+	obj, shutdown := dl.workqueue.Get()
+	if shutdown {
+		return false
+	}
+	defer dl.workqueue.Done(obj)
+
+	objKey, ok := obj.(string)
+	if !ok {
+		dl.workqueue.Forget(obj)
+		utilruntime.HandleError(fmt.Errorf("dgraph-live-load-controller: expected string in "+
+			"workqueue but got %#v", obj))
+		return true
+	}
+
+	if err := dl.sync(objKey); err != nil {
+		// Put the item back on the workqueue to handle any transient errors.
+		dl.workqueue.AddRateLimited(objKey)
+		glog.Errorf("dgraph-live-load-controller: error syncing '%s': %s, requeuing",
+			objKey, err)
+		return true
+	}
+
+	dl.workqueue.Forget(obj)
+	glog.Infof("dgraph-live-load-controller: successfully synced '%s'", objKey)
+
+	return true
+}
+
+// Syncs the DgraphLiveLoad resource represented by `key`
+func (dl *Controller) sync(key string) error {
+	startTime := time.Now()
+	defer func() {
+		glog.Infof("dgraph-live-load-controller: DgraphLiveLoad sync done %q (%v)",
+			key,
+			time.Since(startTime))
+	}()
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	liveLoad, err := dl.dgraphLiveLoadLister.DgraphLiveLoads(namespace).Get(name)
+	if kerrors.IsNotFound(err) {
+		// The live loader job is owned by the DgraphLiveLoad and is garbage
+		// collected along with it.
+		glog.Infof("dgraph-live-load-controller: DgraphLiveLoad(%q) has already been deleted",
+			key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return dl.UpdateDgraphLiveLoad(liveLoad.DeepCopy())
+}
+
+// enqueueObj enqueues the object to the work queue.
+func (dl *Controller) enqueueObj(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("dgraph-live-load-controller: cound't get "+
+			"key for object %+v: %v", obj, err))
+		return
+	}
+	glog.Infof("dgraph-live-load-controller: enqueuing %q in workqueue", key)
+	dl.workqueue.Add(key)
+}
+
+// enqueueJobOwner enqueues the DgraphLiveLoad owning the job, if any.
+func (dl *Controller) enqueueJobOwner(obj interface{}) {
+	job, ok := obj.(*batchv1.Job)
+	if !ok {
+		return
+	}
+
+	owner := metav1.GetControllerOf(job)
+	if owner == nil || owner.Kind != dgraphio.DgraphLiveLoadKindDefinition {
+		return
+	}
+
+	dl.workqueue.Add(job.GetNamespace() + "/" + owner.Name)
+}
@@ -0,0 +1,179 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dgraphliveload
+
+import (
+	"fmt"
+	"reflect"
+
+	dgraphio "github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/k8s"
+	dgraphk8s "github.com/dgraph-io/dgraph-operator/pkg/k8s/dgraph"
+	"github.com/dgraph-io/dgraph-operator/pkg/manager"
+	"github.com/dgraph-io/dgraph-operator/pkg/utils"
+
+	"github.com/golang/glog"
+	batchv1 "k8s.io/api/batch/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// UpdateDgraphLiveLoad function handles an update event on dgraph live load object.
+// It creates the live loader job once the DgraphCluster is available and mirrors
+// the status of the job in the DgraphLiveLoad status.
+func (dl *Controller) UpdateDgraphLiveLoad(dlObj *dgraphio.DgraphLiveLoad) error {
+	oldStatus := dlObj.Status.DeepCopy()
+
+	syncErr := dl.syncLiveLoadJob(dlObj)
+
+	if !reflect.DeepEqual(dlObj.Status, *oldStatus) {
+		if err := dl.UpdateDgraphLiveLoadStatus(dlObj, &dlObj.Status); err != nil {
+			return err
+		}
+	}
+
+	return syncErr
+}
+
+// syncLiveLoadJob ensures that the live loader job for the DgraphLiveLoad exists and
+// records its status.
+func (dl *Controller) syncLiveLoadJob(dlObj *dgraphio.DgraphLiveLoad) error {
+	ns := dlObj.GetNamespace()
+	status := &dlObj.Status
+	jobName := utils.DgraphLiveLoadName(dlObj.GetName())
+
+	// Live load has already finished, there is nothing left to do.
+	if status.Phase == dgraphio.LiveLoadPhaseSucceeded ||
+		status.Phase == dgraphio.LiveLoadPhaseFailed {
+		return nil
+	}
+
+	job, err := dl.jobLister.Jobs(ns).Get(jobName)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	if kerrors.IsNotFound(err) {
+		job, err = dl.createLiveLoadJob(dlObj)
+		if err != nil || job == nil {
+			return err
+		}
+	}
+
+	mirrorJobStatus(status, job)
+	return nil
+}
+
+// createLiveLoadJob creates the live loader job once the alpha cluster of the referenced
+// DgraphCluster is ready. It returns nil if the job could not be created yet.
+func (dl *Controller) createLiveLoadJob(dlObj *dgraphio.DgraphLiveLoad) (*batchv1.Job, error) {
+	ns := dlObj.GetNamespace()
+	status := &dlObj.Status
+	status.Phase = dgraphio.LiveLoadPhasePending
+
+	dc, err := dl.dgraphClusterLister.DgraphClusters(ns).Get(dlObj.Spec.ClusterName)
+	if kerrors.IsNotFound(err) {
+		status.Message = fmt.Sprintf("waiting for DgraphCluster %s to be created",
+			dlObj.Spec.ClusterName)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The live load is retried on the periodic resync of the informer until alpha is ready.
+	ready, err := manager.AlphaReady(dl.statefulSetLister, dc)
+	if err != nil {
+		return nil, err
+	}
+	if !ready {
+		status.Message = fmt.Sprintf("waiting for alpha of DgraphCluster %s to be ready",
+			dc.GetName())
+		return nil, nil
+	}
+
+	// Versions are validated by the DgraphCluster controller, the job is retried on the
+	// periodic resync once the version of the cluster is fixed.
+	job, err := dgraphk8s.NewLiveLoadJob(dlObj, dc)
+	if err != nil {
+		status.Message = fmt.Sprintf("cannot load data into DgraphCluster %s: %s",
+			dc.GetName(), err)
+		return nil, nil
+	}
+	glog.Infof("dgraph-live-load-controller: creating live loader job: %s", job.GetName())
+	if err := k8s.CreateNewJob(dl.k8sClient, ns, job); err != nil &&
+		!kerrors.IsAlreadyExists(err) {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// mirrorJobStatus records the status of the live loader job in the DgraphLiveLoad status.
+func mirrorJobStatus(status *dgraphio.DgraphLiveLoadStatus, job *batchv1.Job) {
+	status.JobName = job.GetName()
+	status.Active = job.Status.Active
+	status.Succeeded = job.Status.Succeeded
+	status.Failed = job.Status.Failed
+	status.StartTime = job.Status.StartTime
+	status.CompletionTime = job.Status.CompletionTime
+
+	finished, succeeded := k8s.IsJobFinished(job)
+	switch {
+	case finished && succeeded:
+		status.Phase = dgraphio.LiveLoadPhaseSucceeded
+		status.Message = "live load completed"
+	case finished:
+		status.Phase = dgraphio.LiveLoadPhaseFailed
+		status.Message = fmt.Sprintf("live loader job %s failed", job.GetName())
+	default:
+		status.Phase = dgraphio.LiveLoadPhaseRunning
+		status.Message = fmt.Sprintf("live loader job %s is running", job.GetName())
+	}
+}
+
+// UpdateDgraphLiveLoadStatus updates the status of the DgraphLiveLoad object represented
+// by dlObj with the status represented in dlStatus.
+func (dl *Controller) UpdateDgraphLiveLoadStatus(
+	dlObj *dgraphio.DgraphLiveLoad,
+	dlStatus *dgraphio.DgraphLiveLoadStatus) error {
+
+	glog.Infof("dgraph-live-load-controller: updating DgraphLiveLoad %s status",
+		dlObj.GetName())
+	ns := dlObj.GetNamespace()
+	name := dlObj.GetName()
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		update := dlObj.DeepCopy()
+		update.Status = *dlStatus.DeepCopy()
+		_, updateErr := dl.dgraphClient.DgraphV1alpha1().DgraphLiveLoads(ns).UpdateStatus(update)
+		if updateErr == nil {
+			return nil
+		}
+
+		// Fetch the latest version of the object on conflict and retry with it.
+		latest, err := dl.dgraphClient.DgraphV1alpha1().DgraphLiveLoads(ns).
+			Get(name, metav1.GetOptions{})
+		if err != nil {
+			glog.Errorf("dgraph-live-load-controller: error getting DgraphLiveLoad %s: %s",
+				name, err)
+			return updateErr
+		}
+		dlObj = latest
+		return updateErr
+	})
+}
@@ -0,0 +1,234 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dgraphschema
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dgraphio "github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/client/clientset/versioned"
+	dgraphscheme "github.com/dgraph-io/dgraph-operator/pkg/client/clientset/versioned/scheme"
+	// nolint
+	dgraphinformer "github.com/dgraph-io/dgraph-operator/pkg/client/informers/externalversions/dgraph.io/v1alpha1"
+	listers "github.com/dgraph-io/dgraph-operator/pkg/client/listers/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/k8s"
+	"github.com/dgraph-io/dgraph-operator/pkg/option"
+
+	"github.com/golang/glog"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8sinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// Controller is the controller to manage the DgraphSchema custom
+// resource created in the Kubernetes cluster.
+//
+// The controller applies the schema of each DgraphSchema to the alpha of the
+// referenced DgraphCluster. The live schema is compared with the specification
+// on every resync of the informer, so changes made out of band are detected.
+type Controller struct {
+	// k8sClient is the client interface to connect to the kube API server.
+	k8sClient kubernetes.Interface
+
+	// dgraphClient is the client interface to interacting with dgraph related
+	// custom resources.
+	dgraphClient versioned.Interface
+
+	dgraphSchemaLister listers.DgraphSchemaLister
+	dgraphSchemaSynced cache.InformerSynced
+
+	dgraphClusterLister listers.DgraphClusterLister
+	dgraphClusterSynced cache.InformerSynced
+
+	statefulSetLister appslisters.StatefulSetLister
+	statefulSetSynced cache.InformerSynced
+
+	// workqueue is a rate limited work queue of DgraphSchema keys to sync.
+	workqueue workqueue.RateLimitingInterface
+}
+
+// NewController returns a new DgraphSchema controller.
+func NewController(k8sClient kubernetes.Interface,
+	dgraphClient versioned.Interface,
+	dgraphSchemaInformer dgraphinformer.DgraphSchemaInformer,
+	dgraphClusterInformer dgraphinformer.DgraphClusterInformer,
+	k8sInformerFactory k8sinformers.SharedInformerFactory) *Controller {
+
+	utilruntime.Must(dgraphscheme.AddToScheme(scheme.Scheme))
+
+	workqueue := workqueue.NewNamedRateLimitingQueue(
+		workqueue.DefaultControllerRateLimiter(),
+		"DgraphSchemas")
+
+	statefulSetInformer := k8sInformerFactory.Apps().V1().StatefulSets()
+
+	ctrl := &Controller{
+		k8sClient:    k8sClient,
+		dgraphClient: dgraphClient,
+
+		dgraphSchemaLister: dgraphSchemaInformer.Lister(),
+		dgraphSchemaSynced: dgraphSchemaInformer.Informer().HasSynced,
+
+		dgraphClusterLister: dgraphClusterInformer.Lister(),
+		dgraphClusterSynced: dgraphClusterInformer.Informer().HasSynced,
+
+		statefulSetLister: statefulSetInformer.Lister(),
+		statefulSetSynced: statefulSetInformer.Informer().HasSynced,
+
+		workqueue: workqueue,
+	}
+
+	// event handlers for DgraphSchema custom kubernetes resource.
+	dgraphSchemaInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			glog.Info("dgraph-schema-controller: add on DgraphSchema CRD invoked.")
+			ctrl.enqueueObj(obj)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			glog.Info("dgraph-schema-controller: update on DgraphSchema CRD invoked.")
+			// Status is updated by the controller itself on every sync, syncing on status
+			// updates would keep altering the schema in a loop. Periodic resyncs have the
+			// same resource version and are not skipped.
+			oldDS := old.(*dgraphio.DgraphSchema)
+			curDS := cur.(*dgraphio.DgraphSchema)
+			if oldDS.ResourceVersion != curDS.ResourceVersion &&
+				oldDS.Generation == curDS.Generation &&
+				oldDS.DeletionTimestamp.Equal(curDS.DeletionTimestamp) {
+				return
+			}
+			ctrl.enqueueObj(cur)
+		},
+	})
+
+	return ctrl
+}
+
+// Run runs the actual underlying DgraphSchema controller.
+func (ds *Controller) Run(ctx context.Context) {
+	glog.Info("dgraph-schema-controller: starting to run DgraphSchema controller")
+
+	// Kubernetes specific controller teardown logic.
+	defer utilruntime.HandleCrash()
+	defer ds.workqueue.ShutDown()
+
+	// Wait for CRD to be ready, skip if any error occurs.
+	if err := k8s.WaitForCRD(dgraphio.DgraphSchemaCRDName); err != nil {
+		glog.Warningf("dgraph-schema-controller: error while waiting for CRD "+
+			"to be ready: %s\nignoring failure", err)
+	}
+
+	glog.Info("dgraph-schema-controller: waiting for informer cache to sync")
+	if ok := cache.WaitForCacheSync(ctx.Done(),
+		ds.dgraphSchemaSynced,
+		ds.dgraphClusterSynced,
+		ds.statefulSetSynced); !ok {
+		glog.Fatalf("dgraph-schema-controller: error while syncing informer cache, exitting")
+	}
+	glog.Info("dgraph-schema-controller: informer cache synced.")
+
+	// Run WorkersCount number of workers to process the work from the queue.
+	for i := 0; i < option.OperatorConfig.WorkersCount; i++ {
+		go wait.Until(ds.runWorker, time.Second, ctx.Done())
+	}
+
+	glog.Info("dgraph-schema-controller: started workers")
+	<-ctx.Done()
+	glog.Info("dgraph-schema-controller: shutting down workers")
+}
+
+func (ds *Controller) runWorker() {
+	for ds.processNextWorkItem() {
+	}
+}
+
+// process a work item from the workqueue.
+func (ds *Controller) processNextWorkItem() bool {
+	obj, shutdown := ds.workqueue.Get()
+	if shutdown {
+		return false
+	}
+	defer ds.workqueue.Done(obj)
+
+	objKey, ok := obj.(string)
+	if !ok {
+		ds.workqueue.Forget(obj)
+		utilruntime.HandleError(fmt.Errorf("dgraph-schema-controller: expected string in "+
+			"workqueue but got %#v", obj))
+		return true
+	}
+
+	if err := ds.sync(objKey); err != nil {
+		// Put the item back on the workqueue to handle any transient errors.
+		ds.workqueue.AddRateLimited(objKey)
+		glog.Errorf("dgraph-schema-controller: error syncing '%s': %s, requeuing",
+			objKey, err)
+		return true
+	}
+
+	ds.workqueue.Forget(obj)
+	glog.Infof("dgraph-schema-controller: successfully synced '%s'", objKey)
+
+	return true
+}
+
+// Syncs the DgraphSchema resource represented by `key`
+func (ds *Controller) sync(key string) error {
+	startTime := time.Now()
+	defer func() {
+		glog.Infof("dgraph-schema-controller: DgraphSchema sync done %q (%v)",
+			key,
+			time.Since(startTime))
+	}()
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	schema, err := ds.dgraphSchemaLister.DgraphSchemas(namespace).Get(name)
+	if kerrors.IsNotFound(err) {
+		// Deleting a DgraphSchema leaves the schema of the cluster untouched.
+		glog.Infof("dgraph-schema-controller: DgraphSchema(%q) has already been deleted",
+			key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return ds.UpdateDgraphSchema(schema.DeepCopy())
+}
+
+// enqueueObj enqueues the object to the work queue.
+func (ds *Controller) enqueueObj(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("dgraph-schema-controller: cound't get "+
+			"key for object %+v: %v", obj, err))
+		return
+	}
+	glog.Infof("dgraph-schema-controller: enqueuing %q in workqueue", key)
+	ds.workqueue.Add(key)
+}
@@ -0,0 +1,211 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dgraphschema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	dgraphio "github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/dgraph"
+	dgraphk8s "github.com/dgraph-io/dgraph-operator/pkg/k8s/dgraph"
+	"github.com/dgraph-io/dgraph-operator/pkg/manager"
+
+	"github.com/golang/glog"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// UpdateDgraphSchema function handles an update event on dgraph schema object.
+// It compares the live schema of the cluster with the specification and applies the
+// differences allowed by the policy of the DgraphSchema.
+func (ds *Controller) UpdateDgraphSchema(dsObj *dgraphio.DgraphSchema) error {
+	oldStatus := dsObj.Status.DeepCopy()
+
+	syncErr := ds.syncSchema(dsObj)
+	if syncErr != nil {
+		dsObj.Status.Phase = dgraphio.SchemaPhaseFailed
+		dsObj.Status.Message = syncErr.Error()
+	}
+
+	if !reflect.DeepEqual(dsObj.Status, *oldStatus) {
+		if err := ds.UpdateDgraphSchemaStatus(dsObj, &dsObj.Status); err != nil {
+			return err
+		}
+	}
+
+	return syncErr
+}
+
+// syncSchema syncs the live schema of the cluster with the DgraphSchema specification
+// and records the result in its status.
+func (ds *Controller) syncSchema(dsObj *dgraphio.DgraphSchema) error {
+	ns := dsObj.GetNamespace()
+	status := &dsObj.Status
+	spec := &dsObj.Spec
+
+	dc, err := ds.dgraphClusterLister.DgraphClusters(ns).Get(spec.ClusterName)
+	if kerrors.IsNotFound(err) {
+		status.Phase = dgraphio.SchemaPhasePending
+		status.Message = fmt.Sprintf("waiting for DgraphCluster %s to be created",
+			spec.ClusterName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	ready, err := manager.AlphaReady(ds.statefulSetLister, dc)
+	if err != nil {
+		return err
+	}
+	if !ready {
+		status.Phase = dgraphio.SchemaPhasePending
+		status.Message = fmt.Sprintf("waiting for alpha of DgraphCluster %s to be ready",
+			dc.GetName())
+		return nil
+	}
+
+	client := dgraph.NewClient(dgraphk8s.AlphaHTTPAddress(dc))
+	live, err := client.Schema()
+	if err != nil {
+		return err
+	}
+
+	status.ObservedGeneration = dsObj.GetGeneration()
+	diff := dgraph.DiffSchema(spec, live, status.AppliedPredicates)
+	if diff.Empty() {
+		status.Phase = dgraphio.SchemaPhaseSynced
+		status.Message = "live schema matches the specification"
+		status.Drift = nil
+		status.AppliedPredicates = appliedPredicates(spec, nil)
+		return nil
+	}
+
+	if len(diff.DestructiveChanges) > 0 && !spec.AllowDestructiveChanges {
+		status.Phase = dgraphio.SchemaPhaseFailed
+		status.Message = "schema requires destructive changes which are not allowed, " +
+			"set allowDestructiveChanges to apply them"
+		status.Drift = append(append(diff.DestructiveChanges, diff.Changes...),
+			droppedPredicatesDrift(diff.DroppedPredicates)...)
+		return nil
+	}
+
+	// The schema is only altered if it differs, dropped predicates alone don't require it.
+	applied := false
+	if changes := append(diff.DestructiveChanges, diff.Changes...); len(changes) > 0 {
+		glog.Infof("dgraph-schema-controller: applying schema %s to cluster %s: %v",
+			dsObj.GetName(), dc.GetName(), changes)
+		if schema := dgraph.SchemaText(spec); schema != "" {
+			if err := client.Alter(schema); err != nil {
+				return err
+			}
+		}
+		applied = true
+	}
+
+	// Previously applied predicates which are no longer in the specification are left in
+	// place unless destructive changes are allowed, they remain applied until dropped.
+	// Predicates applied by others, such as a DgraphGraphQLSchema, are never dropped.
+	status.Drift = nil
+	status.AppliedPredicates = appliedPredicates(spec, diff.DroppedPredicates)
+	if spec.AllowDestructiveChanges {
+		for _, predicate := range diff.DroppedPredicates {
+			glog.Infof("dgraph-schema-controller: dropping predicate %s from cluster %s",
+				predicate, dc.GetName())
+			if err := client.DropPredicate(predicate); err != nil {
+				return err
+			}
+			applied = true
+		}
+		status.AppliedPredicates = appliedPredicates(spec, nil)
+	} else {
+		status.Drift = droppedPredicatesDrift(diff.DroppedPredicates)
+	}
+
+	if applied {
+		now := metav1.Now()
+		status.LastAppliedTime = &now
+	}
+	if len(status.Drift) > 0 {
+		status.Phase = dgraphio.SchemaPhaseDrifted
+		status.Message = "previously applied predicates are no longer in the " +
+			"specification, set allowDestructiveChanges to drop them"
+		return nil
+	}
+	status.Phase = dgraphio.SchemaPhaseSynced
+	status.Message = "schema applied"
+	return nil
+}
+
+// appliedPredicates returns the sorted predicates of the provided specification along with
+// the provided previously applied predicates which are kept in the cluster.
+func appliedPredicates(spec *dgraphio.DgraphSchemaSpec, kept []string) []string {
+	predicates := make([]string, 0, len(spec.Predicates)+len(kept))
+	for _, p := range spec.Predicates {
+		predicates = append(predicates, p.Name)
+	}
+	predicates = append(predicates, kept...)
+	sort.Strings(predicates)
+
+	return predicates
+}
+
+// droppedPredicatesDrift returns the drift entries for previously applied predicates of
+// the live schema which are no longer part of the specification.
+func droppedPredicatesDrift(predicates []string) []string {
+	drift := make([]string, 0, len(predicates))
+	for _, predicate := range predicates {
+		drift = append(drift, fmt.Sprintf("predicate %s is not in the specification",
+			predicate))
+	}
+
+	return drift
+}
+
+// UpdateDgraphSchemaStatus updates the status of the DgraphSchema object represented
+// by dsObj with the status represented in dsStatus.
+func (ds *Controller) UpdateDgraphSchemaStatus(
+	dsObj *dgraphio.DgraphSchema,
+	dsStatus *dgraphio.DgraphSchemaStatus) error {
+
+	glog.Infof("dgraph-schema-controller: updating DgraphSchema %s status", dsObj.GetName())
+	ns := dsObj.GetNamespace()
+	name := dsObj.GetName()
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		update := dsObj.DeepCopy()
+		update.Status = *dsStatus.DeepCopy()
+		_, updateErr := ds.dgraphClient.DgraphV1alpha1().DgraphSchemas(ns).UpdateStatus(update)
+		if updateErr == nil {
+			return nil
+		}
+
+		// Fetch the latest version of the object on conflict and retry with it.
+		latest, err := ds.dgraphClient.DgraphV1alpha1().DgraphSchemas(ns).
+			Get(name, metav1.GetOptions{})
+		if err != nil {
+			glog.Errorf("dgraph-schema-controller: error getting DgraphSchema %s: %s",
+				name, err)
+			return updateErr
+		}
+		dsObj = latest
+		return updateErr
+	})
+}
@@ -0,0 +1,271 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dgraphsnapshot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dgraphio "github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/client/clientset/versioned"
+	dgraphscheme "github.com/dgraph-io/dgraph-operator/pkg/client/clientset/versioned/scheme"
+	// nolint
+	dgraphinformer "github.com/dgraph-io/dgraph-operator/pkg/client/informers/externalversions/dgraph.io/v1alpha1"
+	listers "github.com/dgraph-io/dgraph-operator/pkg/client/listers/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+	"github.com/dgraph-io/dgraph-operator/pkg/k8s"
+	"github.com/dgraph-io/dgraph-operator/pkg/option"
+
+	"github.com/golang/glog"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	k8sinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// Controller is the controller to manage the DgraphSnapshot custom
+// resource created in the Kubernetes cluster.
+//
+// The controller takes crash consistent volume snapshots of the persistent volume
+// claims of all the alpha and zero members of the referenced DgraphCluster. Alpha
+// members are put into draining mode through their /admin endpoint until all the
+// volume snapshots have been taken.
+type Controller struct {
+	// k8sClient is the client interface to connect to the kube API server.
+	k8sClient kubernetes.Interface
+
+	// dgraphClient is the client interface to interacting with dgraph related
+	// custom resources.
+	dgraphClient versioned.Interface
+
+	// dynamicClient is the client interface to interact with volume snapshots, which
+	// are not part of the kubernetes API.
+	dynamicClient dynamic.Interface
+
+	dgraphSnapshotLister listers.DgraphSnapshotLister
+	dgraphSnapshotSynced cache.InformerSynced
+
+	dgraphClusterLister listers.DgraphClusterLister
+	dgraphClusterSynced cache.InformerSynced
+
+	statefulSetLister appslisters.StatefulSetLister
+	statefulSetSynced cache.InformerSynced
+
+	podLister corelisters.PodLister
+	podSynced cache.InformerSynced
+
+	pvcLister corelisters.PersistentVolumeClaimLister
+	pvcSynced cache.InformerSynced
+
+	// workqueue is a rate limited work queue of DgraphSnapshot keys to sync.
+	workqueue workqueue.RateLimitingInterface
+}
+
+// NewController returns a new DgraphSnapshot controller.
+func NewController(k8sClient kubernetes.Interface,
+	dgraphClient versioned.Interface,
+	dynamicClient dynamic.Interface,
+	dgraphSnapshotInformer dgraphinformer.DgraphSnapshotInformer,
+	dgraphClusterInformer dgraphinformer.DgraphClusterInformer,
+	k8sInformerFactory k8sinformers.SharedInformerFactory) *Controller {
+
+	utilruntime.Must(dgraphscheme.AddToScheme(scheme.Scheme))
+
+	workqueue := workqueue.NewNamedRateLimitingQueue(
+		workqueue.DefaultControllerRateLimiter(),
+		"DgraphSnapshots")
+
+	statefulSetInformer := k8sInformerFactory.Apps().V1().StatefulSets()
+	podInformer := k8sInformerFactory.Core().V1().Pods()
+	pvcInformer := k8sInformerFactory.Core().V1().PersistentVolumeClaims()
+
+	ctrl := &Controller{
+		k8sClient:     k8sClient,
+		dgraphClient:  dgraphClient,
+		dynamicClient: dynamicClient,
+
+		dgraphSnapshotLister: dgraphSnapshotInformer.Lister(),
+		dgraphSnapshotSynced: dgraphSnapshotInformer.Informer().HasSynced,
+
+		dgraphClusterLister: dgraphClusterInformer.Lister(),
+		dgraphClusterSynced: dgraphClusterInformer.Informer().HasSynced,
+
+		statefulSetLister: statefulSetInformer.Lister(),
+		statefulSetSynced: statefulSetInformer.Informer().HasSynced,
+
+		podLister: podInformer.Lister(),
+		podSynced: podInformer.Informer().HasSynced,
+
+		pvcLister: pvcInformer.Lister(),
+		pvcSynced: pvcInformer.Informer().HasSynced,
+
+		workqueue: workqueue,
+	}
+
+	// event handlers for DgraphSnapshot custom kubernetes resource.
+	dgraphSnapshotInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			glog.Info("dgraph-snapshot-controller: add on DgraphSnapshot CRD invoked.")
+			ctrl.enqueueObj(obj)
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			glog.Info("dgraph-snapshot-controller: update on DgraphSnapshot CRD invoked.")
+			// Status is updated by the controller itself while the snapshot progresses,
+			// which polls the volume snapshots on its own.
+			oldDS := old.(*dgraphio.DgraphSnapshot)
+			curDS := cur.(*dgraphio.DgraphSnapshot)
+			if oldDS.ResourceVersion != curDS.ResourceVersion &&
+				oldDS.Generation == curDS.Generation &&
+				oldDS.DeletionTimestamp.Equal(curDS.DeletionTimestamp) {
+				return
+			}
+			ctrl.enqueueObj(cur)
+		},
+	})
+
+	return ctrl
+}
+
+// Run runs the actual underlying DgraphSnapshot controller.
+func (ds *Controller) Run(ctx context.Context) {
+	glog.Info("dgraph-snapshot-controller: starting to run DgraphSnapshot controller")
+
+	// Kubernetes specific controller teardown logic.
+	defer utilruntime.HandleCrash()
+	defer ds.workqueue.ShutDown()
+
+	// Wait for CRD to be ready, skip if any error occurs.
+	if err := k8s.WaitForCRD(dgraphio.DgraphSnapshotCRDName); err != nil {
+		glog.Warningf("dgraph-snapshot-controller: error while waiting for CRD "+
+			"to be ready: %s\nignoring failure", err)
+	}
+
+	glog.Info("dgraph-snapshot-controller: waiting for informer cache to sync")
+	if ok := cache.WaitForCacheSync(ctx.Done(),
+		ds.dgraphSnapshotSynced,
+		ds.dgraphClusterSynced,
+		ds.statefulSetSynced,
+		ds.podSynced,
+		ds.pvcSynced); !ok {
+		glog.Fatalf("dgraph-snapshot-controller: error while syncing informer cache, exitting")
+	}
+	glog.Info("dgraph-snapshot-controller: informer cache synced.")
+
+	// Run WorkersCount number of workers to process the work from the queue.
+	for i := 0; i < option.OperatorConfig.WorkersCount; i++ {
+		go wait.Until(ds.runWorker, time.Second, ctx.Done())
+	}
+
+	glog.Info("dgraph-snapshot-controller: started workers")
+	<-ctx.Done()
+	glog.Info("dgraph-snapshot-controller: shutting down workers")
+}
+
+func (ds *Controller) runWorker() {
+	for ds.processNextWorkItem() {
+	}
+}
+
+// process a work item from the workqueue.
+func (ds *Controller) processNextWorkItem() bool {
+	obj, shutdown := ds.workqueue.Get()
+	if shutdown {
+		return false
+	}
+	defer ds.workqueue.Done(obj)
+
+	objKey, ok := obj.(string)
+	if !ok {
+		ds.workqueue.Forget(obj)
+		utilruntime.HandleError(fmt.Errorf("dgraph-snapshot-controller: expected "+
+			"string in workqueue but got %#v", obj))
+		return true
+	}
+
+	if err := ds.sync(objKey); err != nil {
+		// Put the item back on the workqueue to handle any transient errors.
+		ds.workqueue.AddRateLimited(objKey)
+		glog.Errorf("dgraph-snapshot-controller: error syncing '%s': %s, requeuing",
+			objKey, err)
+		return true
+	}
+
+	ds.workqueue.Forget(obj)
+	glog.Infof("dgraph-snapshot-controller: successfully synced '%s'", objKey)
+
+	return true
+}
+
+// Syncs the DgraphSnapshot resource represented by `key`
+func (ds *Controller) sync(key string) error {
+	startTime := time.Now()
+	defer func() {
+		glog.Infof("dgraph-snapshot-controller: DgraphSnapshot sync done %q (%v)",
+			key,
+			time.Since(startTime))
+	}()
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := ds.dgraphSnapshotLister.DgraphSnapshots(namespace).Get(name)
+	if kerrors.IsNotFound(err) {
+		// Volume snapshots are owned by the DgraphSnapshot and deleted along with it.
+		glog.Infof("dgraph-snapshot-controller: DgraphSnapshot(%q) has already "+
+			"been deleted", key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	dsObj := snapshot.DeepCopy()
+	if err := ds.UpdateDgraphSnapshot(dsObj); err != nil {
+		return err
+	}
+
+	// Alpha members must be resumed as soon as the volume snapshots have been taken,
+	// so they are polled instead of waiting for the next resync.
+	if dsObj.Status.Phase == dgraphio.SnapshotPhaseDraining &&
+		dsObj.GetDeletionTimestamp() == nil {
+		ds.workqueue.AddAfter(key, defaults.SnapshotPollInterval)
+	}
+
+	return nil
+}
+
+// enqueueObj enqueues the object to the work queue.
+func (ds *Controller) enqueueObj(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("dgraph-snapshot-controller: cound't get "+
+			"key for object %+v: %v", obj, err))
+		return
+	}
+	glog.Infof("dgraph-snapshot-controller: enqueuing %q in workqueue", key)
+	ds.workqueue.Add(key)
+}
@@ -0,0 +1,468 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dgraphsnapshot
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	dgraphio "github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+	"github.com/dgraph-io/dgraph-operator/pkg/dgraph"
+	"github.com/dgraph-io/dgraph-operator/pkg/k8s"
+	dgraphk8s "github.com/dgraph-io/dgraph-operator/pkg/k8s/dgraph"
+	"github.com/dgraph-io/dgraph-operator/pkg/manager"
+	"github.com/dgraph-io/dgraph-operator/pkg/utils"
+
+	"github.com/golang/glog"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/retry"
+)
+
+// UpdateDgraphSnapshot function handles an update event on dgraph snapshot object. It
+// moves the snapshot through its phases, the alpha members are put into draining mode
+// while the volume snapshots are taken and resumed as soon as they have been.
+func (ds *Controller) UpdateDgraphSnapshot(dsObj *dgraphio.DgraphSnapshot) error {
+	if dsObj.GetDeletionTimestamp() != nil {
+		return ds.finalizeSnapshot(dsObj)
+	}
+
+	oldStatus := dsObj.Status.DeepCopy()
+
+	syncErr := ds.syncSnapshot(dsObj)
+
+	if !reflect.DeepEqual(dsObj.Status, *oldStatus) {
+		if err := ds.UpdateDgraphSnapshotStatus(dsObj, &dsObj.Status); err != nil {
+			return err
+		}
+	}
+
+	return syncErr
+}
+
+// syncSnapshot performs the step of the current phase of the snapshot and records the
+// result in its status.
+func (ds *Controller) syncSnapshot(dsObj *dgraphio.DgraphSnapshot) error {
+	switch dsObj.Status.Phase {
+	case "", dgraphio.SnapshotPhasePending:
+		return ds.syncPending(dsObj)
+	case dgraphio.SnapshotPhaseDraining:
+		return ds.syncDraining(dsObj)
+	case dgraphio.SnapshotPhaseSnapshotting:
+		return ds.syncSnapshotting(dsObj)
+	}
+
+	// Completed and failed snapshots are never taken again.
+	return nil
+}
+
+// syncPending puts the alpha members of the cluster into draining mode once they are
+// all ready, then starts taking the volume snapshots.
+func (ds *Controller) syncPending(dsObj *dgraphio.DgraphSnapshot) error {
+	ns := dsObj.GetNamespace()
+	status := &dsObj.Status
+	clusterName := dsObj.Spec.ClusterName
+
+	dc, err := ds.dgraphClusterLister.DgraphClusters(ns).Get(clusterName)
+	if kerrors.IsNotFound(err) {
+		status.Phase = dgraphio.SnapshotPhasePending
+		status.Message = fmt.Sprintf("waiting for DgraphCluster %s to be created",
+			clusterName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if dc.Spec.AlphaCluster.Storage.Ephemeral() {
+		status.Phase = dgraphio.SnapshotPhaseFailed
+		status.Message = fmt.Sprintf("alpha of DgraphCluster %s has ephemeral storage",
+			clusterName)
+		return nil
+	}
+
+	ready, err := manager.AlphaReady(ds.statefulSetLister, dc)
+	if err != nil {
+		return err
+	}
+	if !ready {
+		status.Phase = dgraphio.SnapshotPhasePending
+		status.Message = fmt.Sprintf("waiting for alpha of DgraphCluster %s to be ready",
+			clusterName)
+		return nil
+	}
+
+	// The claims of all the members must exist, otherwise the snapshot couldn't be used
+	// to restore a cluster with the same topology.
+	claims := dgraphk8s.MemberVolumeClaims(dc)
+	volumes := make([]dgraphio.SnapshotVolume, 0, len(claims))
+	for _, claim := range claims {
+		_, err := ds.pvcLister.PersistentVolumeClaims(ns).Get(claim.Claim.GetName())
+		if kerrors.IsNotFound(err) {
+			status.Phase = dgraphio.SnapshotPhasePending
+			status.Message = fmt.Sprintf("waiting for persistent volume claim %s to be "+
+				"created", claim.Claim.GetName())
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		volumes = append(volumes, dgraphio.SnapshotVolume{
+			MemberVolume:   claim.MemberVolume,
+			Claim:          claim.Claim.GetName(),
+			VolumeSnapshot: dgraphk8s.VolumeSnapshotName(dsObj, claim.Claim.GetName()),
+		})
+	}
+
+	pods, err := ds.alphaPods(dc)
+	if err != nil {
+		return err
+	}
+
+	// The finalizer makes sure the alpha members are resumed if the snapshot is deleted
+	// while they are draining.
+	if err := ds.updateSnapshotFinalizer(dsObj, true); err != nil {
+		return err
+	}
+
+	glog.Infof("dgraph-snapshot-controller: putting alpha members of cluster %s into "+
+		"draining mode for snapshot %s", clusterName, dsObj.GetName())
+	for i, pod := range pods {
+		if err := alphaClient(pod).SetDraining(true); err != nil {
+			resumeAlphas(pods[:i+1])
+			if updateErr := ds.updateSnapshotFinalizer(dsObj, false); updateErr != nil {
+				glog.Errorf("dgraph-snapshot-controller: error removing finalizer of "+
+					"DgraphSnapshot %s: %s", dsObj.GetName(), updateErr)
+			}
+			return fmt.Errorf("error putting alpha member %s into draining mode: %s",
+				pod.GetName(), err)
+		}
+	}
+
+	now := metav1.Now()
+	status.StartTime = &now
+	status.Volumes = volumes
+	status.Source = &dgraphio.SnapshotSource{
+		ClusterName:   dc.GetName(),
+		ClusterID:     dc.Spec.GetClusterID(),
+		ClusterDomain: dgraphk8s.ClusterDomain(dc),
+		UID:           dc.GetUID(),
+	}
+	status.Phase = dgraphio.SnapshotPhaseDraining
+	status.Message = "alpha members are draining while the volume snapshots are taken"
+
+	return ds.syncDraining(dsObj)
+}
+
+// syncDraining creates the volume snapshots of all the claims and resumes the alpha
+// members once they have all been taken.
+func (ds *Controller) syncDraining(dsObj *dgraphio.DgraphSnapshot) error {
+	ns := dsObj.GetNamespace()
+	status := &dsObj.Status
+
+	if status.StartTime != nil &&
+		time.Since(status.StartTime.Time) > defaults.SnapshotDrainTimeout {
+		return ds.failSnapshot(dsObj, fmt.Sprintf("volume snapshots were not taken "+
+			"within %s", defaults.SnapshotDrainTimeout))
+	}
+
+	taken := true
+	for i := range status.Volumes {
+		volume := &status.Volumes[i]
+		snapshot, err := k8s.GetVolumeSnapshot(ds.dynamicClient, ns, volume.VolumeSnapshot)
+		if kerrors.IsNotFound(err) {
+			glog.Infof("dgraph-snapshot-controller: creating volume snapshot %s of "+
+				"persistent volume claim %s", volume.VolumeSnapshot, volume.Claim)
+			err = k8s.CreateNewVolumeSnapshot(ds.dynamicClient, ns,
+				dgraphk8s.NewVolumeSnapshot(dsObj, volume.Claim))
+			if err != nil && !kerrors.IsAlreadyExists(err) {
+				return err
+			}
+			taken = false
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if msg := updateSnapshotVolume(volume, snapshot); msg != "" {
+			return ds.failSnapshot(dsObj, fmt.Sprintf("volume snapshot %s failed: %s",
+				volume.VolumeSnapshot, msg))
+		}
+
+		// The point in time of the snapshot has been taken once it has a creation time,
+		// the alpha members don't have to wait for it to be ready to use.
+		if _, ok, _ := unstructured.NestedString(snapshot.Object,
+			"status", "creationTime"); !ok {
+			taken = false
+		}
+	}
+
+	if !taken {
+		return nil
+	}
+
+	glog.Infof("dgraph-snapshot-controller: volume snapshots of %s taken, resuming alpha "+
+		"members", dsObj.GetName())
+	if err := ds.resumeCluster(dsObj); err != nil {
+		return err
+	}
+
+	status.Phase = dgraphio.SnapshotPhaseSnapshotting
+	status.Message = "waiting for the volume snapshots to be ready to use"
+
+	return ds.syncSnapshotting(dsObj)
+}
+
+// syncSnapshotting completes the snapshot once all the volume snapshots are ready to use.
+func (ds *Controller) syncSnapshotting(dsObj *dgraphio.DgraphSnapshot) error {
+	ns := dsObj.GetNamespace()
+	status := &dsObj.Status
+
+	ready := true
+	for i := range status.Volumes {
+		volume := &status.Volumes[i]
+		snapshot, err := k8s.GetVolumeSnapshot(ds.dynamicClient, ns, volume.VolumeSnapshot)
+		if kerrors.IsNotFound(err) {
+			return ds.failSnapshot(dsObj, fmt.Sprintf("volume snapshot %s was deleted",
+				volume.VolumeSnapshot))
+		}
+		if err != nil {
+			return err
+		}
+
+		if msg := updateSnapshotVolume(volume, snapshot); msg != "" {
+			return ds.failSnapshot(dsObj, fmt.Sprintf("volume snapshot %s failed: %s",
+				volume.VolumeSnapshot, msg))
+		}
+		ready = ready && volume.ReadyToUse
+	}
+
+	if !ready {
+		return nil
+	}
+
+	now := metav1.Now()
+	status.CompletionTime = &now
+	status.Phase = dgraphio.SnapshotPhaseCompleted
+	status.Message = "all volume snapshots are ready to use"
+	return nil
+}
+
+// updateSnapshotVolume records the status of the volume snapshot in the snapshot volume
+// and returns the error message of the volume snapshot, empty unless it failed.
+func updateSnapshotVolume(volume *dgraphio.SnapshotVolume,
+	snapshot *unstructured.Unstructured) string {
+	if ready, ok, _ := unstructured.NestedBool(snapshot.Object,
+		"status", "readyToUse"); ok {
+		volume.ReadyToUse = ready
+	}
+	if size, ok, _ := unstructured.NestedString(snapshot.Object,
+		"status", "restoreSize"); ok {
+		volume.RestoreSize = size
+	}
+
+	msg, _, _ := unstructured.NestedString(snapshot.Object, "status", "error", "message")
+	return msg
+}
+
+// failSnapshot resumes the alpha members and marks the snapshot as failed with the
+// provided message.
+func (ds *Controller) failSnapshot(dsObj *dgraphio.DgraphSnapshot, msg string) error {
+	glog.Errorf("dgraph-snapshot-controller: DgraphSnapshot %s failed: %s",
+		dsObj.GetName(), msg)
+	if err := ds.resumeCluster(dsObj); err != nil {
+		return err
+	}
+
+	dsObj.Status.Phase = dgraphio.SnapshotPhaseFailed
+	dsObj.Status.Message = msg
+	return nil
+}
+
+// finalizeSnapshot resumes the alpha members of a DgraphSnapshot deleted while they were
+// draining. The volume snapshots are deleted by kubernetes along with it.
+func (ds *Controller) finalizeSnapshot(dsObj *dgraphio.DgraphSnapshot) error {
+	if !hasSnapshotFinalizer(dsObj) {
+		return nil
+	}
+
+	glog.Infof("dgraph-snapshot-controller: finalizing deleted DgraphSnapshot %s",
+		dsObj.GetName())
+	return ds.resumeCluster(dsObj)
+}
+
+// resumeCluster resumes all the alpha members of the cluster of the snapshot and removes
+// its finalizer once they have been.
+func (ds *Controller) resumeCluster(dsObj *dgraphio.DgraphSnapshot) error {
+	if !hasSnapshotFinalizer(dsObj) {
+		return nil
+	}
+
+	dc, err := ds.dgraphClusterLister.DgraphClusters(dsObj.GetNamespace()).
+		Get(dsObj.Spec.ClusterName)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil {
+		pods, err := ds.alphaPods(dc)
+		if err != nil {
+			return err
+		}
+		if err := resumeAlphas(pods); err != nil {
+			return err
+		}
+	}
+
+	return ds.updateSnapshotFinalizer(dsObj, false)
+}
+
+// alphaPods returns the ready alpha member pods of the provided DgraphCluster.
+func (ds *Controller) alphaPods(dc *dgraphio.DgraphCluster) ([]*corev1.Pod, error) {
+	selector := klabels.SelectorFromSet(dgraphk8s.DefaultAlphaLabels(
+		utils.DgraphAlphaMemberName(dc.Spec.GetClusterID(), dc.GetName())))
+	pods, err := ds.podLister.Pods(dc.GetNamespace()).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	ready := make([]*corev1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if k8s.IsPodReady(pod) && pod.Status.PodIP != "" {
+			ready = append(ready, pod)
+		}
+	}
+
+	return ready, nil
+}
+
+// resumeAlphas takes the provided alpha member pods out of draining mode. All the pods
+// are resumed even if one of them fails, the last error is returned.
+func resumeAlphas(pods []*corev1.Pod) error {
+	var resumeErr error
+	for _, pod := range pods {
+		if err := alphaClient(pod).SetDraining(false); err != nil {
+			glog.Errorf("dgraph-snapshot-controller: error resuming alpha member %s: %s",
+				pod.GetName(), err)
+			resumeErr = err
+		}
+	}
+
+	return resumeErr
+}
+
+// alphaClient returns a client of the alpha member running in the provided pod, as
+// draining mode only applies to the member the request is sent to.
+func alphaClient(pod *corev1.Pod) *dgraph.Client {
+	return dgraph.NewClient(fmt.Sprintf("http://%s:%d", pod.Status.PodIP,
+		defaults.AlphaHTTPPort))
+}
+
+// updateSnapshotFinalizer adds or removes the finalizer of the DgraphSnapshot object
+// represented by dsObj, which is updated with the resulting metadata.
+func (ds *Controller) updateSnapshotFinalizer(dsObj *dgraphio.DgraphSnapshot,
+	add bool) error {
+	if hasSnapshotFinalizer(dsObj) == add {
+		return nil
+	}
+
+	ns := dsObj.GetNamespace()
+	name := dsObj.GetName()
+
+	latest := dsObj.DeepCopy()
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		update := latest.DeepCopy()
+		update.Finalizers = make([]string, 0, len(latest.Finalizers)+1)
+		for _, finalizer := range latest.Finalizers {
+			if finalizer != defaults.SnapshotFinalizer {
+				update.Finalizers = append(update.Finalizers, finalizer)
+			}
+		}
+		if add {
+			update.Finalizers = append(update.Finalizers, defaults.SnapshotFinalizer)
+		}
+
+		updated, updateErr := ds.dgraphClient.DgraphV1alpha1().DgraphSnapshots(ns).Update(update)
+		if updateErr == nil {
+			dsObj.SetResourceVersion(updated.GetResourceVersion())
+			dsObj.SetFinalizers(updated.GetFinalizers())
+			return nil
+		}
+
+		// Fetch the latest version of the object on conflict and retry with it.
+		fetched, err := ds.dgraphClient.DgraphV1alpha1().DgraphSnapshots(ns).
+			Get(name, metav1.GetOptions{})
+		if err != nil {
+			glog.Errorf("dgraph-snapshot-controller: error getting DgraphSnapshot %s: %s",
+				name, err)
+			return updateErr
+		}
+		latest = fetched
+		return updateErr
+	})
+}
+
+// hasSnapshotFinalizer returns true if the provided DgraphSnapshot object has the
+// finalizer resuming the alpha members.
+func hasSnapshotFinalizer(dsObj *dgraphio.DgraphSnapshot) bool {
+	for _, finalizer := range dsObj.GetFinalizers() {
+		if finalizer == defaults.SnapshotFinalizer {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UpdateDgraphSnapshotStatus updates the status of the DgraphSnapshot object represented
+// by dsObj with the status represented in dsStatus.
+func (ds *Controller) UpdateDgraphSnapshotStatus(
+	dsObj *dgraphio.DgraphSnapshot,
+	dsStatus *dgraphio.DgraphSnapshotStatus) error {
+
+	glog.Infof("dgraph-snapshot-controller: updating DgraphSnapshot %s status",
+		dsObj.GetName())
+	ns := dsObj.GetNamespace()
+	name := dsObj.GetName()
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		update := dsObj.DeepCopy()
+		update.Status = *dsStatus.DeepCopy()
+		_, updateErr := ds.dgraphClient.DgraphV1alpha1().DgraphSnapshots(ns).
+			UpdateStatus(update)
+		if updateErr == nil {
+			return nil
+		}
+
+		// Fetch the latest version of the object on conflict and retry with it.
+		latest, err := ds.dgraphClient.DgraphV1alpha1().DgraphSnapshots(ns).
+			Get(name, metav1.GetOptions{})
+		if err != nil {
+			glog.Errorf("dgraph-snapshot-controller: error getting DgraphSnapshot %s: %s",
+				name, err)
+			return updateErr
+		}
+		dsObj = latest
+		return updateErr
+	})
+}
@@ -25,12 +25,17 @@ import (
 	"github.com/dgraph-io/dgraph-operator/pkg/client/clientset/versioned"
 	informers "github.com/dgraph-io/dgraph-operator/pkg/client/informers/externalversions"
 	dc "github.com/dgraph-io/dgraph-operator/pkg/controller/dgraphcluster"
+	dg "github.com/dgraph-io/dgraph-operator/pkg/controller/dgraphgraphqlschema"
+	dl "github.com/dgraph-io/dgraph-operator/pkg/controller/dgraphliveload"
+	ds "github.com/dgraph-io/dgraph-operator/pkg/controller/dgraphschema"
+	dsnap "github.com/dgraph-io/dgraph-operator/pkg/controller/dgraphsnapshot"
 	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
 	"github.com/dgraph-io/dgraph-operator/pkg/k8s"
 
 	"github.com/golang/glog"
 	"github.com/google/uuid"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	k8sinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/leaderelection"
@@ -43,8 +48,9 @@ import (
 // All controllers which operator manages should be in the registered controller list
 // of Manager.
 type Manager struct {
-	k8sClient    kubernetes.Interface
-	dgraphClient versioned.Interface
+	k8sClient     kubernetes.Interface
+	dgraphClient  versioned.Interface
+	dynamicClient dynamic.Interface
 
 	registeredControllers []Controller
 }
@@ -53,6 +59,10 @@ type Manager struct {
 // must implement.
 // List of configured controllers managed by dgraph operator as of now are:
 // * DgraphClusterController
+// * DgraphLiveLoadController
+// * DgraphSchemaController
+// * DgraphGraphQLSchemaController
+// * DgraphSnapshotController
 type Controller interface {
 	// Run starts running the controller watching for required kubernetes resources
 	// and associating required handler with resource events.
@@ -72,6 +82,11 @@ func MustNewControllerManager() *Manager {
 		glog.Fatalf("error while building dgraph k8s client")
 	}
 
+	dynamicClient, err := k8s.DynamicClient()
+	if err != nil {
+		glog.Fatalf("error while building dynamic k8s client")
+	}
+
 	// We register controller during manager run. This is to make sure that
 	// Different controller can also share the same informer factory
 	// from kubernetes.
@@ -80,6 +95,7 @@ func MustNewControllerManager() *Manager {
 	return &Manager{
 		k8sClient,
 		dgraphClient,
+		dynamicClient,
 
 		registeredControllers,
 	}
@@ -197,6 +213,46 @@ func (cm *Manager) onStart(ctx context.Context) {
 	cm.registeredControllers = append(cm.registeredControllers, dc.NewController(
 		cm.k8sClient,
 		cm.dgraphClient,
+		cm.dynamicClient,
+		dgraphClusterInformer.Dgraph().V1alpha1().DgraphClusters(),
+		dgraphClusterInformer.Dgraph().V1alpha1().DgraphSnapshots(),
+		k8sInformerFactory,
+	))
+
+	// Add dgraph live load controller to registered controller list of the controller manager.
+	cm.registeredControllers = append(cm.registeredControllers, dl.NewController(
+		cm.k8sClient,
+		cm.dgraphClient,
+		dgraphClusterInformer.Dgraph().V1alpha1().DgraphLiveLoads(),
+		dgraphClusterInformer.Dgraph().V1alpha1().DgraphClusters(),
+		k8sInformerFactory,
+	))
+
+	// Add dgraph schema controller to registered controller list of the controller manager.
+	cm.registeredControllers = append(cm.registeredControllers, ds.NewController(
+		cm.k8sClient,
+		cm.dgraphClient,
+		dgraphClusterInformer.Dgraph().V1alpha1().DgraphSchemas(),
+		dgraphClusterInformer.Dgraph().V1alpha1().DgraphClusters(),
+		k8sInformerFactory,
+	))
+
+	// Add dgraph GraphQL schema controller to registered controller list of the controller
+	// manager.
+	cm.registeredControllers = append(cm.registeredControllers, dg.NewController(
+		cm.k8sClient,
+		cm.dgraphClient,
+		dgraphClusterInformer.Dgraph().V1alpha1().DgraphGraphQLSchemas(),
+		dgraphClusterInformer.Dgraph().V1alpha1().DgraphClusters(),
+		k8sInformerFactory,
+	))
+
+	// Add dgraph snapshot controller to registered controller list of the controller manager.
+	cm.registeredControllers = append(cm.registeredControllers, dsnap.NewController(
+		cm.k8sClient,
+		cm.dgraphClient,
+		cm.dynamicClient,
+		dgraphClusterInformer.Dgraph().V1alpha1().DgraphSnapshots(),
 		dgraphClusterInformer.Dgraph().V1alpha1().DgraphClusters(),
 		k8sInformerFactory,
 	))
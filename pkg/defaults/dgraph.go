@@ -16,6 +16,10 @@
 
 package defaults
 
+import (
+	"time"
+)
+
 const (
 	// AlphaMemberName is the component name of the alpha dgraph component.
 	AlphaMemberName string = "alpha"
@@ -53,10 +57,18 @@ const (
 	// HeadlessServiceSuffix is the suffix name to associate with dgraph headless services.
 	HeadlessServiceSuffix string = "headless"
 
+	// PodServiceSuffix is the suffix name to associate with the services exposing a single
+	// dgraph member outside of the kubernetes cluster.
+	PodServiceSuffix string = "external"
+
 	// ZeroPersistentVolumeMountPath is the mount path for persistent volume that should be
 	// attached to the zero container.
 	ZeroPersistentVolumeMountPath string = "/dgraph"
 
+	// ZeroRebalanceDisabledInterval is the rebalance interval passed to zero when automatic
+	// rebalancing of tablets is disabled, as zero has no flag to turn it off.
+	ZeroRebalanceDisabledInterval string = "876000h"
+
 	// AlphaPersistentVolumeMountPath is the mount path for persistent volume that should be
 	// attached to the alpha container.
 	AlphaPersistentVolumeMountPath string = "/dgraph"
@@ -85,4 +97,97 @@ const (
 
 	// RatelPort is the port for dgraph Ratel UI.
 	RatelPort int32 = 8000
+
+	// RatelImage is the default image of dgraph Ratel, which is run standalone for all the
+	// dgraph versions.
+	RatelImage string = "dgraph/ratel"
+
+	// RatelVersion is the default version of the standalone dgraph Ratel image.
+	RatelVersion string = "v21.12.0"
+
+	// BulkLoadMemberName is the component name of the dgraph bulk loader jobs.
+	BulkLoadMemberName string = "bulk-load"
+
+	// BulkLoadMemberSuffix is the suffix to add to identifiers whenever required which
+	// represents Dgraph bulk loader related components.
+	BulkLoadMemberSuffix string = "bulk"
+
+	// BulkLoadCopySuffix is the suffix to add to the bulk loader jobs which copy
+	// the bulk loader output to alpha persistent volumes.
+	BulkLoadCopySuffix string = "copy"
+
+	// DataSourceMountPath is the mount path of the volume containing the data
+	// and schema files for the bulk and live loaders.
+	DataSourceMountPath string = "/source"
+
+	// BulkLoadOutputMountPath is the mount path of the volume holding the output
+	// of the bulk loader.
+	BulkLoadOutputMountPath string = "/bulk"
+
+	// LiveLoadMemberName is the component name of the dgraph live loader jobs.
+	LiveLoadMemberName string = "live-load"
+
+	// LiveLoadMemberSuffix is the suffix to add to identifiers whenever required which
+	// represents Dgraph live loader related components.
+	LiveLoadMemberSuffix string = "live"
+
+	// LiveLoadTLSMountPath is the mount path of the TLS certificates for the live loader.
+	LiveLoadTLSMountPath string = "/dgraph-tls"
+
+	// ConfigMemberSuffix is the suffix to add to the config map holding the config file of
+	// a dgraph component.
+	ConfigMemberSuffix string = "config"
+
+	// ConfigMountPath is the mount path of the config file of dgraph components.
+	ConfigMountPath string = "/dgraph-config"
+
+	// StorageMigrationSuffix is the suffix to add to the temporary persistent volume claims
+	// and the jobs used to migrate the data of alpha members to a new storage class.
+	StorageMigrationSuffix string = "migration"
+
+	// StorageMigrationCopySuffix is the suffix to add to the storage migration jobs which
+	// copy the data of alpha members to the temporary persistent volume claims.
+	StorageMigrationCopySuffix string = "copy"
+
+	// StorageMigrationRestoreSuffix is the suffix to add to the storage migration jobs
+	// which copy the data of alpha members back to their recreated persistent volume claims.
+	StorageMigrationRestoreSuffix string = "restore"
+
+	// StorageMigrationMountPath is the mount path of the volumes copied by the storage
+	// migration jobs.
+	StorageMigrationMountPath string = "/migration"
+
+	// ConfigFileName is the name of the config file of dgraph components.
+	ConfigFileName string = "config.json"
+
+	// ConfigHashAnnotation is the pod annotation holding the hash of the config file of
+	// dgraph components, so that changes to the config file roll the pods.
+	ConfigHashAnnotation string = "dgraph.io/config-hash"
+
+	// TracingAgentName is the name of the tracing agent sidecar container.
+	TracingAgentName string = "tracing-agent"
+
+	// TracingAgentImage is the default image of the tracing agent sidecar, an
+	// OpenTelemetry collector receiving jaeger traces.
+	TracingAgentImage string = "otel/opentelemetry-collector:0.88.0"
+
+	// TracingAgentConfigFileName is the name of the config file of the tracing agent, held
+	// in the config map of the dgraph component.
+	TracingAgentConfigFileName string = "tracing-agent.yaml"
+
+	// TracingAgentJaegerPort is the port on which the tracing agent receives jaeger traces
+	// over HTTP from the dgraph container.
+	TracingAgentJaegerPort int32 = 14268
+
+	// AutoscalingScaleDownPercent is the default percentage of the autoscaling targets below
+	// which alpha is scaled down.
+	AutoscalingScaleDownPercent int32 = 50
+
+	// AutoscalingScaleUpCooldown is the default minimum time between a scaling operation
+	// and scaling up alpha.
+	AutoscalingScaleUpCooldown time.Duration = 5 * time.Minute
+
+	// AutoscalingScaleDownCooldown is the default minimum time between a scaling operation
+	// and scaling down alpha.
+	AutoscalingScaleDownCooldown time.Duration = 30 * time.Minute
 )
@@ -35,4 +35,50 @@ const (
 	// K8SDelimeter is the default delimeter for strings constructed for kubernetes context by
 	// dgraph.
 	K8SDelimeter string = "-"
+
+	// JobBackoffLimit is the number of retries for the kubernetes jobs created
+	// by the operator before marking them as failed.
+	JobBackoffLimit int32 = 3
+
+	// IngressClassAnnotation is the annotation setting the class of the ingress controller
+	// serving an ingress.
+	IngressClassAnnotation string = "kubernetes.io/ingress.class"
+
+	// GatewayAPIGroupVersion is the group version of the Gateway API routes created by the
+	// operator.
+	GatewayAPIGroupVersion string = "gateway.networking.k8s.io/v1"
+
+	// PVCRetentionFinalizer is the finalizer of the DgraphCluster objects whose persistent
+	// volume claims must be deleted along with them.
+	PVCRetentionFinalizer string = "dgraph.io/pvc-retention"
+
+	// VolumeSnapshotGroupVersion is the group version of the volume snapshots created by the
+	// operator.
+	VolumeSnapshotGroupVersion string = "snapshot.storage.k8s.io/v1"
+
+	// VolumeSnapshotResource is the resource of volume snapshots.
+	VolumeSnapshotResource string = "volumesnapshots"
+
+	// VolumeSnapshotKind is the kind of volume snapshots.
+	VolumeSnapshotKind string = "VolumeSnapshot"
+
+	// SnapshotFinalizer is the finalizer of the DgraphSnapshot objects whose alpha members
+	// are in draining mode, so that they are resumed if the snapshot is deleted.
+	SnapshotFinalizer string = "dgraph.io/snapshot-draining"
+
+	// SnapshotPollInterval is the interval in which volume snapshots are polled while the
+	// alpha members are in draining mode.
+	SnapshotPollInterval time.Duration = 2 * time.Second
+
+	// SnapshotDrainTimeout is the maximum time the alpha members are kept in draining mode
+	// waiting for the volume snapshots to be taken, after which the snapshot fails.
+	SnapshotDrainTimeout time.Duration = 5 * time.Minute
+
+	// VolumeUsageInterval is the interval in which the usage of the volumes of the dgraph
+	// members is collected from the kubelets.
+	VolumeUsageInterval time.Duration = time.Minute
+
+	// VolumeAutoExpandThresholdPercent is the default percentage of the capacity of a volume
+	// in use above which its claim is automatically expanded.
+	VolumeAutoExpandThresholdPercent int32 = 80
 )
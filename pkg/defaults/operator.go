@@ -33,6 +33,9 @@ const (
 	// WorkersCount is default number of workers to run for the operator controller.
 	WorkersCount int = 3
 
+	// ClusterDomain is the default DNS domain of the kubernetes cluster.
+	ClusterDomain string = "cluster.local"
+
 	// LeaseLockName is the default value of lease lock we acquire when doing leader elections
 	// among the operators.
 	LeaseLockName string = "dgraph-io-controller-manager"
@@ -46,4 +49,8 @@ const (
 	// LeaderElectionRetryPeriod is the retry period of current operator for leader election
 	// among operators.
 	LeaderElectionRetryPeriod time.Duration = 3 * time.Second
+
+	// DgraphRequestTimeout is the timeout for the requests made by the operator to the
+	// HTTP endpoints of dgraph components.
+	DgraphRequestTimeout time.Duration = 30 * time.Second
 )
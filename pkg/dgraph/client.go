@@ -0,0 +1,95 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package dgraph implements clients for the HTTP endpoints of dgraph components the
+// operator interacts with.
+package dgraph
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+)
+
+// Client is a client for the HTTP API of dgraph alpha.
+type Client struct {
+	addr       string
+	httpClient *http.Client
+}
+
+// NewClient returns a new client for the dgraph alpha HTTP API served at addr,
+// for example http://alpha:8080.
+func NewClient(addr string) *Client {
+	return &Client{
+		addr: strings.TrimSuffix(addr, "/"),
+		httpClient: &http.Client{
+			Timeout: defaults.DgraphRequestTimeout,
+		},
+	}
+}
+
+// responseError is an error returned by dgraph in the response body.
+type responseError struct {
+	Message string `json:"message"`
+}
+
+// response is the envelope of responses of dgraph alpha HTTP endpoints.
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []responseError `json:"errors"`
+}
+
+// post sends body to the endpoint at path and decodes the data of the response into
+// out if it is not nil.
+func (c *Client) post(path, contentType string, body []byte, out interface{}) error {
+	url := c.addr + path
+	resp, err := c.httpClient.Post(url, contentType, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request to %s failed with status %s: %s",
+			url, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+
+	var r response
+	if err := json.Unmarshal(respBody, &r); err != nil {
+		return fmt.Errorf("error decoding response of %s: %s", url, err)
+	}
+	if len(r.Errors) > 0 {
+		msgs := make([]string, 0, len(r.Errors))
+		for _, e := range r.Errors {
+			msgs = append(msgs, e.Message)
+		}
+		return fmt.Errorf("request to %s failed: %s", url, strings.Join(msgs, "; "))
+	}
+
+	if out == nil || len(r.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(r.Data, out)
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dgraph
+
+import (
+	"fmt"
+)
+
+// SetDraining puts the alpha member into draining mode, in which it rejects queries and
+// mutations, or resumes it. Draining mode only applies to the member the client talks to.
+func (c *Client) SetDraining(enable bool) error {
+	var data struct {
+		Draining *struct {
+			Response *struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			} `json:"response"`
+		} `json:"draining"`
+	}
+	query := `mutation($enable: Boolean) {
+  draining(enable: $enable) {
+    response { code message }
+  }
+}`
+	err := c.admin(query, map[string]interface{}{"enable": enable}, &data)
+	if err != nil {
+		return err
+	}
+
+	if data.Draining == nil || data.Draining.Response == nil {
+		return fmt.Errorf("draining returned no response")
+	}
+	if data.Draining.Response.Code != "Success" {
+		return fmt.Errorf("draining failed: %s", data.Draining.Response.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dgraph
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// graphQLRequest is the body of a request to a GraphQL endpoint.
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// admin runs the GraphQL query against the /admin endpoint of alpha and decodes the
+// data of the response into out.
+func (c *Client) admin(query string, variables map[string]interface{}, out interface{}) error {
+	body, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+
+	return c.post("/admin", "application/json", body, out)
+}
+
+// GraphQLSchema returns the current GraphQL SDL of the cluster, it is empty if no
+// GraphQL schema has been applied yet.
+func (c *Client) GraphQLSchema() (string, error) {
+	var data struct {
+		GetGQLSchema *struct {
+			Schema string `json:"schema"`
+		} `json:"getGQLSchema"`
+	}
+	if err := c.admin(`{ getGQLSchema { schema } }`, nil, &data); err != nil {
+		return "", err
+	}
+	if data.GetGQLSchema == nil {
+		return "", nil
+	}
+
+	return data.GetGQLSchema.Schema, nil
+}
+
+// UpdateGraphQLSchema applies the GraphQL SDL to the cluster.
+func (c *Client) UpdateGraphQLSchema(schema string) error {
+	var data struct {
+		UpdateGQLSchema *struct {
+			GQLSchema *struct {
+				Schema string `json:"schema"`
+			} `json:"gqlSchema"`
+		} `json:"updateGQLSchema"`
+	}
+	query := `mutation($schema: String!) {
+  updateGQLSchema(input: { set: { schema: $schema } }) {
+    gqlSchema { schema }
+  }
+}`
+	err := c.admin(query, map[string]interface{}{"schema": schema}, &data)
+	if err != nil {
+		return err
+	}
+
+	if data.UpdateGQLSchema == nil || data.UpdateGQLSchema.GQLSchema == nil {
+		return fmt.Errorf("updateGQLSchema returned no schema")
+	}
+	if data.UpdateGQLSchema.GQLSchema.Schema != schema {
+		return fmt.Errorf("updateGQLSchema returned a schema different from the one applied")
+	}
+
+	return nil
+}
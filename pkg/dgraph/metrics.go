@@ -0,0 +1,134 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dgraph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// PendingQueriesMetric is the number of queries being processed by an alpha member.
+	PendingQueriesMetric = "dgraph_pending_queries_total"
+
+	// MemoryInUseMetric is the memory in use by an alpha member in bytes.
+	MemoryInUseMetric = "dgraph_memory_inuse_bytes"
+)
+
+// Metrics are the values of the prometheus metrics exported by a dgraph component, keyed
+// by metric name. Values of the samples of a metric with different labels are summed.
+type Metrics map[string]float64
+
+// Get returns the value of the metric, or zero if the metric is not exported.
+func (m Metrics) Get(name string) float64 {
+	return m[name]
+}
+
+// DiskUsage returns the size in bytes of the LSM trees and value logs of the badger
+// stores of the component. Badger metrics are prefixed with the major version of badger,
+// for example badger_v2_lsm_size_bytes.
+func (m Metrics) DiskUsage() float64 {
+	var usage float64
+	for name, value := range m {
+		if !strings.HasPrefix(name, "badger_") {
+			continue
+		}
+		if strings.HasSuffix(name, "_lsm_size_bytes") ||
+			strings.HasSuffix(name, "_vlog_size_bytes") {
+			usage += value
+		}
+	}
+
+	return usage
+}
+
+// Metrics returns the prometheus metrics exported by the dgraph alpha member.
+func (c *Client) Metrics() (Metrics, error) {
+	url := c.addr + "/debug/prometheus_metrics"
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request to %s failed with status %s", url, resp.Status)
+	}
+
+	return ParseMetrics(resp.Body)
+}
+
+// ParseMetrics parses metrics in the prometheus text exposition format.
+func ParseMetrics(r io.Reader) (Metrics, error) {
+	metrics := make(Metrics)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, rest, err := splitMetricName(line)
+		if err != nil {
+			return nil, err
+		}
+		// The value may be followed by a timestamp.
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("missing value of metric %s", name)
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of metric %s: %s", name, err)
+		}
+		metrics[name] += value
+	}
+
+	return metrics, scanner.Err()
+}
+
+// splitMetricName splits a sample line into the metric name and the remaining line after
+// the labels of the sample.
+func splitMetricName(line string) (string, string, error) {
+	end := strings.IndexAny(line, "{ \t")
+	if end < 0 {
+		return "", "", fmt.Errorf("invalid metric sample: %s", line)
+	}
+	name := line[:end]
+	if line[end] != '{' {
+		return name, line[end:], nil
+	}
+
+	// Label values are quoted and may contain escaped quotes and braces.
+	quoted := false
+	for i := end + 1; i < len(line); i++ {
+		switch {
+		case quoted && line[i] == '\\':
+			i++
+		case line[i] == '"':
+			quoted = !quoted
+		case !quoted && line[i] == '}':
+			return name, line[i+1:], nil
+		}
+	}
+
+	return "", "", fmt.Errorf("unterminated labels of metric %s", name)
+}
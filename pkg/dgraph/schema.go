@@ -0,0 +1,227 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+)
+
+// reservedPredicatePrefix is the prefix of predicates and types managed by dgraph itself.
+const reservedPredicatePrefix = "dgraph."
+
+// Predicate is the definition of a predicate as reported by dgraph.
+type Predicate struct {
+	Predicate string   `json:"predicate"`
+	Type      string   `json:"type"`
+	List      bool     `json:"list,omitempty"`
+	Index     bool     `json:"index,omitempty"`
+	Tokenizer []string `json:"tokenizer,omitempty"`
+	Upsert    bool     `json:"upsert,omitempty"`
+	Reverse   bool     `json:"reverse,omitempty"`
+	Count     bool     `json:"count,omitempty"`
+	Lang      bool     `json:"lang,omitempty"`
+}
+
+// TypeField is a field of a type as reported by dgraph.
+type TypeField struct {
+	Name string `json:"name"`
+}
+
+// Type is the definition of a type as reported by dgraph.
+type Type struct {
+	Name   string      `json:"name"`
+	Fields []TypeField `json:"fields"`
+}
+
+// Schema is the DQL schema of a dgraph cluster.
+type Schema struct {
+	Predicates []Predicate `json:"schema"`
+	Types      []Type      `json:"types"`
+}
+
+// Schema returns the current DQL schema of the cluster.
+func (c *Client) Schema() (*Schema, error) {
+	schema := &Schema{}
+	if err := c.post("/query", "application/graphql+-", []byte("schema {}"), schema); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// Alter applies the DQL schema in the text format to the cluster.
+func (c *Client) Alter(schema string) error {
+	return c.post("/alter", "application/rdf", []byte(schema), nil)
+}
+
+// DropPredicate drops the predicate along with all its data from the cluster.
+func (c *Client) DropPredicate(predicate string) error {
+	body, err := json.Marshal(map[string]string{"drop_attr": predicate})
+	if err != nil {
+		return err
+	}
+
+	return c.post("/alter", "application/json", body, nil)
+}
+
+// SchemaText renders the DQL schema specification in the text format accepted
+// by dgraph alter.
+func SchemaText(spec *v1alpha1.DgraphSchemaSpec) string {
+	var sb strings.Builder
+	for _, p := range spec.Predicates {
+		fmt.Fprintf(&sb, "%s: %s", p.Name, predicateType(p.Type, p.List))
+		if len(p.Index) > 0 {
+			fmt.Fprintf(&sb, " @index(%s)", strings.Join(p.Index, ", "))
+		}
+		directives := []struct {
+			name    string
+			enabled bool
+		}{
+			{"@upsert", p.Upsert},
+			{"@reverse", p.Reverse},
+			{"@count", p.Count},
+			{"@lang", p.Lang},
+		}
+		for _, d := range directives {
+			if d.enabled {
+				fmt.Fprintf(&sb, " %s", d.name)
+			}
+		}
+		sb.WriteString(" .\n")
+	}
+
+	for _, t := range spec.Types {
+		fmt.Fprintf(&sb, "\ntype %s {\n", t.Name)
+		for _, f := range t.Fields {
+			fmt.Fprintf(&sb, "    %s\n", f)
+		}
+		sb.WriteString("}\n")
+	}
+
+	return sb.String()
+}
+
+// SchemaDiff is the difference between a DQL schema specification and the live
+// schema of a cluster.
+type SchemaDiff struct {
+	// Changes are the differences which can be applied without losing data.
+	Changes []string
+
+	// DestructiveChanges are the differences which may lose data when applied, changing
+	// the type of a predicate.
+	DestructiveChanges []string
+
+	// DroppedPredicates are the previously applied predicates in the cluster which are no
+	// longer part of the specification.
+	DroppedPredicates []string
+}
+
+// Empty returns true if the live schema matches the specification.
+func (sd *SchemaDiff) Empty() bool {
+	return len(sd.Changes) == 0 && len(sd.DestructiveChanges) == 0 &&
+		len(sd.DroppedPredicates) == 0
+}
+
+// DiffSchema compares the DQL schema specification with the live schema of the cluster.
+// Predicates and types reserved by dgraph are ignored, as well as the predicates of the
+// cluster which are neither in the specification nor in the previously applied ones.
+func DiffSchema(spec *v1alpha1.DgraphSchemaSpec, live *Schema, applied []string) *SchemaDiff {
+	diff := &SchemaDiff{}
+
+	livePredicates := make(map[string]Predicate, len(live.Predicates))
+	for _, p := range live.Predicates {
+		livePredicates[p.Predicate] = p
+	}
+
+	wanted := make(map[string]bool, len(spec.Predicates))
+	for _, p := range spec.Predicates {
+		wanted[p.Name] = true
+
+		lp, ok := livePredicates[p.Name]
+		switch {
+		case !ok:
+			diff.Changes = append(diff.Changes, fmt.Sprintf("predicate %s is missing", p.Name))
+		case lp.Type != p.Type || lp.List != p.List:
+			diff.DestructiveChanges = append(diff.DestructiveChanges,
+				fmt.Sprintf("predicate %s has type %s, expected %s",
+					p.Name, predicateType(lp.Type, lp.List), predicateType(p.Type, p.List)))
+		case !sameStrings(lp.Tokenizer, p.Index) || lp.Upsert != p.Upsert ||
+			lp.Reverse != p.Reverse || lp.Count != p.Count || lp.Lang != p.Lang:
+			diff.Changes = append(diff.Changes,
+				fmt.Sprintf("predicate %s has different indexes or directives", p.Name))
+		}
+	}
+
+	managed := make(map[string]bool, len(applied))
+	for _, predicate := range applied {
+		managed[predicate] = true
+	}
+	for _, p := range live.Predicates {
+		if !wanted[p.Predicate] && managed[p.Predicate] &&
+			!strings.HasPrefix(p.Predicate, reservedPredicatePrefix) {
+			diff.DroppedPredicates = append(diff.DroppedPredicates, p.Predicate)
+		}
+	}
+	sort.Strings(diff.DroppedPredicates)
+
+	liveTypes := make(map[string][]string, len(live.Types))
+	for _, t := range live.Types {
+		fields := make([]string, 0, len(t.Fields))
+		for _, f := range t.Fields {
+			fields = append(fields, f.Name)
+		}
+		liveTypes[t.Name] = fields
+	}
+	for _, t := range spec.Types {
+		fields, ok := liveTypes[t.Name]
+		if !ok {
+			diff.Changes = append(diff.Changes, fmt.Sprintf("type %s is missing", t.Name))
+		} else if !sameStrings(fields, t.Fields) {
+			diff.Changes = append(diff.Changes,
+				fmt.Sprintf("type %s has different fields", t.Name))
+		}
+	}
+
+	return diff
+}
+
+// predicateType returns the type of a predicate as written in the DQL schema.
+func predicateType(typ string, list bool) string {
+	if list {
+		return fmt.Sprintf("[%s]", typ)
+	}
+	return typ
+}
+
+// sameStrings returns true if both slices contain the same strings in any order.
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	as := append([]string(nil), a...)
+	bs := append([]string(nil), b...)
+	sort.Strings(as)
+	sort.Strings(bs)
+
+	return reflect.DeepEqual(as, bs)
+}
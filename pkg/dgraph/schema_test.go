@@ -0,0 +1,204 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dgraph
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+)
+
+// schemaTestSpec is the specification of the schema tests.
+var schemaTestSpec = &v1alpha1.DgraphSchemaSpec{
+	ClusterName: "test",
+	Predicates: []v1alpha1.PredicateSpec{
+		{Name: "name", Type: "string", Index: []string{"exact", "term"}, Lang: true},
+		{Name: "friend", Type: "uid", List: true, Reverse: true, Count: true},
+		{Name: "email", Type: "string", Index: []string{"hash"}, Upsert: true},
+	},
+	Types: []v1alpha1.TypeSpec{
+		{Name: "Person", Fields: []string{"name", "friend", "email"}},
+	},
+}
+
+// schemaTestLive returns a live schema matching schemaTestSpec, along with the predicates
+// and types reserved by dgraph and the provided extra predicates.
+func schemaTestLive(extra ...Predicate) *Schema {
+	live := &Schema{
+		Predicates: []Predicate{
+			{Predicate: "dgraph.type", Type: "string", List: true, Index: true,
+				Tokenizer: []string{"exact"}},
+			{Predicate: "email", Type: "string", Index: true, Tokenizer: []string{"hash"},
+				Upsert: true},
+			{Predicate: "friend", Type: "uid", List: true, Reverse: true, Count: true},
+			{Predicate: "name", Type: "string", Index: true, Tokenizer: []string{"term", "exact"},
+				Lang: true},
+		},
+		Types: []Type{
+			{Name: "dgraph.graphql", Fields: []TypeField{{Name: "dgraph.graphql.schema"}}},
+			{Name: "Person", Fields: []TypeField{{Name: "email"}, {Name: "name"},
+				{Name: "friend"}}},
+		},
+	}
+	live.Predicates = append(live.Predicates, extra...)
+
+	return live
+}
+
+func TestDiffSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		live    *Schema
+		applied []string
+
+		changes            []string
+		destructiveChanges []string
+		droppedPredicates  []string
+	}{
+		{
+			name: "matching schema",
+			live: schemaTestLive(),
+		},
+		{
+			name: "missing predicate and type",
+			live: &Schema{
+				Predicates: schemaTestLive().Predicates[:3],
+			},
+			changes: []string{"predicate name is missing", "type Person is missing"},
+		},
+		{
+			name: "type change",
+			live: func() *Schema {
+				live := schemaTestLive()
+				live.Predicates[1].Type = "default"
+				live.Predicates[2].List = false
+				return live
+			}(),
+			destructiveChanges: []string{
+				"predicate friend has type uid, expected [uid]",
+				"predicate email has type default, expected string",
+			},
+		},
+		{
+			name: "index added",
+			live: func() *Schema {
+				live := schemaTestLive()
+				live.Predicates[3].Tokenizer = []string{"exact"}
+				return live
+			}(),
+			changes: []string{"predicate name has different indexes or directives"},
+		},
+		{
+			name: "index removed",
+			live: func() *Schema {
+				live := schemaTestLive()
+				live.Predicates[1].Tokenizer = []string{"hash", "exact"}
+				return live
+			}(),
+			changes: []string{"predicate email has different indexes or directives"},
+		},
+		{
+			name: "directive removed",
+			live: func() *Schema {
+				live := schemaTestLive()
+				live.Predicates[2].Reverse = false
+				return live
+			}(),
+			changes: []string{"predicate friend has different indexes or directives"},
+		},
+		{
+			name: "type fields changed",
+			live: func() *Schema {
+				live := schemaTestLive()
+				live.Types[1].Fields = live.Types[1].Fields[:2]
+				return live
+			}(),
+			changes: []string{"type Person has different fields"},
+		},
+		{
+			name: "foreign predicates are ignored",
+			live: schemaTestLive(
+				Predicate{Predicate: "Post.title", Type: "string"},
+				Predicate{Predicate: "Post.author", Type: "uid"},
+			),
+			applied: []string{"email", "friend", "name"},
+		},
+		{
+			name: "dropped applied predicates only",
+			live: schemaTestLive(
+				Predicate{Predicate: "nickname", Type: "string"},
+				Predicate{Predicate: "Post.title", Type: "string"},
+				Predicate{Predicate: "age", Type: "int"},
+			),
+			applied:           []string{"age", "email", "friend", "name", "nickname"},
+			droppedPredicates: []string{"age", "nickname"},
+		},
+		{
+			name: "reserved predicates are never dropped",
+			live: schemaTestLive(
+				Predicate{Predicate: "dgraph.graphql.schema", Type: "string"},
+			),
+			applied: []string{"dgraph.graphql.schema", "email", "friend", "name"},
+		},
+		{
+			name:    "applied predicates already dropped",
+			live:    schemaTestLive(),
+			applied: []string{"age", "email", "friend", "name"},
+		},
+	}
+
+	for _, tt := range tests {
+		diff := DiffSchema(schemaTestSpec, tt.live, tt.applied)
+		if !reflect.DeepEqual(diff.Changes, tt.changes) {
+			t.Errorf("%s: changes: got %q, want %q", tt.name, diff.Changes, tt.changes)
+		}
+		if !reflect.DeepEqual(diff.DestructiveChanges, tt.destructiveChanges) {
+			t.Errorf("%s: destructive changes: got %q, want %q", tt.name,
+				diff.DestructiveChanges, tt.destructiveChanges)
+		}
+		if !reflect.DeepEqual(diff.DroppedPredicates, tt.droppedPredicates) {
+			t.Errorf("%s: dropped predicates: got %q, want %q", tt.name,
+				diff.DroppedPredicates, tt.droppedPredicates)
+		}
+		empty := tt.changes == nil && tt.destructiveChanges == nil &&
+			tt.droppedPredicates == nil
+		if diff.Empty() != empty {
+			t.Errorf("%s: Empty: got %t, want %t", tt.name, diff.Empty(), empty)
+		}
+	}
+}
+
+func TestSchemaText(t *testing.T) {
+	want := `name: string @index(exact, term) @lang .
+friend: [uid] @reverse @count .
+email: string @index(hash) @upsert .
+
+type Person {
+    name
+    friend
+    email
+}
+`
+	if got := SchemaText(schemaTestSpec); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := SchemaText(&v1alpha1.DgraphSchemaSpec{ClusterName: "test"}); got != "" {
+		t.Errorf("empty specification: got %q", got)
+	}
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dgraph
+
+import (
+	"fmt"
+)
+
+// Shutdown cleanly shuts down the alpha member the client talks to.
+func (c *Client) Shutdown() error {
+	var data struct {
+		Shutdown *struct {
+			Response *struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			} `json:"response"`
+		} `json:"shutdown"`
+	}
+	query := `mutation {
+  shutdown {
+    response { code message }
+  }
+}`
+	if err := c.admin(query, nil, &data); err != nil {
+		return err
+	}
+
+	if data.Shutdown == nil || data.Shutdown.Response == nil {
+		return fmt.Errorf("shutdown returned no response")
+	}
+	if data.Shutdown.Response.Code != "Success" {
+		return fmt.Errorf("shutdown failed: %s", data.Shutdown.Response.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,230 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+)
+
+// ZeroClient is a client for the HTTP API of dgraph zero.
+type ZeroClient struct {
+	addr       string
+	httpClient *http.Client
+}
+
+// NewZeroClient returns a new client for the dgraph zero HTTP API served at addr,
+// for example http://zero:6080.
+func NewZeroClient(addr string) *ZeroClient {
+	return &ZeroClient{
+		addr: strings.TrimSuffix(addr, "/"),
+		httpClient: &http.Client{
+			Timeout: defaults.DgraphRequestTimeout,
+		},
+	}
+}
+
+// ZeroMember is a member of the zero cluster or of an alpha group as reported by zero.
+type ZeroMember struct {
+	ID      json.Number `json:"id"`
+	GroupID uint32      `json:"groupId,omitempty"`
+	Addr    string      `json:"addr"`
+	Leader  bool        `json:"leader,omitempty"`
+}
+
+// Hostname returns the hostname of the member, which is the name of its pod.
+func (m *ZeroMember) Hostname() string {
+	host, _, err := net.SplitHostPort(m.Addr)
+	if err != nil {
+		host = m.Addr
+	}
+
+	return strings.SplitN(host, ".", 2)[0]
+}
+
+// HTTPAddress returns the address of the HTTP endpoint of the zero member.
+func (m *ZeroMember) HTTPAddress() (string, error) {
+	host, _, err := net.SplitHostPort(m.Addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid address of zero member %s: %s", m.ID, err)
+	}
+
+	return fmt.Sprintf("http://%s", net.JoinHostPort(host,
+		strconv.Itoa(int(defaults.ZeroHTTPPort)))), nil
+}
+
+// Tablet is the tablet of a predicate as reported by zero.
+type Tablet struct {
+	GroupID   uint32 `json:"groupId"`
+	Predicate string `json:"predicate"`
+
+	// Size of the tablet on disk, reported as space before dgraph v20.11.
+	OnDiskBytes json.Number `json:"onDiskBytes,omitempty"`
+	Space       json.Number `json:"space,omitempty"`
+}
+
+// Size returns the size of the tablet on disk in bytes.
+func (t *Tablet) Size() int64 {
+	size := t.OnDiskBytes
+	if size == "" {
+		size = t.Space
+	}
+	bytes, _ := size.Int64()
+
+	return bytes
+}
+
+// ZeroGroup is an alpha group as reported by zero.
+type ZeroGroup struct {
+	Members map[string]ZeroMember `json:"members"`
+	Tablets map[string]Tablet     `json:"tablets"`
+}
+
+// Size returns the size on disk of all the tablets of the group in bytes.
+func (g *ZeroGroup) Size() int64 {
+	var size int64
+	for _, tablet := range g.Tablets {
+		size += tablet.Size()
+	}
+
+	return size
+}
+
+// ZeroState is the membership state of the cluster as reported by zero.
+type ZeroState struct {
+	Groups map[string]ZeroGroup  `json:"groups"`
+	Zeros  map[string]ZeroMember `json:"zeros"`
+}
+
+// Leader returns the leader of the zero cluster.
+func (s *ZeroState) Leader() (*ZeroMember, error) {
+	for _, zero := range s.Zeros {
+		if zero.Leader {
+			return &zero, nil
+		}
+	}
+
+	return nil, fmt.Errorf("zero cluster has no leader")
+}
+
+// Tablet returns the tablet of the predicate, or nil if the predicate has no tablet.
+func (s *ZeroState) Tablet(predicate string) *Tablet {
+	for _, group := range s.Groups {
+		if tablet, ok := group.Tablets[predicate]; ok {
+			return &tablet
+		}
+	}
+
+	return nil
+}
+
+// LeaderClient returns a client for the HTTP API of the leader of the zero cluster.
+func (s *ZeroState) LeaderClient() (*ZeroClient, error) {
+	leader, err := s.Leader()
+	if err != nil {
+		return nil, err
+	}
+	addr, err := leader.HTTPAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewZeroClient(addr), nil
+}
+
+// HasGroup returns true if the alpha group with the provided ID is part of the cluster.
+func (s *ZeroState) HasGroup(groupID uint32) bool {
+	_, ok := s.Groups[strconv.FormatUint(uint64(groupID), 10)]
+	return ok
+}
+
+// get sends a GET request to the endpoint at path with the provided query parameters and
+// returns the body of the response.
+func (c *ZeroClient) get(path string, params url.Values) ([]byte, error) {
+	reqURL := c.addr + path
+	if len(params) > 0 {
+		reqURL = reqURL + "?" + params.Encode()
+	}
+	resp, err := c.httpClient.Get(reqURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request to %s failed with status %s: %s",
+			reqURL, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
+
+// State returns the current membership state of the cluster.
+func (c *ZeroClient) State() (*ZeroState, error) {
+	body, err := c.get("/state", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &ZeroState{}
+	if err := json.Unmarshal(body, state); err != nil {
+		return nil, fmt.Errorf("error decoding zero state: %s", err)
+	}
+
+	return state, nil
+}
+
+// MoveTablet moves the tablet of the predicate to the alpha group with the provided ID.
+// The request must be sent to the leader of the zero cluster.
+func (c *ZeroClient) MoveTablet(predicate string, groupID uint32) error {
+	_, err := c.get("/moveTablet", url.Values{
+		"tablet": []string{predicate},
+		"group":  []string{strconv.FormatUint(uint64(groupID), 10)},
+	})
+
+	return err
+}
+
+// RemoveNode removes the member with the provided raft ID from the alpha group. The
+// member must be stopped before it is removed and can't join the cluster again.
+func (c *ZeroClient) RemoveNode(id string, groupID uint32) error {
+	_, err := c.get("/removeNode", url.Values{
+		"id":    []string{id},
+		"group": []string{strconv.FormatUint(uint64(groupID), 10)},
+	})
+
+	return err
+}
+
+// IsTimeout returns true if the error is a timeout of a request to dgraph. Dgraph may
+// still be processing a request which timed out.
+func IsTimeout(err error) bool {
+	netErr, ok := err.(net.Error)
+	return ok && netErr.Timeout()
+}
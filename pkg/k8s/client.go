@@ -24,6 +24,7 @@ import (
 	"github.com/dgraph-io/dgraph-operator/pkg/client/clientset/versioned"
 	"github.com/dgraph-io/dgraph-operator/pkg/option"
 	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -87,6 +88,21 @@ func APIExtClient() (apiextensionsclient.Interface, error) {
 	return c, nil
 }
 
+// DynamicClient creates a new k8s dynamic client, used for the resources of the custom
+// resource definitions which may not be installed in the cluster.
+func DynamicClient() (dynamic.Interface, error) {
+	cfg, err := CreateConfig(option.OperatorConfig.K8sAPIServerURL,
+		option.OperatorConfig.KubeCfgPath)
+	if err != nil {
+		return nil, err
+	}
+	c, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
 // CreateConfig creates a rest.Config for connecting to k8s api-server.
 //
 // The precedence of the configuration selection is the following:
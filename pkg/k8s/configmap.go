@@ -0,0 +1,57 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package k8s
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// CreateNewConfigMap creates a new Kubernetes config map for the provided
+// config map object.
+func CreateNewConfigMap(k8sClient kubernetes.Interface, namespace string,
+	cm *corev1.ConfigMap) error {
+	_, err := k8sClient.CoreV1().
+		ConfigMaps(namespace).
+		Create(cm)
+	return err
+}
+
+// UpdateConfigMap updates the config map in the kubernetes cluster.
+func UpdateConfigMap(k8sClient kubernetes.Interface, namespace string,
+	cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	var updatedCM *corev1.ConfigMap
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		var updateErr error
+		updatedCM, updateErr = k8sClient.CoreV1().
+			ConfigMaps(namespace).
+			Update(cm)
+
+		return updateErr
+	})
+
+	return updatedCM, err
+}
+
+// DeleteConfigMap deletes a kubernetes config map from the cluster.
+func DeleteConfigMap(k8sClient kubernetes.Interface, namespace string,
+	cm *corev1.ConfigMap) error {
+	return k8sClient.CoreV1().
+		ConfigMaps(namespace).
+		Delete(cm.Name, nil)
+}
@@ -18,9 +18,12 @@ package manager
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
 	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+	k8sutils "github.com/dgraph-io/dgraph-operator/pkg/k8s/utils"
 	"github.com/dgraph-io/dgraph-operator/pkg/labels"
 	"github.com/dgraph-io/dgraph-operator/pkg/utils"
 
@@ -48,34 +51,47 @@ func NewAlphaService(dc *v1alpha1.DgraphCluster) *corev1.Service {
 	clusterID := dc.Spec.GetClusterID()
 
 	serviceName := utils.DgraphAlphaMemberName(clusterID, name)
+	spec := dc.AlphaClusterSpec()
 	alphaLabels := DefaultAlphaLabels(serviceName)
 
-	return &corev1.Service{
+	svc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            serviceName,
 			Namespace:       ns,
-			Labels:          alphaLabels,
+			Labels:          k8sutils.MergeStringMaps(spec.ServiceLabels, alphaLabels),
+			Annotations:     spec.ServiceAnnotations,
 			OwnerReferences: []metav1.OwnerReference{dc.AsOwnerReference()},
 		},
 		Spec: corev1.ServiceSpec{
-			Type: dc.Spec.AlphaServiceType(),
-			Ports: []corev1.ServicePort{
-				{
-					Name:       defaults.AlphaGRPCPortName,
-					Port:       defaults.AlphaGRPCPort,
-					TargetPort: intstr.FromInt(int(defaults.AlphaGRPCPort)),
-					Protocol:   corev1.ProtocolTCP,
-				},
-				{
-					Name:       defaults.AlphaHTTPPortName,
-					Port:       defaults.AlphaHTTPPort,
-					TargetPort: intstr.FromInt(int(defaults.AlphaHTTPPort)),
-					Protocol:   corev1.ProtocolTCP,
-				},
-			},
+			Type:     dc.Spec.AlphaServiceType(),
+			Ports:    alphaServicePorts(),
 			Selector: alphaLabels,
 		},
 	}
+	applyServiceSpec(svc, spec.Service, map[string]*v1alpha1.ServicePortSpec{
+		defaults.AlphaGRPCPortName: spec.Service.GetGRPC(),
+		defaults.AlphaHTTPPortName: spec.Service.GetHTTP(),
+	})
+
+	return svc
+}
+
+// alphaServicePorts returns the ports of the services of dgraph Alpha.
+func alphaServicePorts() []corev1.ServicePort {
+	return []corev1.ServicePort{
+		{
+			Name:       defaults.AlphaGRPCPortName,
+			Port:       defaults.AlphaGRPCPort,
+			TargetPort: intstr.FromInt(int(defaults.AlphaGRPCPort)),
+			Protocol:   corev1.ProtocolTCP,
+		},
+		{
+			Name:       defaults.AlphaHTTPPortName,
+			Port:       defaults.AlphaHTTPPort,
+			TargetPort: intstr.FromInt(int(defaults.AlphaHTTPPort)),
+			Protocol:   corev1.ProtocolTCP,
+		},
+	}
 }
 
 // NewAlphaHeadlessService constructs a K8s headless service object for dgraph Alpha
@@ -89,7 +105,7 @@ func NewAlphaHeadlessService(dc *v1alpha1.DgraphCluster) *corev1.Service {
 	// Change spec for kubernetes headless service
 	svc.Spec = corev1.ServiceSpec{
 		ClusterIP:                "None",
-		Ports:                    svc.Spec.Ports,
+		Ports:                    alphaServicePorts(),
 		Selector:                 svc.Spec.Selector,
 		PublishNotReadyAddresses: true,
 	}
@@ -97,33 +113,149 @@ func NewAlphaHeadlessService(dc *v1alpha1.DgraphCluster) *corev1.Service {
 	return svc
 }
 
+// NewAlphaPodServices constructs a K8s service object for each member of dgraph Alpha from
+// the provided DgraphCluster configuration, exposing the member outside of the kubernetes
+// cluster. It returns nil if pod services are not enabled.
+// Services are kept for the desired members while the stateful sets are scaled down, so
+// they don't lose their external IP or node port.
+func NewAlphaPodServices(dc *v1alpha1.DgraphCluster) []*corev1.Service {
+	podServices := dc.Spec.AlphaCluster.PodServices
+	if podServices == nil {
+		return nil
+	}
+
+	alphaName := utils.DgraphAlphaMemberName(dc.Spec.GetClusterID(), dc.GetName())
+	spec := dc.AlphaClusterSpec()
+
+	var services []*corev1.Service
+	for _, member := range AlphaMembers(dc) {
+		podName := fmt.Sprintf("%s%s%d", member.StatefulSet.GetName(), defaults.K8SDelimeter,
+			member.Ordinal)
+		svcLabels := labels.Labels(DefaultAlphaLabels(alphaName)).PodService(podName)
+
+		services = append(services, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            utils.DgraphPodServiceName(podName),
+				Namespace:       dc.GetNamespace(),
+				Labels:          k8sutils.MergeStringMaps(spec.ServiceLabels, svcLabels),
+				Annotations:     podServices.Annotations,
+				OwnerReferences: []metav1.OwnerReference{dc.AsOwnerReference()},
+			},
+			Spec: corev1.ServiceSpec{
+				Type:                  podServices.ServiceType(),
+				Ports:                 alphaServicePorts(),
+				ExternalTrafficPolicy: podServices.TrafficPolicy(),
+				SessionAffinity:       corev1.ServiceAffinityNone,
+				Selector: map[string]string{
+					appsv1.StatefulSetPodNameLabel: podName,
+				},
+			},
+		})
+	}
+
+	return services
+}
+
+// NewAlphaStatefulSets constructs the K8s stateful set objects for dgraph Alpha from the
+// provided DgraphCluster configuration. If alpha groups are specified there is one stateful
+// set for each group ordered by group ID, else a single stateful set for all the members.
+func NewAlphaStatefulSets(dc *v1alpha1.DgraphCluster) []*appsv1.StatefulSet {
+	groups := dc.Spec.AlphaCluster.AlphaGroups()
+	if len(groups) == 0 {
+		return []*appsv1.StatefulSet{NewAlphaStatefulSet(dc)}
+	}
+
+	statefulSets := make([]*appsv1.StatefulSet, 0, len(groups))
+	for i := range groups {
+		statefulSets = append(statefulSets, NewAlphaGroupStatefulSet(dc, &groups[i]))
+	}
+
+	return statefulSets
+}
+
 // NewAlphaStatefulSet constructs a K8s stateful set object for dgraph Alpha from the
-// provided DgraphCluster configuration.
+// provided DgraphCluster configuration running all the alpha members.
 func NewAlphaStatefulSet(dc *v1alpha1.DgraphCluster) *appsv1.StatefulSet {
+	ssName := utils.DgraphAlphaMemberName(dc.Spec.GetClusterID(), dc.GetName())
+
+	return newAlphaStatefulSet(dc, ssName, &v1alpha1.AlphaGroupSpec{
+		Replicas: dc.AlphaReplicas(),
+	})
+}
+
+// NewAlphaGroupStatefulSet constructs a K8s stateful set object for the members of the
+// provided dgraph Alpha group.
+func NewAlphaGroupStatefulSet(dc *v1alpha1.DgraphCluster,
+	group *v1alpha1.AlphaGroupSpec) *appsv1.StatefulSet {
+	ssName := utils.DgraphAlphaGroupMemberName(dc.Spec.GetClusterID(), dc.GetName(), group.ID)
+
+	return newAlphaStatefulSet(dc, ssName, group)
+}
+
+// newAlphaStatefulSet constructs a K8s stateful set object for dgraph Alpha running the
+// members of the provided group. Group ID is zero for the stateful set running all the
+// alpha members, in which case dgraph zero decides the group of each member.
+func newAlphaStatefulSet(dc *v1alpha1.DgraphCluster, ssName string,
+	group *v1alpha1.AlphaGroupSpec) *appsv1.StatefulSet {
 	ns := dc.GetNamespace()
 	name := dc.GetName()
 	clusterID := dc.Spec.GetClusterID()
 
-	ssName := utils.DgraphAlphaMemberName(clusterID, name)
-	zeroMemberName := utils.DgraphZeroMemberName(clusterID, name)
+	alphaName := utils.DgraphAlphaMemberName(clusterID, name)
 	headlessServiceName := fmt.Sprintf("%s%s%s",
-		ssName,
+		alphaName,
 		defaults.K8SDelimeter,
 		defaults.HeadlessServiceSuffix)
-	storageClassName := dc.Spec.AlphaCluster.PersistentStorage.StorageClassName
+
+	storage := dc.Spec.AlphaCluster.PersistentStorage
+	if group.PersistentStorage != nil {
+		storage = group.PersistentStorage
+	}
+
+	spec := dc.AlphaClusterSpec()
+	resources := spec.ResourceRequirements()
+	if group.Resources != nil {
+		resources = *group.Resources.DeepCopy()
+	}
 
 	lruMB := dc.Spec.AlphaCluster.LruMB()
 	if lruMB < defaults.MinLruMBValue {
 		lruMB = defaults.LruMBValue
 	}
-	alphaLabels := DefaultAlphaLabels(ssName)
 
-	replicaCount := dc.Spec.AlphaCluster.Replicas
-	partitionCount := dc.Spec.AlphaCluster.Replicas
-	// nolint
+	// Alpha service selects the members of all the groups, the group label is only
+	// used to select the members of the stateful set.
+	alphaLabels := labels.Labels(DefaultAlphaLabels(alphaName))
+	flags := dgraphFlags(dc.AlphaClusterSpec().Version)
+	alphaFlags := flags.AlphaCache(lruMB)
+	if group.ID > 0 {
+		alphaLabels.Group(strconv.Itoa(int(group.ID)))
+		alphaFlags = fmt.Sprintf("%s %s", alphaFlags, flags.AlphaGroup(group.ID))
+	}
+
+	replicaCount := group.Replicas
+	if replicas, ok := dc.HibernatedAlphaReplicas(ssName); ok {
+		replicaCount = replicas
+	}
+	// Members are restarted one at a time whenever the pod template changes.
+	partitionCount := int32(0)
+	// Alpha members must not start before the bulk loader output has been copied
+	// to their persistent volumes, nor while the cluster is hibernated.
+	if dc.AlphaBootstrapPending() || dc.AlphaHibernated() {
+		replicaCount = 0
+	}
+	config := dc.Spec.AlphaCluster.ComponentConfig()
+	tracing := dc.AlphaClusterSpec().TracingConfig(config)
+	alphaFlags += directoryFlags(dc.Spec.AlphaCluster.Volumes)
+	alphaFlags += tracingFlags(flags, tracing)
+	configMap := NewAlphaConfigMap(dc)
+	extraFlags := configFlags(config, configMap)
+
+	// Alpha members connect to any of the zero members, so that they can restart
+	// while a zero member is down.
 	AlphaRunCmd := fmt.Sprintf(`set -ex
-dgraph alpha --my=$(hostname -f):7080 --lru_mb %d --zero %s-0.%s-headless.${POD_NAMESPACE}.svc.cluster.local:5080
-`, lruMB, zeroMemberName, zeroMemberName)
+dgraph alpha --my=$(hostname -f):7080 %s --zero %s%s
+`, alphaFlags, strings.Join(ZeroAddresses(dc), ","), extraFlags)
 
 	podVolumeMounts := []corev1.VolumeMount{
 		{
@@ -168,13 +300,16 @@ dgraph alpha --my=$(hostname -f):7080 --lru_mb %d --zero %s-0.%s-headless.${POD_
 					},
 				},
 				VolumeMounts: podVolumeMounts,
-				Resources:    dc.AlphaClusterSpec().ResourceRequirements(),
+				Resources:    resources,
 			},
 		},
 		RestartPolicy: corev1.RestartPolicyAlways,
+		NodeSelector:  group.NodeSelector,
+		Tolerations:   group.Tolerations,
+		Affinity:      group.Affinity,
 	}
 
-	return &appsv1.StatefulSet{
+	ss := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            ssName,
 			Namespace:       ns,
@@ -196,27 +331,31 @@ dgraph alpha --my=$(hostname -f):7080 --lru_mb %d --zero %s-0.%s-headless.${POD_
 
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: alphaLabels,
+					Labels:      k8sutils.MergeStringMaps(spec.PodLabels, alphaLabels),
+					Annotations: spec.PodTemplateAnnotations(),
 				},
 				Spec: podSpec,
 			},
 			ServiceName: headlessServiceName,
-			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: ssName,
-					},
-					Spec: corev1.PersistentVolumeClaimSpec{
-						AccessModes: []corev1.PersistentVolumeAccessMode{
-							corev1.ReadWriteOnce,
-						},
-						StorageClassName: &storageClassName,
-						Resources: corev1.ResourceRequirements{
-							Requests: dc.Spec.AlphaCluster.PersistentStorage.StorageRequest(),
-						},
-					},
-				},
-			},
 		},
 	}
+	addStorage(ss, dc.Spec.AlphaCluster.Storage, storage, dc.Spec.AlphaCluster.Volumes,
+		k8sutils.MergeStringMaps(spec.PVCLabels, alphaLabels))
+	addConfigFile(&ss.Spec.Template, configMap)
+	addTracingAgent(&ss.Spec.Template, tracing)
+
+	return ss
+}
+
+// AlphaHTTPAddress returns the address of the HTTP endpoint of the dgraph Alpha service of
+// the provided DgraphCluster, reachable from within the kubernetes cluster. The headless
+// service is used if the HTTP port is not exposed on the service.
+func AlphaHTTPAddress(dc *v1alpha1.DgraphCluster) string {
+	svc := NewAlphaService(dc)
+	if !serviceExposesPort(svc, defaults.AlphaHTTPPortName) {
+		svc = NewAlphaHeadlessService(dc)
+	}
+
+	return fmt.Sprintf("http://%s", utils.ServiceAddress(svc.GetName(),
+		dc.GetNamespace(), ClusterDomain(dc), defaults.AlphaHTTPPort))
 }
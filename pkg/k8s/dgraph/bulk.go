@@ -0,0 +1,329 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+	"github.com/dgraph-io/dgraph-operator/pkg/labels"
+	"github.com/dgraph-io/dgraph-operator/pkg/utils"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// bulkLoadOutputVolumeName is the name of the pod volume holding the bulk loader output.
+const bulkLoadOutputVolumeName = "bulk-output"
+
+// DefaultBulkLoadLabels returns a map representing labels associated with dgraph bulk loader
+// component.
+func DefaultBulkLoadLabels(instanceName string) map[string]string {
+	bulkLoadLabels := labels.NewLabelSet().
+		Instance(instanceName).
+		Component(defaults.BulkLoadMemberName).
+		ManagedBy(defaults.DgraphOperatorName)
+
+	return bulkLoadLabels
+}
+
+// NewBulkLoadOutputPVC constructs a K8s persistent volume claim object to hold the output
+// of the dgraph bulk loader from the provided DgraphCluster configuration.
+func NewBulkLoadOutputPVC(dc *v1alpha1.DgraphCluster) *corev1.PersistentVolumeClaim {
+	ns := dc.GetNamespace()
+	name := dc.GetName()
+	clusterID := dc.Spec.GetClusterID()
+
+	pvcName := utils.DgraphBulkLoadName(clusterID, name)
+	storage := dc.Spec.Bootstrap.BulkLoad.PersistentStorage
+	storageClassName := storage.StorageClassName
+
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            pvcName,
+			Namespace:       ns,
+			Labels:          DefaultBulkLoadLabels(pvcName),
+			OwnerReferences: []metav1.OwnerReference{dc.AsOwnerReference()},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{
+				corev1.ReadWriteOnce,
+			},
+			StorageClassName: &storageClassName,
+			Resources: corev1.ResourceRequirements{
+				Requests: storage.StorageRequest(),
+			},
+		},
+	}
+}
+
+// NewBulkLoadJob constructs a K8s job object running dgraph bulk loader from the provided
+// DgraphCluster configuration.
+// The bulk loader writes one output shard per alpha group to the bulk load output volume.
+func NewBulkLoadJob(dc *v1alpha1.DgraphCluster) *batchv1.Job {
+	name := dc.GetName()
+	clusterID := dc.Spec.GetClusterID()
+	bulkLoad := dc.Spec.Bootstrap.BulkLoad
+
+	jobName := utils.DgraphBulkLoadName(clusterID, name)
+	reduceShards := dc.Spec.AlphaGroupCount()
+
+	args := []string{
+		"dgraph bulk",
+		fmt.Sprintf("--files %s", dataSourcePath(&bulkLoad.Source, bulkLoad.DataFiles)),
+		fmt.Sprintf("--schema %s", dataSourcePath(&bulkLoad.Source, bulkLoad.SchemaFile)),
+		fmt.Sprintf("--map_shards %d", bulkLoad.MapShardCount(reduceShards)),
+		fmt.Sprintf("--reduce_shards %d", reduceShards),
+		fmt.Sprintf("--zero %s", zeroAddress(dc, 0)),
+		fmt.Sprintf("--out %s/out", defaults.BulkLoadOutputMountPath),
+		fmt.Sprintf("--tmp %s/tmp", defaults.BulkLoadOutputMountPath),
+		"--replace_out",
+	}
+	if bulkLoad.Format != "" {
+		args = append(args, fmt.Sprintf("--format %s", bulkLoad.Format))
+	}
+	bulkLoadRunCmd := fmt.Sprintf("set -ex\nexec %s\n", strings.Join(args, " \\\n    "))
+
+	volumes, volumeMounts, envFrom := dataSourcePodConfig(&bulkLoad.Source)
+	volumes = append(volumes, corev1.Volume{
+		Name: bulkLoadOutputVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: jobName,
+			},
+		},
+	})
+	volumeMounts = append(volumeMounts, corev1.VolumeMount{
+		Name:      bulkLoadOutputVolumeName,
+		MountPath: defaults.BulkLoadOutputMountPath,
+	})
+
+	resources := corev1.ResourceRequirements{}
+	if bulkLoad.Resources != nil {
+		resources = *bulkLoad.Resources.DeepCopy()
+	}
+
+	container := corev1.Container{
+		Name:            jobName,
+		Image:           dc.AlphaClusterSpec().Image(),
+		ImagePullPolicy: dc.AlphaClusterSpec().PodImagePullPolicy(),
+		Command: []string{
+			"/bin/bash",
+			"-c",
+			bulkLoadRunCmd,
+		},
+		Env:          []corev1.EnvVar{podNamespaceEnvVar()},
+		EnvFrom:      envFrom,
+		VolumeMounts: volumeMounts,
+		Resources:    resources,
+	}
+
+	return newDgraphJob(dc, jobName, container, volumes)
+}
+
+// AlphaMember is a single member of the dgraph alpha cluster.
+type AlphaMember struct {
+	// StatefulSet is the stateful set running the member.
+	StatefulSet *appsv1.StatefulSet
+
+	// Ordinal is the ordinal of the member pod in the stateful set.
+	Ordinal int32
+
+	// Shard is the index of the bulk loader output shard for the group of the member.
+	Shard int32
+}
+
+// AlphaMembers returns all the members of the alpha cluster of the provided DgraphCluster
+// configuration, ordered by stateful set and ordinal. Members are counted from the desired
+// replicas of the stateful sets, even while they are scaled down.
+func AlphaMembers(dc *v1alpha1.DgraphCluster) []AlphaMember {
+	groups := dc.Spec.AlphaCluster.AlphaGroups()
+	groupSize := dc.Spec.AlphaGroupSize()
+
+	var members []AlphaMember
+	for i, ss := range NewAlphaStatefulSets(dc) {
+		// The single stateful set runs the replicas decided by the autoscaler, if enabled.
+		replicas := dc.AlphaReplicas()
+		if len(groups) > 0 {
+			replicas = groups[i].Replicas
+		}
+
+		for ordinal := int32(0); ordinal < replicas; ordinal++ {
+			// Output shard i of the bulk loader is served by group i+1.
+			shard := int32(i)
+			if len(groups) == 0 {
+				shard = ordinal / groupSize
+			}
+			members = append(members, AlphaMember{
+				StatefulSet: ss,
+				Ordinal:     ordinal,
+				Shard:       shard,
+			})
+		}
+	}
+
+	return members
+}
+
+// NewBulkLoadCopyJob constructs a K8s job object which copies the bulk loader output shard
+// for the group of the alpha member into the persistent volume holding its postings
+// directory. Index is the position of the member in AlphaMembers.
+func NewBulkLoadCopyJob(dc *v1alpha1.DgraphCluster, index int32,
+	member AlphaMember) *batchv1.Job {
+	name := dc.GetName()
+	clusterID := dc.Spec.GetClusterID()
+
+	jobName := utils.DgraphBulkLoadCopyName(clusterID, name, index)
+	outputClaimName := utils.DgraphBulkLoadName(clusterID, name)
+	// The postings directory is at the root of the volume whether it's the alpha
+	// persistent volume or an additional one.
+	alphaClaim := NewAlphaPostingsVolumeClaim(dc, member)
+	shard := member.Shard
+
+	// nolint
+	copyRunCmd := fmt.Sprintf(`set -ex
+rm -rf %s/p
+cp -a %s/out/%d/p %s/p
+`, defaults.AlphaPersistentVolumeMountPath, defaults.BulkLoadOutputMountPath, shard,
+		defaults.AlphaPersistentVolumeMountPath)
+
+	volumes := []corev1.Volume{
+		{
+			Name: bulkLoadOutputVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: outputClaimName,
+					ReadOnly:  true,
+				},
+			},
+		},
+		{
+			Name: alphaClaim.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: alphaClaim.Name,
+				},
+			},
+		},
+	}
+
+	container := corev1.Container{
+		Name:            jobName,
+		Image:           dc.AlphaClusterSpec().Image(),
+		ImagePullPolicy: dc.AlphaClusterSpec().PodImagePullPolicy(),
+		Command: []string{
+			"/bin/bash",
+			"-c",
+			copyRunCmd,
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      bulkLoadOutputVolumeName,
+				MountPath: defaults.BulkLoadOutputMountPath,
+				ReadOnly:  true,
+			},
+			{
+				Name:      alphaClaim.Name,
+				MountPath: defaults.AlphaPersistentVolumeMountPath,
+			},
+		},
+	}
+
+	return newDgraphJob(dc, jobName, container, volumes)
+}
+
+// NewAlphaPersistentVolumeClaims constructs the K8s persistent volume claim objects of all
+// the volumes of the alpha member.
+func NewAlphaPersistentVolumeClaims(member AlphaMember) []*corev1.PersistentVolumeClaim {
+	templates := member.StatefulSet.Spec.VolumeClaimTemplates
+	claims := make([]*corev1.PersistentVolumeClaim, 0, len(templates))
+	for i := range templates {
+		claims = append(claims, newMemberVolumeClaim(member.StatefulSet, member.Ordinal, &templates[i]))
+	}
+
+	return claims
+}
+
+// NewAlphaPostingsVolumeClaim constructs the K8s persistent volume claim object of the
+// volume holding the postings directory of the alpha member, which is an additional volume
+// if one holds it and the alpha persistent volume otherwise.
+func NewAlphaPostingsVolumeClaim(dc *v1alpha1.DgraphCluster,
+	member AlphaMember) *corev1.PersistentVolumeClaim {
+	ss := member.StatefulSet
+	templateName := ss.GetName()
+	for _, volume := range dc.Spec.AlphaCluster.Volumes {
+		if volume.Directory == v1alpha1.DirectoryPostings {
+			templateName = volumeClaimTemplateName(ss, volume)
+		}
+	}
+
+	templates := ss.Spec.VolumeClaimTemplates
+	for i := range templates {
+		if templates[i].Name == templateName {
+			return newMemberVolumeClaim(member.StatefulSet, member.Ordinal, &templates[i])
+		}
+	}
+
+	return newMemberVolumeClaim(member.StatefulSet, member.Ordinal, &templates[0])
+}
+
+// newDgraphJob constructs a K8s job object owned by the provided DgraphCluster running
+// the container to completion.
+func newDgraphJob(dc *v1alpha1.DgraphCluster, jobName string, container corev1.Container,
+	volumes []corev1.Volume) *batchv1.Job {
+	jobLabels := DefaultBulkLoadLabels(jobName)
+	backoffLimit := defaults.JobBackoffLimit
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            jobName,
+			Namespace:       dc.GetNamespace(),
+			Labels:          jobLabels,
+			OwnerReferences: []metav1.OwnerReference{dc.AsOwnerReference()},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: jobLabels,
+				},
+				Spec: corev1.PodSpec{
+					Containers:    []corev1.Container{container},
+					Volumes:       volumes,
+					RestartPolicy: corev1.RestartPolicyNever,
+				},
+			},
+		},
+	}
+}
+
+// podNamespaceEnvVar returns the environment variable exposing the namespace of the
+// pod to the container.
+func podNamespaceEnvVar() corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: "POD_NAMESPACE",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: "metadata.namespace",
+			},
+		},
+	}
+}
@@ -0,0 +1,249 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+	"github.com/dgraph-io/dgraph-operator/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// configVolumeName is the name of the pod volume holding the config file of a dgraph
+// component.
+const configVolumeName = "config"
+
+// NewAlphaConfigMap constructs a K8s config map object holding the config file of dgraph
+// alpha and of its tracing agent from the provided DgraphCluster configuration. It returns
+// nil if neither is specified.
+func NewAlphaConfigMap(dc *v1alpha1.DgraphCluster) *corev1.ConfigMap {
+	alphaName := utils.DgraphAlphaMemberName(dc.Spec.GetClusterID(), dc.GetName())
+	config := dc.Spec.AlphaCluster.ComponentConfig()
+
+	return newConfigMap(dc, alphaName, DefaultAlphaLabels(alphaName), config,
+		dc.AlphaClusterSpec().TracingConfig(config))
+}
+
+// NewZeroConfigMap constructs a K8s config map object holding the config file of dgraph
+// zero and of its tracing agent from the provided DgraphCluster configuration. It returns
+// nil if neither is specified.
+func NewZeroConfigMap(dc *v1alpha1.DgraphCluster) *corev1.ConfigMap {
+	zeroName := utils.DgraphZeroMemberName(dc.Spec.GetClusterID(), dc.GetName())
+	config := dc.Spec.ZeroCluster.ComponentConfig()
+
+	return newConfigMap(dc, zeroName, DefaultZeroLabels(zeroName), config,
+		dc.ZeroClusterSpec().TracingConfig(config))
+}
+
+// newConfigMap constructs the config map holding the config files of the dgraph component
+// with the provided member name. Options are rendered as JSON, which dgraph reads based on
+// the extension of the config file and which is valid YAML for the tracing agent.
+func newConfigMap(dc *v1alpha1.DgraphCluster, memberName string, memberLabels map[string]string,
+	config *v1alpha1.DgraphConfig, tracing *v1alpha1.TracingSpec) *corev1.ConfigMap {
+	data := make(map[string]string)
+	if config != nil && len(config.ConfigFile) > 0 {
+		data[defaults.ConfigFileName] = renderConfigFile(config.ConfigFile)
+	}
+
+	if tracing != nil && tracing.Agent != nil {
+		data[defaults.TracingAgentConfigFileName] =
+			renderConfigFile(tracingAgentConfig(tracing.Agent))
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            utils.DgraphConfigName(memberName),
+			Namespace:       dc.GetNamespace(),
+			Labels:          memberLabels,
+			OwnerReferences: []metav1.OwnerReference{dc.AsOwnerReference()},
+		},
+		Data: data,
+	}
+}
+
+// renderConfigFile renders the provided options as indented JSON. Options only hold
+// strings, booleans, lists and maps of them, which can always be marshalled. Maps are
+// marshalled with sorted keys, so the content only changes with the options.
+func renderConfigFile(options interface{}) string {
+	content, _ := json.MarshalIndent(options, "", "  ")
+	return string(content)
+}
+
+// tracingAgentConfig returns the configuration of the OpenTelemetry collector running as
+// tracing agent, which receives the jaeger traces of dgraph on localhost and forwards them
+// to the OTLP endpoint of the agent specification.
+func tracingAgentConfig(agent *v1alpha1.TracingAgentSpec) map[string]interface{} {
+	return map[string]interface{}{
+		"receivers": map[string]interface{}{
+			"jaeger": map[string]interface{}{
+				"protocols": map[string]interface{}{
+					"thrift_http": map[string]interface{}{
+						"endpoint": fmt.Sprintf("localhost:%d", defaults.TracingAgentJaegerPort),
+					},
+				},
+			},
+		},
+		"exporters": map[string]interface{}{
+			"otlp": map[string]interface{}{
+				"endpoint": agent.Endpoint,
+				"tls": map[string]interface{}{
+					"insecure": agent.Insecure,
+				},
+			},
+		},
+		"service": map[string]interface{}{
+			"pipelines": map[string]interface{}{
+				"traces": map[string]interface{}{
+					"receivers": []string{"jaeger"},
+					"exporters": []string{"otlp"},
+				},
+			},
+		},
+	}
+}
+
+// tracingFlags returns the flags to append to the command of a dgraph component to send its
+// traces to the jaeger collector of the tracing configuration.
+func tracingFlags(flags *Flags, tracing *v1alpha1.TracingSpec) string {
+	if tracing == nil {
+		return ""
+	}
+
+	if tf := flags.Tracing(tracing.CollectorURL(), tracing.SamplingRatio); tf != "" {
+		return " " + tf
+	}
+	return ""
+}
+
+// configFlags returns the flags to append to the command of a dgraph component to pass it
+// the config file and the extra flags of its configuration. Extra flags are sorted by name
+// and quoted for the shell, they come last so that they override the operator flags.
+func configFlags(config *v1alpha1.DgraphConfig, cm *corev1.ConfigMap) string {
+	var flags []string
+	if cm != nil && cm.Data[defaults.ConfigFileName] != "" {
+		flags = append(flags, fmt.Sprintf("--config %s",
+			path.Join(defaults.ConfigMountPath, defaults.ConfigFileName)))
+	}
+
+	if config != nil {
+		names := make([]string, 0, len(config.ExtraFlags))
+		for name := range config.ExtraFlags {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			flags = append(flags, shellQuote(fmt.Sprintf("--%s=%s",
+				strings.TrimLeft(name, "-"), config.ExtraFlags[name])))
+		}
+	}
+
+	if len(flags) == 0 {
+		return ""
+	}
+	return " " + strings.Join(flags, " ")
+}
+
+// addConfigFile mounts the config files held in the config map into the dgraph container of
+// the pod template, and annotates the pod with the hash of the config files so that changes
+// to them roll the pods.
+func addConfigFile(template *corev1.PodTemplateSpec, cm *corev1.ConfigMap) {
+	if cm == nil {
+		return
+	}
+
+	template.Spec.Volumes = append(template.Spec.Volumes, corev1.Volume{
+		Name: configVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: cm.GetName(),
+				},
+			},
+		},
+	})
+	container := &template.Spec.Containers[0]
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      configVolumeName,
+		MountPath: defaults.ConfigMountPath,
+		ReadOnly:  true,
+	})
+
+	keys := make([]string, 0, len(cm.Data))
+	for key := range cm.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	for _, key := range keys {
+		fmt.Fprintf(hash, "%s\n%s\n", key, cm.Data[key])
+	}
+	if template.Annotations == nil {
+		template.Annotations = make(map[string]string)
+	}
+	template.Annotations[defaults.ConfigHashAnnotation] = hex.EncodeToString(hash.Sum(nil))
+}
+
+// addTracingAgent adds the tracing agent sidecar of the tracing configuration to the pod
+// template, reading its config file from the config map mounted by addConfigFile.
+func addTracingAgent(template *corev1.PodTemplateSpec, tracing *v1alpha1.TracingSpec) {
+	if tracing == nil || tracing.Agent == nil {
+		return
+	}
+
+	agent := corev1.Container{
+		Name:            defaults.TracingAgentName,
+		Image:           tracing.Agent.AgentImage(),
+		ImagePullPolicy: tracing.Agent.ImagePullPolicy,
+		Args: []string{
+			fmt.Sprintf("--config=%s",
+				path.Join(defaults.ConfigMountPath, defaults.TracingAgentConfigFileName)),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      configVolumeName,
+				MountPath: defaults.ConfigMountPath,
+				ReadOnly:  true,
+			},
+		},
+	}
+	if tracing.Agent.Resources != nil {
+		agent.Resources = *tracing.Agent.Resources.DeepCopy()
+	}
+
+	template.Spec.Containers = append(template.Spec.Containers, agent)
+}
+
+// shellQuote quotes the string as a single word for the shell.
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
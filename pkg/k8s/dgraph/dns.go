@@ -0,0 +1,41 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+	"github.com/dgraph-io/dgraph-operator/pkg/option"
+)
+
+// podNamespace is the namespace of the pods of dgraph components as expanded by the
+// shell from the environment of their containers.
+const podNamespace = "${POD_NAMESPACE}"
+
+// ClusterDomain returns the DNS domain of the kubernetes cluster used to address the
+// members of the provided DgraphCluster, which is the cluster domain of the operator
+// unless the DgraphCluster specifies one.
+func ClusterDomain(dc *v1alpha1.DgraphCluster) string {
+	if dc.Spec.ClusterDomain != "" {
+		return dc.Spec.ClusterDomain
+	}
+	if option.OperatorConfig.ClusterDomain != "" {
+		return option.OperatorConfig.ClusterDomain
+	}
+
+	return defaults.ClusterDomain
+}
@@ -0,0 +1,233 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+
+	go_version "github.com/blang/semver"
+)
+
+// releaseLine is a line of dgraph releases sharing the same command line flags.
+type releaseLine int
+
+const (
+	// releaseLineLruMB is dgraph v1.1 to v20.07, where the alpha cache is configured
+	// with --lru_mb.
+	releaseLineLruMB releaseLine = iota
+
+	// releaseLineCacheMB is dgraph v20.11, where --lru_mb is replaced with --cache_mb.
+	releaseLineCacheMB
+
+	// releaseLineSuperflags is dgraph v21.03 and later, where related options are
+	// grouped into superflags like --cache and --raft.
+	releaseLineSuperflags
+)
+
+// releaseLines are the supported dgraph release lines. Versions are matched on their
+// major and minor numbers only, so that release candidates belong to their release line.
+var releaseLines = []struct {
+	versions go_version.Range
+	line     releaseLine
+}{
+	{mustParseRange(">=1.1.0 <20.11.0"), releaseLineLruMB},
+	{mustParseRange(">=20.11.0 <21.3.0"), releaseLineCacheMB},
+	{mustParseRange(">=21.3.0 <25.0.0"), releaseLineSuperflags},
+}
+
+func mustParseRange(versions string) go_version.Range {
+	r, err := go_version.ParseRange(versions)
+	if err != nil {
+		panic(fmt.Errorf("cannot parse version range '%s' %s", versions, err))
+	}
+
+	return r
+}
+
+// parseDgraphVersion parses a dgraph image tag like v21.03.2 into its major and minor
+// version. Dgraph pads the minor version with zeros, which semver doesn't allow.
+func parseDgraphVersion(version string) (go_version.Version, error) {
+	release := strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(release, "-+"); i >= 0 {
+		release = release[:i]
+	}
+
+	parts := strings.Split(release, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return go_version.Version{}, fmt.Errorf("invalid dgraph version %q", version)
+	}
+	major, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return go_version.Version{}, fmt.Errorf("invalid dgraph version %q: %s", version, err)
+	}
+	minor, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return go_version.Version{}, fmt.Errorf("invalid dgraph version %q: %s", version, err)
+	}
+
+	return go_version.Version{Major: major, Minor: minor}, nil
+}
+
+// Flags renders the command line flags of dgraph components for a dgraph release line.
+type Flags struct {
+	line releaseLine
+}
+
+// NewFlags returns the flags of the provided dgraph version, or an error if the version
+// is not supported.
+func NewFlags(version string) (*Flags, error) {
+	ver, err := parseDgraphVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rl := range releaseLines {
+		if rl.versions(ver) {
+			return &Flags{line: rl.line}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported dgraph version %q", version)
+}
+
+// dgraphFlags returns the flags of the provided dgraph version of a DgraphCluster
+// component. Unsupported versions are rejected by ValidateVersions before the resources of
+// the components are built, the flags of the latest release line are returned for them.
+// Resources built outside of the DgraphCluster controller must use NewFlags instead.
+func dgraphFlags(version string) *Flags {
+	flags, err := NewFlags(version)
+	if err != nil {
+		return &Flags{line: releaseLineSuperflags}
+	}
+
+	return flags
+}
+
+// SupportsAlphaGroups returns true if the group of an alpha member can be specified
+// when starting it.
+func (f *Flags) SupportsAlphaGroups() bool {
+	return f.line >= releaseLineSuperflags
+}
+
+// AlphaCache returns the flag setting the size of the alpha cache in MB.
+func (f *Flags) AlphaCache(sizeMB int32) string {
+	switch f.line {
+	case releaseLineLruMB:
+		return fmt.Sprintf("--lru_mb %d", sizeMB)
+	case releaseLineCacheMB:
+		return fmt.Sprintf("--cache_mb %d", sizeMB)
+	default:
+		return fmt.Sprintf(`--cache "size-mb=%d"`, sizeMB)
+	}
+}
+
+// AlphaGroup returns the flag setting the group of an alpha member, it is empty if the
+// group can't be specified.
+func (f *Flags) AlphaGroup(groupID int32) string {
+	if !f.SupportsAlphaGroups() {
+		return ""
+	}
+
+	return fmt.Sprintf(`--raft "group=%d"`, groupID)
+}
+
+// ZeroIdx returns the flag setting the raft index of a zero member.
+func (f *Flags) ZeroIdx(idx string) string {
+	if f.line < releaseLineSuperflags {
+		return fmt.Sprintf("--idx %s", idx)
+	}
+
+	return fmt.Sprintf(`--raft "idx=%s"`, idx)
+}
+
+// LiveCredentials returns the flags setting the ACL credentials of the live loader.
+func (f *Flags) LiveCredentials(user, password string) string {
+	if f.line < releaseLineSuperflags {
+		return fmt.Sprintf(`--user "%s" --password "%s"`, user, password)
+	}
+
+	return fmt.Sprintf(`--creds "user=%s;password=%s"`, user, password)
+}
+
+// LiveTLS returns the flags setting the TLS certificates of the live loader, the client
+// certificate and key are optional. The flags contain no quotes so that they can be
+// stored in a shell variable and expanded.
+func (f *Flags) LiveTLS(caCert, clientCert, clientKey string) string {
+	if f.line < releaseLineSuperflags {
+		flags := fmt.Sprintf("--tls_cacert %s", caCert)
+		if clientCert != "" {
+			flags = fmt.Sprintf("%s --tls_cert %s --tls_key %s", flags, clientCert, clientKey)
+		}
+		return flags
+	}
+
+	flags := fmt.Sprintf("--tls=ca-cert=%s", caCert)
+	if clientCert != "" {
+		flags = fmt.Sprintf("%s;client-cert=%s;client-key=%s", flags, clientCert, clientKey)
+	}
+	return flags
+}
+
+// Tracing returns the flags setting the jaeger collector and the ratio of traced requests
+// of alpha and zero, it is empty if neither is specified.
+func (f *Flags) Tracing(jaegerCollector, samplingRatio string) string {
+	if f.line < releaseLineSuperflags {
+		var flags []string
+		if jaegerCollector != "" {
+			flags = append(flags, fmt.Sprintf("--jaeger.collector %s", shellQuote(jaegerCollector)))
+		}
+		if samplingRatio != "" {
+			flags = append(flags, fmt.Sprintf("--trace %s", samplingRatio))
+		}
+		return strings.Join(flags, " ")
+	}
+
+	var options []string
+	if samplingRatio != "" {
+		options = append(options, fmt.Sprintf("ratio=%s", samplingRatio))
+	}
+	if jaegerCollector != "" {
+		options = append(options, fmt.Sprintf("jaeger=%s", jaegerCollector))
+	}
+	if len(options) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("--trace %s", shellQuote(strings.Join(options, "; ")))
+}
+
+// ValidateVersions returns an error if the dgraph versions of the components of the
+// provided DgraphCluster are not supported or don't support the specified features.
+func ValidateVersions(dc *v1alpha1.DgraphCluster) error {
+	alphaFlags, err := NewFlags(dc.AlphaClusterSpec().Version)
+	if err != nil {
+		return fmt.Errorf("alpha: %s", err)
+	}
+	if _, err := NewFlags(dc.ZeroClusterSpec().Version); err != nil {
+		return fmt.Errorf("zero: %s", err)
+	}
+
+	if len(dc.Spec.AlphaCluster.Groups) > 0 && !alphaFlags.SupportsAlphaGroups() {
+		return fmt.Errorf("alpha groups require dgraph v21.03 or later, got %s",
+			dc.AlphaClusterSpec().Version)
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// flagsTests are the expected flags of each dgraph release line.
+var flagsTests = []struct {
+	versions []string
+
+	alphaCache      string
+	alphaGroup      string
+	zeroIdx         string
+	liveCredentials string
+	liveTLS         string
+	liveClientTLS   string
+	tracing         string
+	tracingRatio    string
+}{
+	{
+		versions:        []string{"v1.1.1", "v20.03.0", "v20.07.3", "v20.07.0-rc1"},
+		alphaCache:      "--lru_mb 2048",
+		alphaGroup:      "",
+		zeroIdx:         "--idx $idx",
+		liveCredentials: `--user "${DGRAPH_USER}" --password "${DGRAPH_PASSWORD}"`,
+		liveTLS:         "--tls_cacert /tls/ca.crt",
+		liveClientTLS:   "--tls_cacert /tls/ca.crt --tls_cert /tls/tls.crt --tls_key /tls/tls.key",
+		tracing:         "--jaeger.collector 'http://jaeger:14268' --trace 0.5",
+		tracingRatio:    "--trace 0.5",
+	},
+	{
+		versions:        []string{"v20.11.0", "v20.11.3"},
+		alphaCache:      "--cache_mb 2048",
+		alphaGroup:      "",
+		zeroIdx:         "--idx $idx",
+		liveCredentials: `--user "${DGRAPH_USER}" --password "${DGRAPH_PASSWORD}"`,
+		liveTLS:         "--tls_cacert /tls/ca.crt",
+		liveClientTLS:   "--tls_cacert /tls/ca.crt --tls_cert /tls/tls.crt --tls_key /tls/tls.key",
+		tracing:         "--jaeger.collector 'http://jaeger:14268' --trace 0.5",
+		tracingRatio:    "--trace 0.5",
+	},
+	{
+		versions:        []string{"v21.03.0", "v21.03.2", "v21.12.0", "v22.0.2", "v23.1.0"},
+		alphaCache:      `--cache "size-mb=2048"`,
+		alphaGroup:      `--raft "group=2"`,
+		zeroIdx:         `--raft "idx=$idx"`,
+		liveCredentials: `--creds "user=${DGRAPH_USER};password=${DGRAPH_PASSWORD}"`,
+		liveTLS:         "--tls=ca-cert=/tls/ca.crt",
+		liveClientTLS:   "--tls=ca-cert=/tls/ca.crt;client-cert=/tls/tls.crt;client-key=/tls/tls.key",
+		tracing:         "--trace 'ratio=0.5; jaeger=http://jaeger:14268'",
+		tracingRatio:    "--trace 'ratio=0.5'",
+	},
+}
+
+func TestFlags(t *testing.T) {
+	for _, tt := range flagsTests {
+		for _, version := range tt.versions {
+			flags, err := NewFlags(version)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %s", version, err)
+			}
+
+			checks := []struct {
+				name, got, want string
+			}{
+				{"AlphaCache", flags.AlphaCache(2048), tt.alphaCache},
+				{"AlphaGroup", flags.AlphaGroup(2), tt.alphaGroup},
+				{"ZeroIdx", flags.ZeroIdx("$idx"), tt.zeroIdx},
+				{"LiveCredentials", flags.LiveCredentials("${DGRAPH_USER}",
+					"${DGRAPH_PASSWORD}"), tt.liveCredentials},
+				{"LiveTLS", flags.LiveTLS("/tls/ca.crt", "", ""), tt.liveTLS},
+				{"LiveTLS with client certificate", flags.LiveTLS("/tls/ca.crt",
+					"/tls/tls.crt", "/tls/tls.key"), tt.liveClientTLS},
+				{"Tracing", flags.Tracing("http://jaeger:14268", "0.5"), tt.tracing},
+				{"Tracing with ratio only", flags.Tracing("", "0.5"), tt.tracingRatio},
+				{"Tracing disabled", flags.Tracing("", ""), ""},
+			}
+			for _, c := range checks {
+				if c.got != c.want {
+					t.Errorf("%s: %s: got %q, want %q", version, c.name, c.got, c.want)
+				}
+			}
+
+			if flags.SupportsAlphaGroups() != (tt.alphaGroup != "") {
+				t.Errorf("%s: SupportsAlphaGroups: got %t", version, flags.SupportsAlphaGroups())
+			}
+		}
+	}
+}
+
+func TestNewFlagsUnsupportedVersions(t *testing.T) {
+	for _, version := range []string{"", "latest", "v1.0.18", "v25.0.0", "v21"} {
+		if _, err := NewFlags(version); err == nil {
+			t.Errorf("%q: expected an error", version)
+		}
+	}
+}
+
+// newFlagsTestCluster returns a DgraphCluster running three zero and three alpha members
+// of the provided dgraph version.
+func newFlagsTestCluster(version string) *v1alpha1.DgraphCluster {
+	pullPolicy := corev1.PullIfNotPresent
+
+	return &v1alpha1.DgraphCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.DgraphClusterSpec{
+			ClusterID:       "c1",
+			BaseImage:       "dgraph/dgraph",
+			Version:         version,
+			ImagePullPolicy: &pullPolicy,
+			AlphaCluster: &v1alpha1.AlphaClusterSpec{
+				Replicas:          3,
+				PersistentStorage: &v1alpha1.ComponentPersistentStorage{},
+			},
+			ZeroCluster: &v1alpha1.ZeroClusterSpec{
+				Replicas:          3,
+				PersistentStorage: &v1alpha1.ComponentPersistentStorage{},
+			},
+		},
+	}
+}
+
+// zeroTestCommand is the command of the zero members of the flags test cluster, formatted
+// with the raft index flag.
+const zeroTestCommand = `set -ex
+[[ $(hostname) =~ -([0-9]+)$ ]] || exit 1
+ordinal=${BASH_REMATCH[1]}
+idx=$(($ordinal + 1))
+if [[ $ordinal -eq 0 ]]; then
+    exec dgraph zero --my=$(hostname -f):5080 %[1]s --replicas 3
+fi
+peer=c1-test-zero-0.c1-test-zero-headless.${POD_NAMESPACE}.svc.cluster.local:5080
+for i in $(seq 0 2); do
+    [[ $i -eq $ordinal ]] && continue
+    host=c1-test-zero-$i.c1-test-zero-headless.${POD_NAMESPACE}.svc.cluster.local
+    if timeout 2 bash -c "exec 3<>/dev/tcp/$host/5080" 2>/dev/null; then
+        peer=$host:5080
+        break
+    fi
+done
+exec dgraph zero --my=$(hostname -f):5080 --peer $peer %[1]s --replicas 3
+`
+
+// alphaTestCommand is the command of the alpha members of the flags test cluster,
+// formatted with the cache flag.
+const alphaTestCommand = `set -ex
+dgraph alpha --my=$(hostname -f):7080 %s --zero ` +
+	`c1-test-zero-0.c1-test-zero-headless.${POD_NAMESPACE}.svc.cluster.local:5080,` +
+	`c1-test-zero-1.c1-test-zero-headless.${POD_NAMESPACE}.svc.cluster.local:5080,` +
+	`c1-test-zero-2.c1-test-zero-headless.${POD_NAMESPACE}.svc.cluster.local:5080
+`
+
+func TestComponentCommands(t *testing.T) {
+	for _, tt := range flagsTests {
+		for _, version := range tt.versions {
+			dc := newFlagsTestCluster(version)
+			if err := ValidateVersions(dc); err != nil {
+				t.Fatalf("%s: unexpected error: %s", version, err)
+			}
+
+			zero := NewZeroStatefulSet(dc).Spec.Template.Spec.Containers[0].Command
+			if want := fmt.Sprintf(zeroTestCommand, tt.zeroIdx); zero[2] != want {
+				t.Errorf("%s: zero command:\n%s\nwant:\n%s", version, zero[2], want)
+			}
+
+			alpha := NewAlphaStatefulSet(dc).Spec.Template.Spec.Containers[0].Command
+			if want := fmt.Sprintf(alphaTestCommand, tt.alphaCache); alpha[2] != want {
+				t.Errorf("%s: alpha command:\n%s\nwant:\n%s", version, alpha[2], want)
+			}
+		}
+	}
+}
+
+func TestValidateVersions(t *testing.T) {
+	dc := newFlagsTestCluster("v20.11.0")
+	dc.Spec.AlphaCluster.Groups = []v1alpha1.AlphaGroupSpec{{ID: 1, Replicas: 3}}
+	if err := ValidateVersions(dc); err == nil {
+		t.Errorf("expected an error for alpha groups with dgraph v20.11")
+	}
+
+	dc = newFlagsTestCluster("v25.0.0")
+	if err := ValidateVersions(dc); err == nil {
+		t.Errorf("expected an error for an unsupported version")
+	}
+}
+
+func TestNewLiveLoadJobUnsupportedVersion(t *testing.T) {
+	dl := &v1alpha1.DgraphLiveLoad{
+		ObjectMeta: metav1.ObjectMeta{Name: "load", Namespace: "default"},
+	}
+	if _, err := NewLiveLoadJob(dl, newFlagsTestCluster("v25.0.0")); err == nil {
+		t.Errorf("expected an error for an unsupported version")
+	}
+}
@@ -0,0 +1,246 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+	k8sutils "github.com/dgraph-io/dgraph-operator/pkg/k8s/utils"
+	"github.com/dgraph-io/dgraph-operator/pkg/utils"
+
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// HTTPRouteResource is the resource name of Gateway API HTTP routes.
+	HTTPRouteResource = "httproutes"
+
+	// GRPCRouteResource is the resource name of Gateway API gRPC routes.
+	GRPCRouteResource = "grpcroutes"
+)
+
+// alphaBackendService returns the name of the alpha service routing the provided port,
+// which is the headless service if the port is not exposed on the alpha service.
+func alphaBackendService(dc *v1alpha1.DgraphCluster, portName string) string {
+	svc := NewAlphaService(dc)
+	if !serviceExposesPort(svc, portName) {
+		svc = NewAlphaHeadlessService(dc)
+	}
+
+	return svc.GetName()
+}
+
+// NewAlphaIngress constructs a K8s ingress object exposing the HTTP endpoint of dgraph
+// Alpha from the provided DgraphCluster configuration. It returns nil if no ingress is
+// configured for alpha.
+func NewAlphaIngress(dc *v1alpha1.DgraphCluster) *networkingv1beta1.Ingress {
+	spec := dc.Spec.AlphaCluster.Ingress
+	if spec == nil {
+		return nil
+	}
+
+	name := utils.DgraphAlphaMemberName(dc.Spec.GetClusterID(), dc.GetName())
+	return newIngress(dc, spec, name, DefaultAlphaLabels(name),
+		alphaBackendService(dc, defaults.AlphaHTTPPortName), defaults.AlphaHTTPPort)
+}
+
+// NewRatelIngress constructs a K8s ingress object exposing dgraph Ratel from the provided
+// DgraphCluster configuration. It returns nil if no ingress is configured for ratel.
+func NewRatelIngress(dc *v1alpha1.DgraphCluster) *networkingv1beta1.Ingress {
+	if dc.Spec.Ratel == nil || dc.Spec.Ratel.Ingress == nil {
+		return nil
+	}
+
+	name := utils.DgraphRatelMemberName(dc.Spec.GetClusterID(), dc.GetName())
+	return newIngress(dc, dc.Spec.Ratel.Ingress, name, DefaultRatelLabels(name),
+		NewRatelService(dc).GetName(), defaults.RatelPort)
+}
+
+func newIngress(dc *v1alpha1.DgraphCluster, spec *v1alpha1.ComponentIngressSpec,
+	name string, ingressLabels map[string]string, serviceName string,
+	port int32) *networkingv1beta1.Ingress {
+	annotations := spec.Annotations
+	if spec.ClassName != "" {
+		annotations = k8sutils.MergeStringMaps(annotations, map[string]string{
+			defaults.IngressClassAnnotation: spec.ClassName,
+		})
+	}
+
+	ing := &networkingv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       dc.GetNamespace(),
+			Labels:          ingressLabels,
+			Annotations:     annotations,
+			OwnerReferences: []metav1.OwnerReference{dc.AsOwnerReference()},
+		},
+		Spec: networkingv1beta1.IngressSpec{
+			Rules: []networkingv1beta1.IngressRule{
+				{
+					Host: spec.Host,
+					IngressRuleValue: networkingv1beta1.IngressRuleValue{
+						HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+							Paths: []networkingv1beta1.HTTPIngressPath{
+								{
+									Path: spec.IngressPath(),
+									Backend: networkingv1beta1.IngressBackend{
+										ServiceName: serviceName,
+										ServicePort: intstr.FromInt(int(port)),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if spec.TLSSecretName != "" {
+		tls := networkingv1beta1.IngressTLS{SecretName: spec.TLSSecretName}
+		if spec.Host != "" {
+			tls.Hosts = []string{spec.Host}
+		}
+		ing.Spec.TLS = []networkingv1beta1.IngressTLS{tls}
+	}
+
+	return ing
+}
+
+// NewAlphaHTTPRoute constructs a Gateway API HTTPRoute object exposing the HTTP endpoint
+// of dgraph Alpha from the provided DgraphCluster configuration. It returns nil if no
+// routes are configured for alpha.
+func NewAlphaHTTPRoute(dc *v1alpha1.DgraphCluster) *unstructured.Unstructured {
+	spec := dc.Spec.AlphaCluster.GatewayRoutes
+	if spec == nil {
+		return nil
+	}
+
+	name := utils.DgraphAlphaMemberName(dc.Spec.GetClusterID(), dc.GetName())
+	return newHTTPRoute(dc, spec, name, DefaultAlphaLabels(name),
+		alphaBackendService(dc, defaults.AlphaHTTPPortName), defaults.AlphaHTTPPort)
+}
+
+// NewAlphaGRPCRoute constructs a Gateway API GRPCRoute object exposing the gRPC endpoint
+// of dgraph Alpha from the provided DgraphCluster configuration. It returns nil if no
+// gRPC route is configured for alpha.
+func NewAlphaGRPCRoute(dc *v1alpha1.DgraphCluster) *unstructured.Unstructured {
+	spec := dc.Spec.AlphaCluster.GatewayRoutes
+	if spec == nil || !spec.GRPC {
+		return nil
+	}
+
+	name := utils.DgraphAlphaMemberName(dc.Spec.GetClusterID(), dc.GetName())
+	route := newRoute(dc, spec, "GRPCRoute", name, DefaultAlphaLabels(name))
+	route.Object["spec"].(map[string]interface{})["rules"] = []interface{}{
+		map[string]interface{}{
+			"backendRefs": routeBackendRefs(
+				alphaBackendService(dc, defaults.AlphaGRPCPortName), defaults.AlphaGRPCPort),
+		},
+	}
+
+	return route
+}
+
+// NewRatelHTTPRoute constructs a Gateway API HTTPRoute object exposing dgraph Ratel from
+// the provided DgraphCluster configuration. It returns nil if no routes are configured
+// for ratel.
+func NewRatelHTTPRoute(dc *v1alpha1.DgraphCluster) *unstructured.Unstructured {
+	if dc.Spec.Ratel == nil || dc.Spec.Ratel.GatewayRoutes == nil {
+		return nil
+	}
+
+	name := utils.DgraphRatelMemberName(dc.Spec.GetClusterID(), dc.GetName())
+	return newHTTPRoute(dc, dc.Spec.Ratel.GatewayRoutes, name, DefaultRatelLabels(name),
+		NewRatelService(dc).GetName(), defaults.RatelPort)
+}
+
+func newHTTPRoute(dc *v1alpha1.DgraphCluster, spec *v1alpha1.GatewayRoutesSpec,
+	name string, routeLabels map[string]string, serviceName string,
+	port int32) *unstructured.Unstructured {
+	route := newRoute(dc, spec, "HTTPRoute", name, routeLabels)
+	route.Object["spec"].(map[string]interface{})["rules"] = []interface{}{
+		map[string]interface{}{
+			"matches": []interface{}{
+				map[string]interface{}{
+					"path": map[string]interface{}{
+						"type":  "PathPrefix",
+						"value": spec.RoutePath(),
+					},
+				},
+			},
+			"backendRefs": routeBackendRefs(serviceName, port),
+		},
+	}
+
+	return route
+}
+
+// newRoute constructs a Gateway API route of the provided kind without any rules.
+func newRoute(dc *v1alpha1.DgraphCluster, spec *v1alpha1.GatewayRoutesSpec, kind,
+	name string, routeLabels map[string]string) *unstructured.Unstructured {
+	parentRefs := make([]interface{}, 0, len(spec.ParentRefs))
+	for _, ref := range spec.ParentRefs {
+		parentRef := map[string]interface{}{
+			"name": ref.Name,
+		}
+		if ref.Namespace != "" {
+			parentRef["namespace"] = ref.Namespace
+		}
+		if ref.SectionName != "" {
+			parentRef["sectionName"] = ref.SectionName
+		}
+		parentRefs = append(parentRefs, parentRef)
+	}
+
+	routeSpec := map[string]interface{}{
+		"parentRefs": parentRefs,
+	}
+	if len(spec.Hostnames) > 0 {
+		hostnames := make([]interface{}, 0, len(spec.Hostnames))
+		for _, host := range spec.Hostnames {
+			hostnames = append(hostnames, host)
+		}
+		routeSpec["hostnames"] = hostnames
+	}
+
+	route := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": routeSpec,
+		},
+	}
+	route.SetAPIVersion(defaults.GatewayAPIGroupVersion)
+	route.SetKind(kind)
+	route.SetName(name)
+	route.SetNamespace(dc.GetNamespace())
+	route.SetLabels(routeLabels)
+	route.SetAnnotations(spec.Annotations)
+	route.SetOwnerReferences([]metav1.OwnerReference{dc.AsOwnerReference()})
+
+	return route
+}
+
+func routeBackendRefs(serviceName string, port int32) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"name": serviceName,
+			"port": int64(port),
+		},
+	}
+}
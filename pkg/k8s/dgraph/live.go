@@ -0,0 +1,188 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+	"github.com/dgraph-io/dgraph-operator/pkg/labels"
+	"github.com/dgraph-io/dgraph-operator/pkg/utils"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// liveLoadTLSVolumeName is the name of the pod volume holding the TLS certificates.
+const liveLoadTLSVolumeName = "tls"
+
+// DefaultLiveLoadLabels returns a map representing labels associated with dgraph live loader
+// component.
+func DefaultLiveLoadLabels(instanceName string) map[string]string {
+	liveLoadLabels := labels.NewLabelSet().
+		Instance(instanceName).
+		Component(defaults.LiveLoadMemberName).
+		ManagedBy(defaults.DgraphOperatorName)
+
+	return liveLoadLabels
+}
+
+// NewLiveLoadJob constructs a K8s job object running dgraph live loader from the provided
+// DgraphLiveLoad configuration against the alpha and zero services of the DgraphCluster.
+// It returns an error if the dgraph version of alpha is not supported.
+func NewLiveLoadJob(dl *v1alpha1.DgraphLiveLoad,
+	dc *v1alpha1.DgraphCluster) (*batchv1.Job, error) {
+	liveLoad := &dl.Spec
+	jobName := utils.DgraphLiveLoadName(dl.GetName())
+
+	flags, err := NewFlags(dc.AlphaClusterSpec().Version)
+	if err != nil {
+		return nil, err
+	}
+	args := []string{
+		"dgraph live",
+		fmt.Sprintf("--files %s", dataSourcePath(&liveLoad.Source, liveLoad.DataFiles)),
+		fmt.Sprintf("--alpha %s:%d", NewAlphaService(dc).GetName(), defaults.AlphaGRPCPort),
+		fmt.Sprintf("--zero %s:%d", NewZeroService(dc).GetName(), defaults.ZeroGRPCPort),
+	}
+	if liveLoad.SchemaFile != "" {
+		args = append(args, fmt.Sprintf("--schema %s",
+			dataSourcePath(&liveLoad.Source, liveLoad.SchemaFile)))
+	}
+	if liveLoad.Format != "" {
+		args = append(args, fmt.Sprintf("--format %s", liveLoad.Format))
+	}
+	if liveLoad.BatchSize > 0 {
+		args = append(args, fmt.Sprintf("--batch %d", liveLoad.BatchSize))
+	}
+	if liveLoad.Concurrency > 0 {
+		args = append(args, fmt.Sprintf("--conc %d", liveLoad.Concurrency))
+	}
+
+	volumes, volumeMounts, envFrom := dataSourcePodConfig(&liveLoad.Source)
+	var (
+		env     []corev1.EnvVar
+		prelude string
+	)
+
+	if liveLoad.ACLSecretName != "" {
+		// Credentials are passed through the environment to keep them out of
+		// the pod specification.
+		env = append(env,
+			secretKeyEnvVar("DGRAPH_USER", liveLoad.ACLSecretName, "username"),
+			secretKeyEnvVar("DGRAPH_PASSWORD", liveLoad.ACLSecretName, "password"))
+		args = append(args, flags.LiveCredentials("${DGRAPH_USER}", "${DGRAPH_PASSWORD}"))
+	}
+
+	if liveLoad.TLSSecretName != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: liveLoadTLSVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: liveLoad.TLSSecretName,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      liveLoadTLSVolumeName,
+			MountPath: defaults.LiveLoadTLSMountPath,
+			ReadOnly:  true,
+		})
+
+		// Client certificates are optional, they are used only if present in the secret.
+		caFile := path.Join(defaults.LiveLoadTLSMountPath, "ca.crt")
+		certFile := path.Join(defaults.LiveLoadTLSMountPath, corev1.TLSCertKey)
+		keyFile := path.Join(defaults.LiveLoadTLSMountPath, corev1.TLSPrivateKeyKey)
+		prelude = fmt.Sprintf(`TLS_ARGS="%s"
+if [ -f %s ]; then
+    TLS_ARGS="%s"
+fi
+`, flags.LiveTLS(caFile, "", ""), certFile, flags.LiveTLS(caFile, certFile, keyFile))
+		args = append(args, "${TLS_ARGS}")
+	}
+
+	// Commands are not traced with -x so that the credentials don't end up in the logs.
+	liveLoadRunCmd := fmt.Sprintf("set -e\n%sexec %s\n", prelude,
+		strings.Join(args, " \\\n    "))
+
+	resources := corev1.ResourceRequirements{}
+	if liveLoad.Resources != nil {
+		resources = *liveLoad.Resources.DeepCopy()
+	}
+
+	jobLabels := DefaultLiveLoadLabels(jobName)
+	backoffLimit := defaults.JobBackoffLimit
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            jobName,
+			Namespace:       dl.GetNamespace(),
+			Labels:          jobLabels,
+			OwnerReferences: []metav1.OwnerReference{dl.AsOwnerReference()},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: jobLabels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:            jobName,
+							Image:           dc.AlphaClusterSpec().Image(),
+							ImagePullPolicy: dc.AlphaClusterSpec().PodImagePullPolicy(),
+							Command: []string{
+								"/bin/bash",
+								"-c",
+								liveLoadRunCmd,
+							},
+							Env:          env,
+							EnvFrom:      envFrom,
+							VolumeMounts: volumeMounts,
+							Resources:    resources,
+						},
+					},
+					Volumes:       volumes,
+					RestartPolicy: corev1.RestartPolicyNever,
+				},
+			},
+		},
+	}
+
+	return job, nil
+}
+
+// secretKeyEnvVar returns the environment variable exposing the key of the secret to the
+// container.
+func secretKeyEnvVar(name, secretName, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secretName,
+				},
+				Key: key,
+			},
+		},
+	}
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+	"github.com/dgraph-io/dgraph-operator/pkg/utils"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NewStorageMigrationClaim constructs the K8s persistent volume claim object holding the
+// data of the provided alpha member claim while it's migrated to the storage class of the
+// claim.
+func NewStorageMigrationClaim(claim *corev1.PersistentVolumeClaim) *corev1.PersistentVolumeClaim {
+	pvc := claim.DeepCopy()
+	pvc.Name = utils.DgraphStorageMigrationClaimName(claim.Name)
+
+	return pvc
+}
+
+// NewStorageMigrationCopyJob constructs a K8s job object which copies the data of the
+// persistent volume claims of the stopped alpha member with the provided pod name to their
+// temporary claims.
+func NewStorageMigrationCopyJob(dc *v1alpha1.DgraphCluster, podName string,
+	claims []string) *batchv1.Job {
+	temporary := make([]string, 0, len(claims))
+	for _, claim := range claims {
+		temporary = append(temporary, utils.DgraphStorageMigrationClaimName(claim))
+	}
+
+	return newStorageMigrationJob(dc,
+		utils.DgraphStorageMigrationJobName(podName, defaults.StorageMigrationCopySuffix),
+		claims, temporary)
+}
+
+// NewStorageMigrationRestoreJob constructs a K8s job object which copies the data of the
+// stopped alpha member with the provided pod name from the temporary claims back to its
+// persistent volume claims, once recreated with the new storage class.
+func NewStorageMigrationRestoreJob(dc *v1alpha1.DgraphCluster, podName string,
+	claims []string) *batchv1.Job {
+	temporary := make([]string, 0, len(claims))
+	for _, claim := range claims {
+		temporary = append(temporary, utils.DgraphStorageMigrationClaimName(claim))
+	}
+
+	return newStorageMigrationJob(dc,
+		utils.DgraphStorageMigrationJobName(podName, defaults.StorageMigrationRestoreSuffix),
+		temporary, claims)
+}
+
+// newStorageMigrationJob constructs a K8s job object which replaces the content of each
+// destination claim with the content of the source claim at the same index.
+func newStorageMigrationJob(dc *v1alpha1.DgraphCluster, jobName string, sources,
+	destinations []string) *batchv1.Job {
+	cmds := []string{"set -ex"}
+	var volumes []corev1.Volume
+	var volumeMounts []corev1.VolumeMount
+	for i := range sources {
+		src := fmt.Sprintf("%s/src-%d", defaults.StorageMigrationMountPath, i)
+		dst := fmt.Sprintf("%s/dst-%d", defaults.StorageMigrationMountPath, i)
+		// The destination is emptied first so that a failed copy can be retried.
+		cmds = append(cmds,
+			fmt.Sprintf("find %s -mindepth 1 -delete", dst),
+			fmt.Sprintf("cp -a %s/. %s/", src, dst))
+
+		volumes = append(volumes,
+			corev1.Volume{
+				Name: fmt.Sprintf("src-%d", i),
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: sources[i],
+						ReadOnly:  true,
+					},
+				},
+			},
+			corev1.Volume{
+				Name: fmt.Sprintf("dst-%d", i),
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: destinations[i],
+					},
+				},
+			})
+		volumeMounts = append(volumeMounts,
+			corev1.VolumeMount{
+				Name:      fmt.Sprintf("src-%d", i),
+				MountPath: src,
+				ReadOnly:  true,
+			},
+			corev1.VolumeMount{
+				Name:      fmt.Sprintf("dst-%d", i),
+				MountPath: dst,
+			})
+	}
+	cmds = append(cmds, "sync")
+
+	resources := corev1.ResourceRequirements{}
+	if migration := dc.Spec.AlphaCluster.StorageMigration; migration != nil &&
+		migration.Resources != nil {
+		resources = *migration.Resources.DeepCopy()
+	}
+
+	container := corev1.Container{
+		Name:            jobName,
+		Image:           dc.AlphaClusterSpec().Image(),
+		ImagePullPolicy: dc.AlphaClusterSpec().PodImagePullPolicy(),
+		Command: []string{
+			"/bin/bash",
+			"-c",
+			strings.Join(cmds, "\n") + "\n",
+		},
+		VolumeMounts: volumeMounts,
+		Resources:    resources,
+	}
+
+	return newDgraphJob(dc, jobName, container, volumes)
+}
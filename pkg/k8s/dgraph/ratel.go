@@ -17,8 +17,12 @@
 package manager
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
 	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+	k8sutils "github.com/dgraph-io/dgraph-operator/pkg/k8s/utils"
 	"github.com/dgraph-io/dgraph-operator/pkg/labels"
 	"github.com/dgraph-io/dgraph-operator/pkg/utils"
 
@@ -46,13 +50,15 @@ func NewRatelService(dc *v1alpha1.DgraphCluster) *corev1.Service {
 	clusterID := dc.Spec.GetClusterID()
 
 	serviceName := utils.DgraphRatelMemberName(clusterID, name)
+	spec := dc.RatelClusterSpec()
 	ratelLabels := DefaultRatelLabels(serviceName)
 
-	return &corev1.Service{
+	svc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            serviceName,
 			Namespace:       ns,
-			Labels:          ratelLabels,
+			Labels:          k8sutils.MergeStringMaps(spec.ServiceLabels, ratelLabels),
+			Annotations:     spec.ServiceAnnotations,
 			OwnerReferences: []metav1.OwnerReference{dc.AsOwnerReference()},
 		},
 		Spec: corev1.ServiceSpec{
@@ -68,6 +74,46 @@ func NewRatelService(dc *v1alpha1.DgraphCluster) *corev1.Service {
 			Selector: ratelLabels,
 		},
 	}
+	applyServiceSpec(svc, spec.Service, map[string]*v1alpha1.ServicePortSpec{
+		defaults.RatelPortName: spec.Service.GetHTTP(),
+	})
+
+	return svc
+}
+
+// ratelImage returns the image of dgraph Ratel. Unless a base image is specified for ratel,
+// the standalone ratel image is used for all the dgraph versions, as the ratel bundled with
+// older dgraph images can't be started with a default server URL.
+func ratelImage(dc *v1alpha1.DgraphCluster) string {
+	if dc.Spec.Ratel.BaseImage != "" {
+		return dc.RatelClusterSpec().Image()
+	}
+
+	version := dc.Spec.Ratel.Version
+	if version == "" {
+		version = defaults.RatelVersion
+	}
+
+	return fmt.Sprintf("%s:%s", defaults.RatelImage, version)
+}
+
+// RatelServerURL returns the URL of dgraph alpha ratel connects to by default.
+func RatelServerURL(dc *v1alpha1.DgraphCluster) string {
+	if dc.Spec.Ratel.ServerURL != "" {
+		return dc.Spec.Ratel.ServerURL
+	}
+
+	ingress := dc.Spec.AlphaCluster.Ingress
+	if ingress == nil || ingress.Host == "" {
+		return AlphaHTTPAddress(dc)
+	}
+	scheme := "http"
+	if ingress.TLSSecretName != "" {
+		scheme = "https"
+	}
+
+	return fmt.Sprintf("%s://%s%s", scheme, ingress.Host,
+		strings.TrimSuffix(ingress.IngressPath(), "/"))
 }
 
 // NewRatelDeployment constructs a K8s Deployment object for dgraph Ratel from
@@ -79,18 +125,22 @@ func NewRatelDeployment(dc *v1alpha1.DgraphCluster) *appsv1.Deployment {
 
 	deploymentName := utils.DgraphRatelMemberName(clusterID, name)
 	ratelLabels := DefaultRatelLabels(deploymentName)
-	replicas := dc.Spec.Ratel.Replicas
+	spec := dc.RatelClusterSpec()
+	replicas := dc.RatelReplicas()
+	if dc.RatelHibernated() {
+		replicas = 0
+	}
+
+	command := []string{"dgraph-ratel", "-addr", RatelServerURL(dc)}
 
 	// POD spec for the deployment.
 	podSpec := corev1.PodSpec{
 		Containers: []corev1.Container{
 			{
 				Name:            deploymentName,
-				Image:           dc.RatelClusterSpec().Image(),
+				Image:           ratelImage(dc),
 				ImagePullPolicy: dc.RatelClusterSpec().PodImagePullPolicy(),
-				Command: []string{
-					"dgraph-ratel",
-				},
+				Command:         command,
 				Ports: []corev1.ContainerPort{
 					{
 						Name:          defaults.RatelPortName,
@@ -110,9 +160,21 @@ func NewRatelDeployment(dc *v1alpha1.DgraphCluster) *appsv1.Deployment {
 					},
 				},
 				Resources: dc.RatelClusterSpec().ResourceRequirements(),
+				ReadinessProbe: &corev1.Probe{
+					Handler: corev1.Handler{
+						HTTPGet: &corev1.HTTPGetAction{
+							Path: "/",
+							Port: intstr.FromInt(int(defaults.RatelPort)),
+						},
+					},
+					InitialDelaySeconds: 5,
+					PeriodSeconds:       10,
+				},
 			},
 		},
 		RestartPolicy: corev1.RestartPolicyAlways,
+		NodeSelector:  spec.NodeSelector,
+		Tolerations:   spec.Tolerations,
 	}
 
 	return &appsv1.Deployment{
@@ -129,7 +191,8 @@ func NewRatelDeployment(dc *v1alpha1.DgraphCluster) *appsv1.Deployment {
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: ratelLabels,
+					Labels:      k8sutils.MergeStringMaps(spec.PodLabels, ratelLabels),
+					Annotations: spec.PodTemplateAnnotations(),
 				},
 				Spec: podSpec,
 			},
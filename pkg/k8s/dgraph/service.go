@@ -0,0 +1,87 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// applyServiceSpec applies the service configuration of a dgraph component to its service.
+// The configuration of each port is looked up by port name in ports. Options which don't
+// apply to the service type are ignored, as kubernetes rejects them. Kubernetes defaults
+// are set for the options not specified, so that removing an option reverts it.
+func applyServiceSpec(svc *corev1.Service, spec *v1alpha1.ComponentServiceSpec,
+	ports map[string]*v1alpha1.ServicePortSpec) {
+	if spec == nil {
+		spec = &v1alpha1.ComponentServiceSpec{}
+	}
+
+	external := svc.Spec.Type == corev1.ServiceTypeNodePort ||
+		svc.Spec.Type == corev1.ServiceTypeLoadBalancer
+
+	servicePorts := make([]corev1.ServicePort, 0, len(svc.Spec.Ports))
+	for _, port := range svc.Spec.Ports {
+		if portSpec := ports[port.Name]; portSpec != nil {
+			if portSpec.Disabled {
+				continue
+			}
+			if external {
+				port.NodePort = portSpec.NodePort
+			}
+		}
+		servicePorts = append(servicePorts, port)
+	}
+	svc.Spec.Ports = servicePorts
+
+	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		svc.Spec.LoadBalancerIP = spec.LoadBalancerIP
+		svc.Spec.LoadBalancerSourceRanges = spec.LoadBalancerSourceRanges
+	}
+	if external {
+		svc.Spec.ExternalTrafficPolicy = spec.ExternalTrafficPolicy
+		if svc.Spec.ExternalTrafficPolicy == "" {
+			svc.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeCluster
+		}
+	}
+
+	svc.Spec.SessionAffinity = spec.SessionAffinity
+	if svc.Spec.SessionAffinity == "" {
+		svc.Spec.SessionAffinity = corev1.ServiceAffinityNone
+	}
+	if spec.SessionAffinity == corev1.ServiceAffinityClientIP &&
+		spec.SessionAffinityTimeoutSeconds != nil {
+		timeout := *spec.SessionAffinityTimeoutSeconds
+		svc.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+			ClientIP: &corev1.ClientIPConfig{
+				TimeoutSeconds: &timeout,
+			},
+		}
+	}
+}
+
+// serviceExposesPort returns true if the service has a port with the provided name.
+func serviceExposesPort(svc *corev1.Service, portName string) bool {
+	for _, port := range svc.Spec.Ports {
+		if port.Name == portName {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// MemberVolumeClaim is a persistent volume claim of a dgraph member created from a volume
+// claim template of its stateful set.
+type MemberVolumeClaim struct {
+	v1alpha1.MemberVolume
+
+	// Claim is the persistent volume claim.
+	Claim *corev1.PersistentVolumeClaim
+}
+
+// MemberVolumeClaims returns the persistent volume claims of all the zero and alpha members
+// of the provided DgraphCluster configuration, identical to the ones their stateful sets
+// create. There are none for components with ephemeral storage.
+func MemberVolumeClaims(dc *v1alpha1.DgraphCluster) []MemberVolumeClaim {
+	claims := memberVolumeClaims(NewZeroStatefulSet(dc), defaults.ZeroMemberName, 0,
+		dc.Spec.ZeroCluster.Volumes)
+
+	groups := dc.Spec.AlphaCluster.AlphaGroups()
+	for i, ss := range NewAlphaStatefulSets(dc) {
+		group := int32(0)
+		if len(groups) > 0 {
+			group = groups[i].ID
+		}
+		claims = append(claims, memberVolumeClaims(ss, defaults.AlphaMemberName, group,
+			dc.Spec.AlphaCluster.Volumes)...)
+	}
+
+	return claims
+}
+
+// memberVolumeClaims returns the persistent volume claims of the members of the provided
+// stateful set, whose volume claim templates are the ones added by addStorage.
+func memberVolumeClaims(ss *appsv1.StatefulSet, component string, group int32,
+	volumes []v1alpha1.ComponentVolume) []MemberVolumeClaim {
+	var claims []MemberVolumeClaim
+	templates := ss.Spec.VolumeClaimTemplates
+	for ordinal := int32(0); ordinal < *ss.Spec.Replicas; ordinal++ {
+		for i := range templates {
+			// The first template holds the main volume, the others the additional
+			// volumes in order.
+			volume := ""
+			if i > 0 {
+				volume = volumes[i-1].Name
+			}
+
+			claims = append(claims, MemberVolumeClaim{
+				MemberVolume: v1alpha1.MemberVolume{
+					Component: component,
+					Group:     group,
+					Volume:    volume,
+					Ordinal:   ordinal,
+				},
+				Claim: newMemberVolumeClaim(ss, ordinal, &templates[i]),
+			})
+		}
+	}
+
+	return claims
+}
+
+// VolumeSnapshotName returns the name of the volume snapshot of the persistent volume claim
+// with the provided name taken by the DgraphSnapshot.
+// The format is <snapshotName>-<claimName>
+func VolumeSnapshotName(ds *v1alpha1.DgraphSnapshot, claimName string) string {
+	return fmt.Sprintf("%s%s%s", ds.GetName(), defaults.K8SDelimeter, claimName)
+}
+
+// NewVolumeSnapshot constructs the volume snapshot object of the persistent volume claim
+// with the provided name for the DgraphSnapshot configuration. Volume snapshots are owned
+// by the DgraphSnapshot, their content is deleted along with it depending on the deletion
+// policy of their class.
+func NewVolumeSnapshot(ds *v1alpha1.DgraphSnapshot, claimName string) *unstructured.Unstructured {
+	snapshotSpec := map[string]interface{}{
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": claimName,
+		},
+	}
+	if ds.Spec.VolumeSnapshotClassName != "" {
+		snapshotSpec["volumeSnapshotClassName"] = ds.Spec.VolumeSnapshotClassName
+	}
+
+	snapshot := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": snapshotSpec,
+		},
+	}
+	snapshot.SetAPIVersion(defaults.VolumeSnapshotGroupVersion)
+	snapshot.SetKind(defaults.VolumeSnapshotKind)
+	snapshot.SetName(VolumeSnapshotName(ds, claimName))
+	snapshot.SetNamespace(ds.GetNamespace())
+	snapshot.SetOwnerReferences([]metav1.OwnerReference{ds.AsOwnerReference()})
+
+	return snapshot
+}
+
+// NewRestoredVolumeClaim constructs the persistent volume claim object of a member of a
+// cluster being restored, populated from the volume snapshot with the provided name.
+func NewRestoredVolumeClaim(claim MemberVolumeClaim,
+	volumeSnapshot string) *corev1.PersistentVolumeClaim {
+	gv, _ := schema.ParseGroupVersion(defaults.VolumeSnapshotGroupVersion)
+	pvc := claim.Claim.DeepCopy()
+	pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
+		APIGroup: &gv.Group,
+		Kind:     defaults.VolumeSnapshotKind,
+		Name:     volumeSnapshot,
+	}
+
+	return pvc
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// dataSourceVolumeName is the name of the pod volume holding the files of a data source.
+const dataSourceVolumeName = "source"
+
+// dataSourcePath returns the path of the file relative to the data source as seen
+// by the loader running in the pod.
+func dataSourcePath(src *v1alpha1.DataSource, file string) string {
+	if src.ObjectStore != nil {
+		return fmt.Sprintf("%s/%s", strings.TrimSuffix(src.ObjectStore.URL, "/"),
+			strings.TrimPrefix(file, "/"))
+	}
+
+	return path.Join(defaults.DataSourceMountPath, file)
+}
+
+// dataSourcePodConfig returns the volumes, volume mounts and environment sources
+// required by a pod to read the files of the data source.
+func dataSourcePodConfig(src *v1alpha1.DataSource) (
+	[]corev1.Volume, []corev1.VolumeMount, []corev1.EnvFromSource) {
+	var (
+		volumes []corev1.Volume
+		mounts  []corev1.VolumeMount
+		envFrom []corev1.EnvFromSource
+	)
+
+	var volumeSource *corev1.VolumeSource
+	switch {
+	case src.ConfigMap != nil:
+		volumeSource = &corev1.VolumeSource{
+			ConfigMap: src.ConfigMap.DeepCopy(),
+		}
+	case src.PersistentVolumeClaim != nil:
+		claim := *src.PersistentVolumeClaim
+		claim.ReadOnly = true
+		volumeSource = &corev1.VolumeSource{
+			PersistentVolumeClaim: &claim,
+		}
+	}
+
+	if volumeSource != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name:         dataSourceVolumeName,
+			VolumeSource: *volumeSource,
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      dataSourceVolumeName,
+			MountPath: defaults.DataSourceMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	if src.ObjectStore != nil && src.ObjectStore.CredentialsSecretName != "" {
+		envFrom = append(envFrom, corev1.EnvFromSource{
+			SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: src.ObjectStore.CredentialsSecretName,
+				},
+			},
+		})
+	}
+
+	return volumes, mounts, envFrom
+}
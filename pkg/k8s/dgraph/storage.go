@@ -0,0 +1,124 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+	k8sutils "github.com/dgraph-io/dgraph-operator/pkg/k8s/utils"
+	"github.com/dgraph-io/dgraph-operator/pkg/utils"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// addStorage adds the volumes holding the data of the members of a dgraph component to
+// the provided stateful set. For ephemeral storage it's an emptyDir volume named after the
+// stateful set. For persistent storage it's a volume claim template named after the stateful
+// set, along with one for each additional volume of the component which is also mounted in
+// the member container.
+func addStorage(ss *appsv1.StatefulSet, storage *v1alpha1.ComponentStorageSpec,
+	persistentStorage *v1alpha1.ComponentPersistentStorage, volumes []v1alpha1.ComponentVolume,
+	pvcLabels map[string]string) {
+	if storage.Ephemeral() {
+		ss.Spec.Template.Spec.Volumes = append(ss.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: ss.GetName(),
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium:    storage.Medium,
+					SizeLimit: storage.SizeLimit,
+				},
+			},
+		})
+		return
+	}
+
+	ss.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{
+		newVolumeClaimTemplate(ss.GetName(), persistentStorage, pvcLabels),
+	}
+	container := &ss.Spec.Template.Spec.Containers[0]
+	for i := range volumes {
+		name := volumeClaimTemplateName(ss, volumes[i])
+		ss.Spec.VolumeClaimTemplates = append(ss.Spec.VolumeClaimTemplates,
+			newVolumeClaimTemplate(name, &volumes[i].ComponentPersistentStorage, pvcLabels))
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: volumes[i].VolumeMountPath(),
+		})
+	}
+}
+
+// volumeClaimTemplateName returns the name of the volume claim template of an additional
+// volume of the members of the provided stateful set.
+func volumeClaimTemplateName(ss *appsv1.StatefulSet, volume v1alpha1.ComponentVolume) string {
+	return fmt.Sprintf("%s%s%s", ss.GetName(), defaults.K8SDelimeter, volume.Name)
+}
+
+func newVolumeClaimTemplate(name string, persistentStorage *v1alpha1.ComponentPersistentStorage,
+	pvcLabels map[string]string) corev1.PersistentVolumeClaim {
+	storageClassName := persistentStorage.StorageClassName
+
+	return corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: pvcLabels,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{
+				corev1.ReadWriteOnce,
+			},
+			StorageClassName: &storageClassName,
+			Resources: corev1.ResourceRequirements{
+				Requests: persistentStorage.StorageRequest(),
+			},
+		},
+	}
+}
+
+// newMemberVolumeClaim constructs the K8s persistent volume claim object for the member
+// of the stateful set with the provided ordinal from a volume claim template of the stateful
+// set. The claim is identical to the one the stateful set would create, so that the stateful
+// set adopts it: it has the labels of the template along with the selector labels of the
+// stateful set.
+func newMemberVolumeClaim(ss *appsv1.StatefulSet, ordinal int32,
+	template *corev1.PersistentVolumeClaim) *corev1.PersistentVolumeClaim {
+	pvc := template.DeepCopy()
+
+	pvc.Name = utils.StatefulSetPVCName(pvc.Name, ss.Name, ordinal)
+	pvc.Namespace = ss.Namespace
+	pvc.Labels = k8sutils.MergeStringMaps(template.Labels, ss.Spec.Selector.MatchLabels)
+
+	return pvc
+}
+
+// directoryFlags returns the flags pointing dgraph at the data directories held by the
+// additional volumes of a component, to be appended to its command line.
+func directoryFlags(volumes []v1alpha1.ComponentVolume) string {
+	var flags string
+	for _, volume := range volumes {
+		flag := "--postings"
+		if volume.Directory != v1alpha1.DirectoryPostings {
+			flag = "--wal"
+		}
+		flags += fmt.Sprintf(" %s %s", flag, volume.DirectoryPath())
+	}
+
+	return flags
+}
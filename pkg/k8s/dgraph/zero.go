@@ -18,9 +18,11 @@ package manager
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
 	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+	k8sutils "github.com/dgraph-io/dgraph-operator/pkg/k8s/utils"
 	"github.com/dgraph-io/dgraph-operator/pkg/labels"
 	"github.com/dgraph-io/dgraph-operator/pkg/utils"
 
@@ -48,34 +50,47 @@ func NewZeroService(dc *v1alpha1.DgraphCluster) *corev1.Service {
 	clusterID := dc.Spec.GetClusterID()
 
 	serviceName := utils.DgraphZeroMemberName(clusterID, name)
+	spec := dc.ZeroClusterSpec()
 	zeroLabels := DefaultZeroLabels(serviceName)
 
-	return &corev1.Service{
+	svc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            serviceName,
 			Namespace:       ns,
-			Labels:          zeroLabels,
+			Labels:          k8sutils.MergeStringMaps(spec.ServiceLabels, zeroLabels),
+			Annotations:     spec.ServiceAnnotations,
 			OwnerReferences: []metav1.OwnerReference{dc.AsOwnerReference()},
 		},
 		Spec: corev1.ServiceSpec{
-			Type: dc.Spec.ZeroServiceType(),
-			Ports: []corev1.ServicePort{
-				{
-					Name:       defaults.ZeroGRPCPortName,
-					Port:       defaults.ZeroGRPCPort,
-					TargetPort: intstr.FromInt(int(defaults.ZeroGRPCPort)),
-					Protocol:   corev1.ProtocolTCP,
-				},
-				{
-					Name:       defaults.ZeroHTTPPortName,
-					Port:       defaults.ZeroHTTPPort,
-					TargetPort: intstr.FromInt(int(defaults.ZeroHTTPPort)),
-					Protocol:   corev1.ProtocolTCP,
-				},
-			},
+			Type:     dc.Spec.ZeroServiceType(),
+			Ports:    zeroServicePorts(),
 			Selector: zeroLabels,
 		},
 	}
+	applyServiceSpec(svc, spec.Service, map[string]*v1alpha1.ServicePortSpec{
+		defaults.ZeroGRPCPortName: spec.Service.GetGRPC(),
+		defaults.ZeroHTTPPortName: spec.Service.GetHTTP(),
+	})
+
+	return svc
+}
+
+// zeroServicePorts returns the ports of the services of dgraph zero.
+func zeroServicePorts() []corev1.ServicePort {
+	return []corev1.ServicePort{
+		{
+			Name:       defaults.ZeroGRPCPortName,
+			Port:       defaults.ZeroGRPCPort,
+			TargetPort: intstr.FromInt(int(defaults.ZeroGRPCPort)),
+			Protocol:   corev1.ProtocolTCP,
+		},
+		{
+			Name:       defaults.ZeroHTTPPortName,
+			Port:       defaults.ZeroHTTPPort,
+			TargetPort: intstr.FromInt(int(defaults.ZeroHTTPPort)),
+			Protocol:   corev1.ProtocolTCP,
+		},
+	}
 }
 
 // NewZeroHeadlessService constructs a K8s headless service object for dgraph zero from the provided
@@ -89,7 +104,7 @@ func NewZeroHeadlessService(dc *v1alpha1.DgraphCluster) *corev1.Service {
 	// Change spec for kubernetes headless service
 	svc.Spec = corev1.ServiceSpec{
 		ClusterIP:                "None",
-		Ports:                    svc.Spec.Ports,
+		Ports:                    zeroServicePorts(),
 		Selector:                 svc.Spec.Selector,
 		PublishNotReadyAddresses: true,
 	}
@@ -109,24 +124,53 @@ func NewZeroStatefulSet(dc *v1alpha1.DgraphCluster) *appsv1.StatefulSet {
 		ssName,
 		defaults.K8SDelimeter,
 		defaults.HeadlessServiceSuffix)
-	storageClassName := dc.Spec.ZeroCluster.PersistentStorage.StorageClassName
 	shardReplicaCount := dc.Spec.ZeroCluster.ShardReplicaCount()
 	zeroLabels := DefaultZeroLabels(ssName)
+	spec := dc.ZeroClusterSpec()
 
-	replicaCount := dc.Spec.ZeroCluster.Replicas
-	partitionCount := dc.Spec.ZeroCluster.Replicas
+	replicaCount := dc.ZeroReplicas()
+	if dc.ZeroHibernated() {
+		replicaCount = 0
+	}
+	// Members are restarted one at a time whenever the pod template changes.
+	partitionCount := int32(0)
 
+	flags := dgraphFlags(dc.ZeroClusterSpec().Version)
+	zeroFlags := fmt.Sprintf("%s --replicas %d", flags.ZeroIdx("$idx"), shardReplicaCount)
+	if interval := dc.Spec.Tablets.ZeroRebalanceInterval(); interval != "" {
+		zeroFlags = fmt.Sprintf("%s --rebalance_interval %s", zeroFlags, interval)
+	}
+	config := dc.Spec.ZeroCluster.ComponentConfig()
+	tracing := dc.ZeroClusterSpec().TracingConfig(config)
+	zeroFlags += directoryFlags(dc.Spec.ZeroCluster.Volumes)
+	zeroFlags += tracingFlags(flags, tracing)
+	configMap := NewZeroConfigMap(dc)
+	zeroFlags += configFlags(config, configMap)
+
+	// Members other than the first one join the cluster through the first reachable
+	// member, so that they can restart while any member of the quorum is down.
 	// nolint
 	zeroRunCmd := fmt.Sprintf(`set -ex
 [[ $(hostname) =~ -([0-9]+)$ ]] || exit 1
 ordinal=${BASH_REMATCH[1]}
 idx=$(($ordinal + 1))
 if [[ $ordinal -eq 0 ]]; then
-    exec dgraph zero --my=$(hostname -f):5080 --idx $idx --replicas %d
-else
-    exec dgraph zero --my=$(hostname -f):5080 --peer %s-0.%s.${POD_NAMESPACE}.svc.cluster.local:5080 \
-        --idx $idx --replicas %d
-fi`, shardReplicaCount, ssName, headlessServiceName, shardReplicaCount)
+    exec dgraph zero --my=$(hostname -f):5080 %s
+fi
+peer=%s
+for i in $(seq 0 %d); do
+    [[ $i -eq $ordinal ]] && continue
+    host=%s
+    if timeout 2 bash -c "exec 3<>/dev/tcp/$host/5080" 2>/dev/null; then
+        peer=$host:5080
+        break
+    fi
+done
+exec dgraph zero --my=$(hostname -f):5080 --peer $peer %s
+`, zeroFlags, zeroAddress(dc, 0), replicaCount-1,
+		utils.ServiceDNSName(utils.PodHost(ssName, headlessServiceName, "$i"),
+			podNamespace, ClusterDomain(dc)),
+		zeroFlags)
 
 	podVolumeMounts := []corev1.VolumeMount{
 		{
@@ -177,7 +221,7 @@ fi`, shardReplicaCount, ssName, headlessServiceName, shardReplicaCount)
 		RestartPolicy: corev1.RestartPolicyAlways,
 	}
 
-	return &appsv1.StatefulSet{
+	ss := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            ssName,
 			Namespace:       ns,
@@ -199,27 +243,50 @@ fi`, shardReplicaCount, ssName, headlessServiceName, shardReplicaCount)
 
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: zeroLabels,
+					Labels:      k8sutils.MergeStringMaps(spec.PodLabels, zeroLabels),
+					Annotations: spec.PodTemplateAnnotations(),
 				},
 				Spec: podSpec,
 			},
 			ServiceName: headlessServiceName,
-			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: ssName,
-					},
-					Spec: corev1.PersistentVolumeClaimSpec{
-						AccessModes: []corev1.PersistentVolumeAccessMode{
-							corev1.ReadWriteOnce,
-						},
-						StorageClassName: &storageClassName,
-						Resources: corev1.ResourceRequirements{
-							Requests: dc.Spec.ZeroCluster.PersistentStorage.StorageRequest(),
-						},
-					},
-				},
-			},
 		},
 	}
+	addStorage(ss, dc.Spec.ZeroCluster.Storage, dc.Spec.ZeroCluster.PersistentStorage,
+		dc.Spec.ZeroCluster.Volumes, k8sutils.MergeStringMaps(spec.PVCLabels, zeroLabels))
+	addConfigFile(&ss.Spec.Template, configMap)
+	addTracingAgent(&ss.Spec.Template, tracing)
+
+	return ss
+}
+
+// ZeroAddresses returns the gRPC addresses of all the dgraph zero members of the provided
+// DgraphCluster, for use in the pods of the cluster.
+func ZeroAddresses(dc *v1alpha1.DgraphCluster) []string {
+	addresses := make([]string, 0, dc.Spec.ZeroCluster.Replicas)
+	for i := 0; i < int(dc.Spec.ZeroCluster.Replicas); i++ {
+		addresses = append(addresses, zeroAddress(dc, i))
+	}
+
+	return addresses
+}
+
+// zeroAddress returns the gRPC address of the dgraph zero member with the provided ordinal.
+func zeroAddress(dc *v1alpha1.DgraphCluster, ordinal int) string {
+	zeroName := utils.DgraphZeroMemberName(dc.Spec.GetClusterID(), dc.GetName())
+	host := utils.PodHost(zeroName, NewZeroHeadlessService(dc).GetName(), strconv.Itoa(ordinal))
+
+	return utils.ServiceAddress(host, podNamespace, ClusterDomain(dc), defaults.ZeroGRPCPort)
+}
+
+// ZeroHTTPAddress returns the address of the HTTP endpoint of the dgraph zero service of
+// the provided DgraphCluster, reachable from within the kubernetes cluster. The headless
+// service is used if the HTTP port is not exposed on the service.
+func ZeroHTTPAddress(dc *v1alpha1.DgraphCluster) string {
+	svc := NewZeroService(dc)
+	if !serviceExposesPort(svc, defaults.ZeroHTTPPortName) {
+		svc = NewZeroHeadlessService(dc)
+	}
+
+	return fmt.Sprintf("http://%s", utils.ServiceAddress(svc.GetName(),
+		dc.GetNamespace(), ClusterDomain(dc), defaults.ZeroHTTPPort))
 }
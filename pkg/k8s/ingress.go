@@ -0,0 +1,56 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package k8s
+
+import (
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// CreateNewIngress creates a new Kubernetes ingress for the provided ingress object.
+func CreateNewIngress(k8sClient kubernetes.Interface, namespace string,
+	ing *networkingv1beta1.Ingress) error {
+	_, err := k8sClient.NetworkingV1beta1().
+		Ingresses(namespace).
+		Create(ing)
+	return err
+}
+
+// UpdateIngress updates the ingress in the kubernetes cluster.
+func UpdateIngress(k8sClient kubernetes.Interface, namespace string,
+	ing *networkingv1beta1.Ingress) (*networkingv1beta1.Ingress, error) {
+	var updatedIngress *networkingv1beta1.Ingress
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		var updateErr error
+		updatedIngress, updateErr = k8sClient.NetworkingV1beta1().
+			Ingresses(namespace).
+			Update(ing)
+
+		return updateErr
+	})
+
+	return updatedIngress, err
+}
+
+// DeleteIngress deletes a kubernetes ingress from the cluster.
+func DeleteIngress(k8sClient kubernetes.Interface, namespace string,
+	ing *networkingv1beta1.Ingress) error {
+	return k8sClient.NetworkingV1beta1().
+		Ingresses(namespace).
+		Delete(ing.Name, nil)
+}
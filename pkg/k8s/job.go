@@ -0,0 +1,58 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package k8s
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// CreateNewJob creates a new Kubernetes Job for the provided Job object.
+func CreateNewJob(k8sClient kubernetes.Interface, namespace string, job *batchv1.Job) error {
+	_, err := k8sClient.BatchV1().
+		Jobs(namespace).
+		Create(job)
+	return err
+}
+
+// DeleteJob deletes a kubernetes Job along with the pods it created from the cluster.
+func DeleteJob(k8sClient kubernetes.Interface, namespace string, job *batchv1.Job) error {
+	propagation := metav1.DeletePropagationBackground
+	return k8sClient.BatchV1().
+		Jobs(namespace).
+		Delete(job.Name, &metav1.DeleteOptions{PropagationPolicy: &propagation})
+}
+
+// IsJobFinished returns true if the job has either completed or failed, the
+// second return value is true if the job has completed successfully.
+func IsJobFinished(job *batchv1.Job) (finished bool, succeeded bool) {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return true, true
+		case batchv1.JobFailed:
+			return true, false
+		}
+	}
+
+	return false, false
+}
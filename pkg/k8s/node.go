@@ -0,0 +1,78 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// StatsSummary is the subset of the stats summary of a node served by its kubelet used by
+// the operator.
+type StatsSummary struct {
+	Pods []PodStats `json:"pods"`
+}
+
+// PodStats are the stats of a pod running on a node.
+type PodStats struct {
+	PodRef      PodReference  `json:"podRef"`
+	VolumeStats []VolumeStats `json:"volume,omitempty"`
+}
+
+// PodReference identifies the pod of the stats.
+type PodReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// VolumeStats are the file system stats of a volume of a pod.
+type VolumeStats struct {
+	// Name is the name of the volume in the pod spec.
+	Name string `json:"name"`
+
+	// PVCRef is the persistent volume claim of the volume, nil if the volume isn't
+	// backed by one.
+	PVCRef *PodReference `json:"pvcRef,omitempty"`
+
+	AvailableBytes *uint64 `json:"availableBytes,omitempty"`
+	CapacityBytes  *uint64 `json:"capacityBytes,omitempty"`
+	UsedBytes      *uint64 `json:"usedBytes,omitempty"`
+}
+
+// GetNodeStatsSummary returns the stats summary of the node with the provided name, served
+// by its kubelet through the API server proxy.
+func GetNodeStatsSummary(k8sClient kubernetes.Interface, nodeName string) (*StatsSummary,
+	error) {
+	data, err := k8sClient.CoreV1().RESTClient().Get().
+		Resource("nodes").
+		Name(nodeName).
+		SubResource("proxy").
+		Suffix("stats", "summary").
+		DoRaw()
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &StatsSummary{}
+	if err := json.Unmarshal(data, summary); err != nil {
+		return nil, fmt.Errorf("error decoding stats summary of node %s: %s", nodeName, err)
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package k8s
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// CreateNewPersistentVolumeClaim creates a new Kubernetes PersistentVolumeClaim for the
+// provided PersistentVolumeClaim object.
+func CreateNewPersistentVolumeClaim(k8sClient kubernetes.Interface, namespace string,
+	pvc *corev1.PersistentVolumeClaim) error {
+	_, err := k8sClient.CoreV1().
+		PersistentVolumeClaims(namespace).
+		Create(pvc)
+	return err
+}
+
+// UpdatePersistentVolumeClaim updates the PersistentVolumeClaim in the kubernetes cluster.
+func UpdatePersistentVolumeClaim(k8sClient kubernetes.Interface, namespace string,
+	pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, error) {
+	var updatedPVC *corev1.PersistentVolumeClaim
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		var updateErr error
+		updatedPVC, updateErr = k8sClient.CoreV1().
+			PersistentVolumeClaims(namespace).
+			Update(pvc)
+
+		return updateErr
+	})
+
+	return updatedPVC, err
+}
+
+// DeletePersistentVolumeClaim deletes a kubernetes PersistentVolumeClaim from the cluster.
+func DeletePersistentVolumeClaim(k8sClient kubernetes.Interface, namespace string,
+	pvc *corev1.PersistentVolumeClaim) error {
+	return k8sClient.CoreV1().
+		PersistentVolumeClaims(namespace).
+		Delete(pvc.Name, nil)
+}
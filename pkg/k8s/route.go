@@ -0,0 +1,96 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package k8s
+
+import (
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
+)
+
+// GatewayRouteResources returns the set of Gateway API route resources, for example
+// httproutes, served by the kubernetes API server. It's empty if the Gateway API CRDs
+// are not installed.
+func GatewayRouteResources(client discovery.DiscoveryInterface) (map[string]bool, error) {
+	resources := make(map[string]bool)
+	list, err := client.ServerResourcesForGroupVersion(defaults.GatewayAPIGroupVersion)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return resources, nil
+		}
+		return nil, err
+	}
+	for _, res := range list.APIResources {
+		resources[res.Name] = true
+	}
+
+	return resources, nil
+}
+
+// GatewayRouteResource returns the group version resource of the provided Gateway API
+// route resource.
+func GatewayRouteResource(resource string) schema.GroupVersionResource {
+	gv, _ := schema.ParseGroupVersion(defaults.GatewayAPIGroupVersion)
+	return gv.WithResource(resource)
+}
+
+// GetRoute returns the Gateway API route with the provided name.
+func GetRoute(client dynamic.Interface, resource, namespace,
+	name string) (*unstructured.Unstructured, error) {
+	return client.Resource(GatewayRouteResource(resource)).
+		Namespace(namespace).
+		Get(name, metav1.GetOptions{})
+}
+
+// CreateNewRoute creates a new Gateway API route for the provided route object.
+func CreateNewRoute(client dynamic.Interface, resource, namespace string,
+	route *unstructured.Unstructured) error {
+	_, err := client.Resource(GatewayRouteResource(resource)).
+		Namespace(namespace).
+		Create(route, metav1.CreateOptions{})
+	return err
+}
+
+// UpdateRoute updates the Gateway API route in the kubernetes cluster.
+func UpdateRoute(client dynamic.Interface, resource, namespace string,
+	route *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	var updatedRoute *unstructured.Unstructured
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		var updateErr error
+		updatedRoute, updateErr = client.Resource(GatewayRouteResource(resource)).
+			Namespace(namespace).
+			Update(route, metav1.UpdateOptions{})
+
+		return updateErr
+	})
+
+	return updatedRoute, err
+}
+
+// DeleteRoute deletes a Gateway API route from the cluster.
+func DeleteRoute(client dynamic.Interface, resource, namespace string,
+	route *unstructured.Unstructured) error {
+	return client.Resource(GatewayRouteResource(resource)).
+		Namespace(namespace).
+		Delete(route.GetName(), nil)
+}
@@ -18,6 +18,7 @@ package k8s
 
 import (
 	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/util/retry"
 )
@@ -55,3 +56,17 @@ func DeleteStatefulSet(k8sClient kubernetes.Interface, namespace string,
 		StatefulSets(namespace).
 		Delete(svc.Name, nil)
 }
+
+// OrphanStatefulSet deletes a kubernetes StatefulSet from the cluster leaving its pods
+// running, so that they are adopted by the stateful set recreated with the same name.
+// The deletion fails if the StatefulSet has already been recreated.
+func OrphanStatefulSet(k8sClient kubernetes.Interface, namespace string,
+	svc *appsv1.StatefulSet) error {
+	propagation := metav1.DeletePropagationOrphan
+	return k8sClient.AppsV1().
+		StatefulSets(namespace).
+		Delete(svc.Name, &metav1.DeleteOptions{
+			Preconditions:     metav1.NewUIDPreconditions(string(svc.UID)),
+			PropagationPolicy: &propagation,
+		})
+}
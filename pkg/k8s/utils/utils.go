@@ -32,3 +32,19 @@ func ResolveK8SServiceType(service string) corev1.ServiceType {
 		return corev1.ServiceTypeClusterIP
 	}
 }
+
+// MergeStringMaps returns the union of the provided maps, the values of later maps take
+// precedence. It returns nil if all the maps are empty.
+func MergeStringMaps(maps ...map[string]string) map[string]string {
+	var merged map[string]string
+	for _, m := range maps {
+		for key, value := range m {
+			if merged == nil {
+				merged = make(map[string]string)
+			}
+			merged[key] = value
+		}
+	}
+
+	return merged
+}
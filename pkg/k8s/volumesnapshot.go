@@ -0,0 +1,50 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package k8s
+
+import (
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// VolumeSnapshotResource returns the group version resource of volume snapshots.
+func VolumeSnapshotResource() schema.GroupVersionResource {
+	gv, _ := schema.ParseGroupVersion(defaults.VolumeSnapshotGroupVersion)
+	return gv.WithResource(defaults.VolumeSnapshotResource)
+}
+
+// GetVolumeSnapshot returns the volume snapshot with the provided name.
+func GetVolumeSnapshot(client dynamic.Interface, namespace,
+	name string) (*unstructured.Unstructured, error) {
+	return client.Resource(VolumeSnapshotResource()).
+		Namespace(namespace).
+		Get(name, metav1.GetOptions{})
+}
+
+// CreateNewVolumeSnapshot creates a new volume snapshot for the provided volume snapshot
+// object.
+func CreateNewVolumeSnapshot(client dynamic.Interface, namespace string,
+	snapshot *unstructured.Unstructured) error {
+	_, err := client.Resource(VolumeSnapshotResource()).
+		Namespace(namespace).
+		Create(snapshot, metav1.CreateOptions{})
+	return err
+}
@@ -24,6 +24,12 @@ var (
 
 	// ComponentLabelKey is the component within the architecture
 	ComponentLabelKey K8SLabelKey = "app.kubernetes.io/component"
+
+	// GroupLabelKey is the dgraph group of an alpha member.
+	GroupLabelKey K8SLabelKey = "dgraph.io/group"
+
+	// PodServiceLabelKey is the pod exposed by a service for a single dgraph member.
+	PodServiceLabelKey K8SLabelKey = "dgraph.io/pod-service"
 )
 
 // Labels is the standard type to manage labels for the operator.
@@ -91,3 +97,15 @@ func (l Labels) Component(value string) Labels {
 	l[string(ComponentLabelKey)] = value
 	return l
 }
+
+// Group sets the GroupLabelKey in the labels set.
+func (l Labels) Group(value string) Labels {
+	l[string(GroupLabelKey)] = value
+	return l
+}
+
+// PodService sets the PodServiceLabelKey in the labels set.
+func (l Labels) PodService(value string) Labels {
+	l[string(PodServiceLabelKey)] = value
+	return l
+}
@@ -17,17 +17,28 @@
 package manager
 
 import (
+	"fmt"
+	"net"
+	"strconv"
+
 	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
 	"github.com/dgraph-io/dgraph-operator/pkg/k8s"
 	dgraphk8s "github.com/dgraph-io/dgraph-operator/pkg/k8s/dgraph"
+	"github.com/dgraph-io/dgraph-operator/pkg/labels"
 	"github.com/dgraph-io/dgraph-operator/pkg/utils"
 	"github.com/golang/glog"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	klabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 	v1 "k8s.io/client-go/listers/apps/v1"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
 	klisters "k8s.io/client-go/listers/core/v1"
+	storagelisters "k8s.io/client-go/listers/storage/v1"
 )
 
 // AlphaManager manages alpha members in a dgraph cluster. It's main function is to sync
@@ -38,6 +49,10 @@ type AlphaManager struct {
 	podLister         klisters.PodLister
 	svcLister         klisters.ServiceLister
 	statefulSetLister v1.StatefulSetLister
+	configMapLister   klisters.ConfigMapLister
+
+	volumeExpander  *volumeExpander
+	storageMigrator *storageMigrator
 }
 
 // NewAlphaManager creates a new manager for dgraph alpha components.
@@ -46,12 +61,19 @@ func NewAlphaManager(
 	podLister klisters.PodLister,
 	svcLister klisters.ServiceLister,
 	statefulSetLister v1.StatefulSetLister,
+	configMapLister klisters.ConfigMapLister,
+	pvcLister klisters.PersistentVolumeClaimLister,
+	storageClassLister storagelisters.StorageClassLister,
+	jobLister batchlisters.JobLister,
 ) *AlphaManager {
 	return &AlphaManager{
 		k8sClient,
 		podLister,
 		svcLister,
 		statefulSetLister,
+		configMapLister,
+		newVolumeExpander(k8sClient, pvcLister, storageClassLister),
+		newStorageMigrator(k8sClient, podLister, pvcLister, statefulSetLister, jobLister),
 	}
 }
 
@@ -61,7 +83,21 @@ func (am *AlphaManager) Sync(dc *v1alpha1.DgraphCluster) error {
 		return err
 	}
 
-	return am.syncAlphaStatefulSetWithDgraphCluster(dc)
+	alphaName := utils.DgraphAlphaMemberName(dc.Spec.GetClusterID(), dc.GetName())
+	if err := syncConfigMap(am.k8sClient, am.configMapLister, dc.GetNamespace(),
+		utils.DgraphConfigName(alphaName), dgraphk8s.NewAlphaConfigMap(dc)); err != nil {
+		return err
+	}
+
+	if err := am.syncAlphaStatefulSetWithDgraphCluster(dc); err != nil {
+		return err
+	}
+
+	if err := am.syncAlphaPodServices(dc); err != nil {
+		return err
+	}
+
+	return am.syncAlphaMembers(dc)
 }
 
 // syncAlphaServiceWithDgraphCluster syncs the dgraph alpha service with the DgraphCluster
@@ -75,6 +111,10 @@ func (am *AlphaManager) Sync(dc *v1alpha1.DgraphCluster) error {
 // 1. Service(ClusterIP, NodePort or LoadBalancer)
 // 2. Headless Service - ClusterIP with ClusterIP None
 func (am *AlphaManager) syncAlphaServiceWithDgraphCluster(dc *v1alpha1.DgraphCluster) error {
+	if err := dc.AlphaClusterSpec().Service.Validate(true); err != nil {
+		return fmt.Errorf("alpha: %s", err)
+	}
+
 	ns := dc.GetNamespace()
 	svc := dgraphk8s.NewAlphaService(dc)
 	serviceName := svc.GetName()
@@ -92,14 +132,16 @@ func (am *AlphaManager) syncAlphaServiceWithDgraphCluster(dc *v1alpha1.DgraphClu
 
 	// If the old service and new service spec is same don't change anything.
 	// else update the service spec as mentioned in the updated specification.
-	svc.Spec.ClusterIP = oldSVC.Spec.ClusterIP
+	preserveServiceAllocations(svc, oldSVC)
 
 	// We don't use DeepEquals here to not compare the default values that
 	// kubernetes might have introduced to the given type.
 	// TODO: Improve this.
-	if !apiequality.Semantic.DeepDerivative(svc.Spec, oldSVC.Spec) {
+	if !serviceSpecUpToDate(svc, oldSVC) ||
+		!metadataUpToDate(svc.ObjectMeta, oldSVC.ObjectMeta) {
 		updateSVC := *oldSVC
 		updateSVC.Spec = svc.Spec
+		mergeMetadata(&updateSVC.ObjectMeta, svc.ObjectMeta)
 		glog.Info("updating service for dgraph alpha")
 		if _, err = k8s.UpdateService(am.k8sClient, ns, &updateSVC); err != nil {
 			return err
@@ -119,46 +161,303 @@ func (am *AlphaManager) syncAlphaServiceWithDgraphCluster(dc *v1alpha1.DgraphClu
 	}
 
 	// If the old service and new service spec is same don't change anything.
-	if apiequality.Semantic.DeepDerivative(headlessSVC.Spec, oldHeadlessSVC.Spec) {
+	if apiequality.Semantic.DeepDerivative(headlessSVC.Spec, oldHeadlessSVC.Spec) &&
+		metadataUpToDate(headlessSVC.ObjectMeta, oldHeadlessSVC.ObjectMeta) {
 		return nil
 	}
 
 	headlessSVCUpdate := *oldHeadlessSVC
 	headlessSVCUpdate.Spec = headlessSVC.Spec
+	mergeMetadata(&headlessSVCUpdate.ObjectMeta, headlessSVC.ObjectMeta)
 	glog.Info("updating headless service for dgraph alpha")
 	_, err = k8s.UpdateService(am.k8sClient, ns, &headlessSVCUpdate)
 
 	return err
 }
 
-// syncAlphaStatefulSetWithDgraphCluster syncs the dgraph Alpha service with the DgraphCluster
-// specification provided.
+// syncAlphaStatefulSetWithDgraphCluster syncs the dgraph Alpha stateful sets with the
+// DgraphCluster specification provided, one for each alpha group if groups are specified.
+//
+// Stateful sets of groups removed from the specification are left untouched, as the
+// tablets served by the group must be moved to other groups before removing it.
+//
+// The stateful set of an alpha member whose storage is being migrated isn't created until
+// the member has been migrated.
 func (am *AlphaManager) syncAlphaStatefulSetWithDgraphCluster(dc *v1alpha1.DgraphCluster) error {
 	glog.Info("syncing dgraph Alpha stateful set with dgraph cluster specification")
-	ns := dc.GetNamespace()
+	if err := dc.Spec.AlphaCluster.ValidateGroups(); err != nil {
+		return err
+	}
+	if err := am.validateAlphaGroupsTransition(dc); err != nil {
+		return err
+	}
 
-	AlphaStatefulSet := dgraphk8s.NewAlphaStatefulSet(dc)
-	AlphaStatefulSetOld, err := am.statefulSetLister.StatefulSets(ns).
-		Get(utils.DgraphAlphaMemberName(dc.Spec.GetClusterID(), dc.GetObjectMeta().GetName()))
+	if err := am.storageMigrator.sync(dc); err != nil {
+		return err
+	}
+
+	var expansions []v1alpha1.VolumeExpansionStatus
+	for _, ss := range dgraphk8s.NewAlphaStatefulSets(dc) {
+		if dc.AlphaStorageMigrationStopped(ss.GetName()) {
+			continue
+		}
+		statuses, err := am.syncAlphaStatefulSet(dc.GetNamespace(), ss)
+		if err != nil {
+			return err
+		}
+		expansions = append(expansions, statuses...)
+	}
+	dc.Status.AlphaCluster.VolumeExpansions = expansions
+
+	return nil
+}
+
+// validateAlphaGroupsTransition returns an error if alpha groups are added to the
+// specification of a cluster already running its alpha members in a single stateful set,
+// or removed from a cluster already running them in group stateful sets. The data served
+// by the members of one shape isn't moved to the other one, so such a change would run
+// both of them side by side.
+func (am *AlphaManager) validateAlphaGroupsTransition(dc *v1alpha1.DgraphCluster) error {
+	clusterID := dc.Spec.GetClusterID()
+	existing := utils.DgraphAlphaMemberName(clusterID, dc.GetName())
+	if len(dc.Spec.AlphaCluster.Groups) == 0 {
+		// Alpha groups always include group 1.
+		existing = utils.DgraphAlphaGroupMemberName(clusterID, dc.GetName(), 1)
+	}
+
+	_, err := am.statefulSetLister.StatefulSets(dc.GetNamespace()).Get(existing)
 	if kerrors.IsNotFound(err) {
-		glog.Info("creating new stateful set for alpha according to DgraphCluster configuration spec")
-		return k8s.CreateNewStatefulSet(am.k8sClient, ns, AlphaStatefulSet)
+		return nil
 	}
 	if err != nil {
 		return err
 	}
 
+	if len(dc.Spec.AlphaCluster.Groups) == 0 {
+		return fmt.Errorf("alpha groups can't be removed from cluster %s as its alpha "+
+			"members run in group stateful sets such as %s", dc.GetName(), existing)
+	}
+	return fmt.Errorf("alpha groups can't be added to cluster %s as its alpha members "+
+		"run in the single stateful set %s", dc.GetName(), existing)
+}
+
+// syncAlphaStatefulSet creates the provided dgraph Alpha stateful set or updates the
+// existing one if it differs. It returns the status of the expansion of the persistent
+// volume claims of the alpha members, if storage requests grew.
+func (am *AlphaManager) syncAlphaStatefulSet(ns string,
+	AlphaStatefulSet *appsv1.StatefulSet) ([]v1alpha1.VolumeExpansionStatus, error) {
+	AlphaStatefulSetOld, err := am.statefulSetLister.StatefulSets(ns).
+		Get(AlphaStatefulSet.GetName())
+	if kerrors.IsNotFound(err) {
+		glog.Infof("creating new stateful set for alpha according to DgraphCluster "+
+			"configuration spec: %s", AlphaStatefulSet.GetName())
+		return nil, k8s.CreateNewStatefulSet(am.k8sClient, ns, AlphaStatefulSet)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if AlphaStatefulSetOld.GetDeletionTimestamp() != nil {
+		glog.Infof("waiting for the deletion of alpha stateful set %s to recreate it",
+			AlphaStatefulSetOld.GetName())
+		return nil, nil
+	}
+	if err := checkVolumeClaimTemplates(AlphaStatefulSet, AlphaStatefulSetOld); err != nil {
+		return nil, err
+	}
+
+	expansions, recreated, err := am.volumeExpander.sync(AlphaStatefulSet, AlphaStatefulSetOld)
+	if err != nil || recreated {
+		return expansions, err
+	}
+
 	// If the old service and new service spec is same don't change anything.
+	// Replicas are compared separately as alpha is scaled up only after the cluster
+	// has been bootstrapped.
 	if apiequality.Semantic.DeepDerivative(
 		AlphaStatefulSet.Spec.Template,
-		AlphaStatefulSetOld.Spec.Template) {
-		return nil
+		AlphaStatefulSetOld.Spec.Template) &&
+		apiequality.Semantic.DeepEqual(
+			AlphaStatefulSet.Spec.Replicas,
+			AlphaStatefulSetOld.Spec.Replicas) &&
+		apiequality.Semantic.DeepDerivative(
+			AlphaStatefulSet.Spec.UpdateStrategy,
+			AlphaStatefulSetOld.Spec.UpdateStrategy) {
+		return expansions, nil
 	}
 
 	statefulSetUpdate := *AlphaStatefulSetOld
 	statefulSetUpdate.Spec = AlphaStatefulSet.Spec
-	glog.Infof("updating underlying stateful set for dgraph alpha")
+	// Volume claim templates can't be updated, growing storage requests are applied by
+	// expanding the volumes and other changes only apply to the stateful sets created
+	// afterwards.
+	statefulSetUpdate.Spec.VolumeClaimTemplates = AlphaStatefulSetOld.Spec.VolumeClaimTemplates
+	glog.Infof("updating underlying stateful set for dgraph alpha: %s",
+		AlphaStatefulSet.GetName())
 	_, err = k8s.UpdateStatefulSet(am.k8sClient, ns, &statefulSetUpdate)
 
-	return err
+	return expansions, err
+}
+
+// syncAlphaPodServices syncs the services exposing each alpha member with the DgraphCluster
+// specification provided, and records their external endpoints in the status.
+//
+// Services are created and deleted as alpha members are added and removed, all of them are
+// deleted if pod services are disabled.
+func (am *AlphaManager) syncAlphaPodServices(dc *v1alpha1.DgraphCluster) error {
+	ns := dc.GetNamespace()
+	services := dgraphk8s.NewAlphaPodServices(dc)
+
+	var statuses []v1alpha1.AlphaPodServiceStatus
+	desired := make(map[string]bool, len(services))
+	for _, svc := range services {
+		desired[svc.GetName()] = true
+
+		oldSVC, err := am.svcLister.Services(ns).Get(svc.GetName())
+		if kerrors.IsNotFound(err) {
+			glog.Infof("creating new service for dgraph alpha member: %s", svc.GetName())
+			if err := k8s.CreateNewService(am.k8sClient, ns, svc); err != nil {
+				return err
+			}
+			statuses = append(statuses, v1alpha1.AlphaPodServiceStatus{
+				Pod:     svc.Spec.Selector[appsv1.StatefulSetPodNameLabel],
+				Service: svc.GetName(),
+			})
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		preserveServiceAllocations(svc, oldSVC)
+		if !serviceSpecUpToDate(svc, oldSVC) ||
+			!metadataUpToDate(svc.ObjectMeta, oldSVC.ObjectMeta) {
+			updateSVC := *oldSVC
+			updateSVC.Spec = svc.Spec
+			mergeMetadata(&updateSVC.ObjectMeta, svc.ObjectMeta)
+			glog.Infof("updating service for dgraph alpha member: %s", svc.GetName())
+			if _, err := k8s.UpdateService(am.k8sClient, ns, &updateSVC); err != nil {
+				return err
+			}
+		}
+
+		status, err := am.alphaPodServiceStatus(oldSVC)
+		if err != nil {
+			return err
+		}
+		statuses = append(statuses, status)
+	}
+	dc.Status.AlphaCluster.PodServices = statuses
+
+	alphaName := utils.DgraphAlphaMemberName(dc.Spec.GetClusterID(), dc.GetName())
+	existing, err := am.svcLister.Services(ns).
+		List(klabels.SelectorFromSet(dgraphk8s.DefaultAlphaLabels(alphaName)))
+	if err != nil {
+		return err
+	}
+	for _, svc := range existing {
+		if !labels.Labels(svc.GetLabels()).Has(string(labels.PodServiceLabelKey)) ||
+			desired[svc.GetName()] {
+			continue
+		}
+
+		glog.Infof("deleting service of removed dgraph alpha member: %s", svc.GetName())
+		if err := k8s.DeleteService(am.k8sClient, ns, svc); err != nil &&
+			!kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// alphaPodServiceStatus returns the status of the existing service exposing an alpha
+// member. The endpoints of LoadBalancer services are the ingress points of the load
+// balancer, the ones of NodePort services are on the node running the member.
+func (am *AlphaManager) alphaPodServiceStatus(
+	svc *corev1.Service) (v1alpha1.AlphaPodServiceStatus, error) {
+	podName := svc.Spec.Selector[appsv1.StatefulSetPodNameLabel]
+	status := v1alpha1.AlphaPodServiceStatus{
+		Pod:     podName,
+		Service: svc.GetName(),
+	}
+
+	var host string
+	nodePort := svc.Spec.Type == corev1.ServiceTypeNodePort
+	if nodePort {
+		pod, err := am.podLister.Pods(svc.GetNamespace()).Get(podName)
+		if kerrors.IsNotFound(err) {
+			return status, nil
+		}
+		if err != nil {
+			return status, err
+		}
+		host = pod.Status.HostIP
+	} else if ingress := svc.Status.LoadBalancer.Ingress; len(ingress) > 0 {
+		host = ingress[0].IP
+		if host == "" {
+			host = ingress[0].Hostname
+		}
+	}
+	if host == "" {
+		return status, nil
+	}
+
+	for _, port := range svc.Spec.Ports {
+		p := port.Port
+		if nodePort {
+			p = port.NodePort
+		}
+		if p == 0 {
+			continue
+		}
+
+		endpoint := net.JoinHostPort(host, strconv.Itoa(int(p)))
+		switch port.Name {
+		case defaults.AlphaGRPCPortName:
+			status.GRPCEndpoint = endpoint
+		case defaults.AlphaHTTPPortName:
+			status.HTTPEndpoint = endpoint
+		}
+	}
+
+	return status, nil
+}
+
+// syncAlphaMembers records the status of the members of all the alpha stateful sets of the
+// provided DgraphCluster, with the address of their gRPC endpoint.
+func (am *AlphaManager) syncAlphaMembers(dc *v1alpha1.DgraphCluster) error {
+	members := make(map[string]v1alpha1.DgraphComponent)
+	for _, ss := range dgraphk8s.NewAlphaStatefulSets(dc) {
+		if err := addStatefulSetMembers(am.podLister, dc, ss, defaults.AlphaGRPCPort,
+			members); err != nil {
+			return err
+		}
+	}
+	dc.Status.AlphaCluster.Members = members
+
+	return nil
+}
+
+// AlphaReady returns true if the alpha cluster of the provided DgraphCluster can serve
+// requests, which requires at least one ready member in each alpha stateful set and the
+// initial data load and any hibernation to be complete.
+func AlphaReady(statefulSetLister v1.StatefulSetLister, dc *v1alpha1.DgraphCluster) (bool, error) {
+	if dc.AlphaBootstrapPending() || dc.Status.Hibernation != nil {
+		return false, nil
+	}
+
+	for _, alpha := range dgraphk8s.NewAlphaStatefulSets(dc) {
+		ss, err := statefulSetLister.StatefulSets(dc.GetNamespace()).Get(alpha.GetName())
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if ss.Status.ReadyReplicas < 1 {
+			return false, nil
+		}
+	}
+
+	return true, nil
 }
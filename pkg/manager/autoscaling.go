@@ -0,0 +1,524 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+	"github.com/dgraph-io/dgraph-operator/pkg/dgraph"
+	"github.com/dgraph-io/dgraph-operator/pkg/k8s"
+	dgraphk8s "github.com/dgraph-io/dgraph-operator/pkg/k8s/dgraph"
+	"github.com/golang/glog"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	v1 "k8s.io/client-go/listers/apps/v1"
+	klisters "k8s.io/client-go/listers/core/v1"
+)
+
+// AutoscalingManager scales the alpha cluster of a dgraph cluster in steps of whole groups
+// based on the metrics scraped from the alpha members. It must be synced before the
+// AlphaManager, which applies the number of replicas it decides.
+//
+// A group is added by scaling up the alpha stateful set, zero assigns the new members to
+// a new group and its rebalancer moves tablets to it. A group is removed in phases which
+// are recorded in the DgraphCluster status:
+// stable -> draining -> removing -> stable
+// While draining the tablets of the last group are moved to the other groups, then the
+// members of the group are stopped, removed from zero and their volumes deleted.
+type AutoscalingManager struct {
+	k8sClient kubernetes.Interface
+
+	podLister         klisters.PodLister
+	pvcLister         klisters.PersistentVolumeClaimLister
+	statefulSetLister v1.StatefulSetLister
+}
+
+// NewAutoscalingManager creates a new manager for autoscaling dgraph alpha.
+func NewAutoscalingManager(
+	k8sClient kubernetes.Interface,
+	podLister klisters.PodLister,
+	pvcLister klisters.PersistentVolumeClaimLister,
+	statefulSetLister v1.StatefulSetLister,
+) *AutoscalingManager {
+	return &AutoscalingManager{
+		k8sClient,
+		podLister,
+		pvcLister,
+		statefulSetLister,
+	}
+}
+
+// Sync advances the autoscaling of the alpha cluster of the provided DgraphCluster. Errors
+// talking to dgraph are recorded in the status rather than returned so that they don't
+// block syncing the other components.
+func (am *AutoscalingManager) Sync(dc *v1alpha1.DgraphCluster) error {
+	spec := dc.Spec.AlphaCluster.Autoscaling
+	if spec == nil {
+		dc.Status.AlphaCluster.Autoscaling = nil
+		return nil
+	}
+	if err := dc.Spec.AlphaCluster.ValidateAutoscaling(); err != nil {
+		return err
+	}
+
+	status := dc.Status.AlphaCluster.Autoscaling
+	if status == nil {
+		status = &v1alpha1.AlphaAutoscalingStatus{
+			Phase:    v1alpha1.AutoscalingPhaseStable,
+			Replicas: initialAlphaReplicas(dc),
+		}
+		dc.Status.AlphaCluster.Autoscaling = status
+	}
+
+	ready, err := AlphaReady(am.statefulSetLister, dc)
+	if err != nil {
+		return err
+	}
+	if !ready {
+		status.Message = "waiting for alpha to be ready"
+		return nil
+	}
+
+	state, err := dgraph.NewZeroClient(dgraphk8s.ZeroHTTPAddress(dc)).State()
+	if err != nil {
+		status.Message = fmt.Sprintf("error getting state of zero: %s", err)
+		return nil
+	}
+
+	glog.Infof("autoscaling-manager: syncing autoscaling of cluster %s in phase %q",
+		dc.GetName(), status.Phase)
+	if status.Phase == v1alpha1.AutoscalingPhaseRemoving {
+		return am.syncRemoving(dc, status, state)
+	}
+
+	metrics, err := am.alphaMetrics(dc, state, status.Replicas)
+	if err != nil {
+		status.Message = err.Error()
+		return nil
+	}
+	status.Metrics = metrics
+
+	groupSize := dc.Spec.AlphaGroupSize()
+	groups := status.Replicas / groupSize
+	scaleUp := groups < spec.MinGroups ||
+		(groups < spec.MaxGroups && metricsAboveTargets(spec, metrics))
+	scaleDown := groups > spec.MaxGroups ||
+		(groups > spec.MinGroups && metricsBelowTargets(spec, metrics))
+
+	if status.Phase == v1alpha1.AutoscalingPhaseDraining {
+		if scaleUp {
+			status.Message = fmt.Sprintf("stopped draining group %d as metrics are above "+
+				"targets", status.DrainingGroup)
+			status.Phase = v1alpha1.AutoscalingPhaseStable
+			status.DrainingGroup = 0
+			return nil
+		}
+		return am.syncDraining(dc, status, state)
+	}
+
+	switch {
+	case scaleUp:
+		if inCooldown(status, spec.GetScaleUpCooldown()) && groups >= spec.MinGroups {
+			status.Message = "waiting for scale up cooldown to add a group"
+			return nil
+		}
+		status.Replicas = (groups + 1) * groupSize
+		status.LastScaleTime = &metav1.Time{Time: time.Now()}
+		status.Message = fmt.Sprintf("scaling up to %d groups", groups+1)
+		glog.Infof("autoscaling-manager: scaling up alpha of cluster %s to %d replicas",
+			dc.GetName(), status.Replicas)
+	case scaleDown:
+		if inCooldown(status, spec.GetScaleDownCooldown()) && groups <= spec.MaxGroups {
+			status.Message = "waiting for scale down cooldown to remove a group"
+			return nil
+		}
+		group, err := lastAlphaGroup(dc, state, status.Replicas)
+		if err != nil {
+			status.Message = fmt.Sprintf("can't scale down: %s", err)
+			return nil
+		}
+		if predicate := pinnedPredicate(dc, group); predicate != "" {
+			status.Message = fmt.Sprintf("can't scale down: tablet of predicate %s is "+
+				"pinned to group %d", predicate, group)
+			return nil
+		}
+		status.Phase = v1alpha1.AutoscalingPhaseDraining
+		status.DrainingGroup = group
+		status.Message = fmt.Sprintf("draining group %d", group)
+		glog.Infof("autoscaling-manager: draining group %d of cluster %s",
+			group, dc.GetName())
+	default:
+		status.Message = ""
+	}
+
+	return nil
+}
+
+// syncDraining moves a tablet of the draining group to the smallest of the other groups,
+// and stops the members of the group once it serves no tablets.
+func (am *AutoscalingManager) syncDraining(dc *v1alpha1.DgraphCluster,
+	status *v1alpha1.AlphaAutoscalingStatus, state *dgraph.ZeroState) error {
+	groupID := strconv.FormatUint(uint64(status.DrainingGroup), 10)
+	group, ok := state.Groups[groupID]
+	if !ok {
+		status.Message = fmt.Sprintf("draining group %d does not exist", status.DrainingGroup)
+		status.Phase = v1alpha1.AutoscalingPhaseStable
+		status.DrainingGroup = 0
+		return nil
+	}
+
+	if len(group.Tablets) == 0 {
+		status.RemovedMembers = nil
+		for id := range group.Members {
+			status.RemovedMembers = append(status.RemovedMembers, id)
+		}
+		sort.Strings(status.RemovedMembers)
+
+		status.Replicas -= int32(len(group.Members))
+		status.Phase = v1alpha1.AutoscalingPhaseRemoving
+		status.LastScaleTime = &metav1.Time{Time: time.Now()}
+		status.Message = fmt.Sprintf("removing drained group %d", status.DrainingGroup)
+		glog.Infof("autoscaling-manager: scaling down alpha of cluster %s to %d replicas",
+			dc.GetName(), status.Replicas)
+		return nil
+	}
+
+	// Tablets are only moved during the maintenance window of the tablets, like the pinned
+	// tablets moved by the tablet manager.
+	open, err := maintenanceWindowOpen(dc)
+	if err != nil {
+		status.Message = err.Error()
+		return nil
+	}
+	if !open {
+		status.Message = fmt.Sprintf("draining group %d: waiting for the maintenance "+
+			"window to move tablets", status.DrainingGroup)
+		return nil
+	}
+
+	// Move the tablets in a stable order to the group they are pinned to, or else to the
+	// group with the least data.
+	predicates := make([]string, 0, len(group.Tablets))
+	for predicate := range group.Tablets {
+		predicates = append(predicates, predicate)
+	}
+	sort.Strings(predicates)
+	predicate := predicates[0]
+
+	target := pinnedGroup(dc, predicate)
+	if target == status.DrainingGroup || !state.HasGroup(target) {
+		target = smallestAlphaGroup(state, status.DrainingGroup)
+	}
+	if target == 0 {
+		status.Message = fmt.Sprintf("no group to move the tablets of group %d to",
+			status.DrainingGroup)
+		return nil
+	}
+
+	zero, err := state.LeaderClient()
+	if err != nil {
+		status.Message = err.Error()
+		return nil
+	}
+	glog.Infof("autoscaling-manager: moving tablet of predicate %s of cluster %s to group %d",
+		predicate, dc.GetName(), target)
+	err = zero.MoveTablet(predicate, target)
+	switch {
+	case dgraph.IsTimeout(err):
+		status.Message = fmt.Sprintf("draining group %d: moving tablet of predicate %s",
+			status.DrainingGroup, predicate)
+	case err != nil:
+		status.Message = fmt.Sprintf("draining group %d: error moving tablet of predicate "+
+			"%s: %s", status.DrainingGroup, predicate, err)
+	default:
+		status.Message = fmt.Sprintf("draining group %d: %d tablets left",
+			status.DrainingGroup, len(group.Tablets)-1)
+	}
+
+	return nil
+}
+
+// syncRemoving removes the members of the drained group from zero once their pods have
+// been deleted, and then deletes their persistent volume claims so that new members
+// with the same ordinals join the cluster afresh.
+func (am *AutoscalingManager) syncRemoving(dc *v1alpha1.DgraphCluster,
+	status *v1alpha1.AlphaAutoscalingStatus, state *dgraph.ZeroState) error {
+	ns := dc.GetNamespace()
+	ss := dgraphk8s.NewAlphaStatefulSet(dc)
+
+	var members []dgraphk8s.AlphaMember
+	for i := 0; i < len(status.RemovedMembers); i++ {
+		member := dgraphk8s.AlphaMember{
+			StatefulSet: ss,
+			Ordinal:     status.Replicas + int32(i),
+		}
+		podName := fmt.Sprintf("%s%s%d", ss.GetName(), defaults.K8SDelimeter, member.Ordinal)
+		_, err := am.podLister.Pods(ns).Get(podName)
+		if err == nil {
+			status.Message = fmt.Sprintf("waiting for alpha member %s to stop", podName)
+			return nil
+		}
+		if !kerrors.IsNotFound(err) {
+			return err
+		}
+		members = append(members, member)
+	}
+
+	group := state.Groups[strconv.FormatUint(uint64(status.DrainingGroup), 10)]
+	for _, id := range status.RemovedMembers {
+		if _, ok := group.Members[id]; !ok {
+			continue
+		}
+		zero, err := state.LeaderClient()
+		if err == nil {
+			err = zero.RemoveNode(id, status.DrainingGroup)
+		}
+		if err != nil {
+			status.Message = fmt.Sprintf("error removing member %s of group %d: %s",
+				id, status.DrainingGroup, err)
+			return nil
+		}
+	}
+
+	// Members with ephemeral storage have no volume claim templates, and so no claims.
+	for _, member := range members {
+		for _, pvc := range dgraphk8s.NewAlphaPersistentVolumeClaims(member) {
+			_, err := am.pvcLister.PersistentVolumeClaims(ns).Get(pvc.GetName())
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			glog.Infof("autoscaling-manager: deleting persistent volume claim %s",
+				pvc.GetName())
+			if err := k8s.DeletePersistentVolumeClaim(am.k8sClient, ns, pvc); err != nil &&
+				!kerrors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	status.Message = fmt.Sprintf("removed group %d", status.DrainingGroup)
+	status.Phase = v1alpha1.AutoscalingPhaseStable
+	status.DrainingGroup = 0
+	status.RemovedMembers = nil
+	return nil
+}
+
+// alphaMetrics scrapes the metrics of all the alpha members and aggregates them. Metrics
+// are only reported once all the expected members are ready.
+func (am *AutoscalingManager) alphaMetrics(dc *v1alpha1.DgraphCluster,
+	state *dgraph.ZeroState, replicas int32) (*v1alpha1.AlphaMetrics, error) {
+	ss := dgraphk8s.NewAlphaStatefulSet(dc)
+	pods, err := am.podLister.Pods(dc.GetNamespace()).
+		List(klabels.SelectorFromSet(ss.Spec.Selector.MatchLabels))
+	if err != nil {
+		return nil, err
+	}
+
+	memberGroups := make(map[string]uint32)
+	for _, group := range state.Groups {
+		for _, member := range group.Members {
+			memberGroups[member.Hostname()] = member.GroupID
+		}
+	}
+
+	var (
+		ready          int32
+		pendingQueries float64
+		metrics        = &v1alpha1.AlphaMetrics{}
+		groupDisk      = make(map[uint32]int64)
+	)
+	for _, pod := range pods {
+		if !k8s.IsPodReady(pod) || pod.Status.PodIP == "" {
+			continue
+		}
+		ready++
+
+		addr := fmt.Sprintf("http://%s:%d", pod.Status.PodIP, defaults.AlphaHTTPPort)
+		m, err := dgraph.NewClient(addr).Metrics()
+		if err != nil {
+			return nil, fmt.Errorf("error scraping metrics of alpha member %s: %s",
+				pod.GetName(), err)
+		}
+
+		pendingQueries += m.Get(dgraph.PendingQueriesMetric)
+		if memory := int64(m.Get(dgraph.MemoryInUseMetric)); memory > metrics.MemoryInUseBytes {
+			metrics.MemoryInUseBytes = memory
+		}
+		// Members of a group hold the same data, so the usage of the group is the
+		// highest usage of its members.
+		group := memberGroups[pod.GetName()]
+		if disk := int64(m.DiskUsage()); disk > groupDisk[group] {
+			groupDisk[group] = disk
+		}
+	}
+	if ready < replicas {
+		return nil, fmt.Errorf("waiting for %d alpha members to be ready, %d ready",
+			replicas, ready)
+	}
+
+	metrics.PendingQueries = int64(pendingQueries / float64(ready))
+	for _, disk := range groupDisk {
+		if disk > metrics.DiskUsageBytes {
+			metrics.DiskUsageBytes = disk
+		}
+	}
+
+	return metrics, nil
+}
+
+// metricsAboveTargets returns true if any of the metrics is above its target.
+func metricsAboveTargets(spec *v1alpha1.AlphaAutoscalingSpec,
+	metrics *v1alpha1.AlphaMetrics) bool {
+	if spec.TargetPendingQueries != nil && metrics.PendingQueries > *spec.TargetPendingQueries {
+		return true
+	}
+	if spec.TargetMemoryInUse != nil &&
+		metrics.MemoryInUseBytes > spec.TargetMemoryInUse.Value() {
+		return true
+	}
+	if spec.TargetDiskUsage != nil && metrics.DiskUsageBytes > spec.TargetDiskUsage.Value() {
+		return true
+	}
+
+	return false
+}
+
+// metricsBelowTargets returns true if all the metrics with a target are below the scale
+// down percentage of their target.
+func metricsBelowTargets(spec *v1alpha1.AlphaAutoscalingSpec,
+	metrics *v1alpha1.AlphaMetrics) bool {
+	percent := int64(spec.GetScaleDownPercent())
+	below := func(value, target int64) bool {
+		return value*100 < target*percent
+	}
+
+	if spec.TargetPendingQueries != nil &&
+		!below(metrics.PendingQueries, *spec.TargetPendingQueries) {
+		return false
+	}
+	if spec.TargetMemoryInUse != nil &&
+		!below(metrics.MemoryInUseBytes, spec.TargetMemoryInUse.Value()) {
+		return false
+	}
+	if spec.TargetDiskUsage != nil &&
+		!below(metrics.DiskUsageBytes, spec.TargetDiskUsage.Value()) {
+		return false
+	}
+
+	return true
+}
+
+// inCooldown returns true if the last scaling operation happened within the cooldown.
+func inCooldown(status *v1alpha1.AlphaAutoscalingStatus, cooldown time.Duration) bool {
+	return status.LastScaleTime != nil && time.Since(status.LastScaleTime.Time) < cooldown
+}
+
+// initialAlphaReplicas returns the number of alpha replicas to start autoscaling with,
+// which is the number of replicas in the specification rounded up to whole groups within
+// the autoscaling bounds.
+func initialAlphaReplicas(dc *v1alpha1.DgraphCluster) int32 {
+	spec := dc.Spec.AlphaCluster.Autoscaling
+	groups := dc.Spec.AlphaGroupCount()
+	if groups < spec.MinGroups {
+		groups = spec.MinGroups
+	}
+	if groups > spec.MaxGroups {
+		groups = spec.MaxGroups
+	}
+
+	return groups * dc.Spec.AlphaGroupSize()
+}
+
+// smallestAlphaGroup returns the alpha group with members serving the least data other than
+// the excluded one, or 0 if there is none.
+func smallestAlphaGroup(state *dgraph.ZeroState, excluded uint32) uint32 {
+	var target uint32
+	var targetSize int64
+	for id, g := range state.Groups {
+		gid, err := strconv.ParseUint(id, 10, 32)
+		if err != nil || uint32(gid) == excluded || len(g.Members) == 0 {
+			continue
+		}
+		if size := g.Size(); target == 0 || size < targetSize {
+			target, targetSize = uint32(gid), size
+		}
+	}
+
+	return target
+}
+
+// pinnedPredicate returns a predicate whose tablet is pinned to the provided alpha group by
+// the tablets placement of the DgraphCluster, or an empty string if none is.
+func pinnedPredicate(dc *v1alpha1.DgraphCluster, group uint32) string {
+	if dc.Spec.Tablets == nil {
+		return ""
+	}
+	for _, placement := range dc.Spec.Tablets.Placement {
+		if placement.Group == group {
+			return placement.Predicate
+		}
+	}
+
+	return ""
+}
+
+// lastAlphaGroup returns the ID of the group served by the alpha members with the highest
+// ordinals, which are the ones removed when scaling down the alpha stateful set. The
+// members must form a whole group for it to be removed.
+func lastAlphaGroup(dc *v1alpha1.DgraphCluster, state *dgraph.ZeroState,
+	replicas int32) (uint32, error) {
+	ssName := dgraphk8s.NewAlphaStatefulSet(dc).GetName()
+	groupSize := dc.Spec.AlphaGroupSize()
+
+	lastMembers := make(map[string]bool)
+	for ordinal := replicas - groupSize; ordinal < replicas; ordinal++ {
+		lastMembers[fmt.Sprintf("%s%s%d", ssName, defaults.K8SDelimeter, ordinal)] = true
+	}
+
+	for id, group := range state.Groups {
+		found := 0
+		for _, member := range group.Members {
+			if lastMembers[member.Hostname()] {
+				found++
+			}
+		}
+		if found == 0 {
+			continue
+		}
+		if found != len(lastMembers) || len(group.Members) != len(lastMembers) {
+			return 0, fmt.Errorf("alpha members with the highest ordinals are not a whole group")
+		}
+		gid, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid group ID %q: %s", id, err)
+		}
+		return uint32(gid), nil
+	}
+
+	return 0, fmt.Errorf("alpha members with the highest ordinals are not part of a group")
+}
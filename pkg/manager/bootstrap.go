@@ -0,0 +1,288 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/k8s"
+	dgraphk8s "github.com/dgraph-io/dgraph-operator/pkg/k8s/dgraph"
+	"github.com/dgraph-io/dgraph-operator/pkg/utils"
+	"github.com/golang/glog"
+
+	batchv1 "k8s.io/api/batch/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+	v1 "k8s.io/client-go/listers/apps/v1"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
+	klisters "k8s.io/client-go/listers/core/v1"
+)
+
+// BootstrapManager manages the initial data load of a dgraph cluster. It runs dgraph bulk
+// loader once zero is up and copies the output of each shard to the persistent volumes
+// of the alpha members of the corresponding group before the alpha cluster is scaled up.
+//
+// Bootstrapping goes through the following phases which are recorded in the DgraphCluster
+// status so that it can resume after an operator restart:
+// pending -> bulkLoading -> copying -> completed
+type BootstrapManager struct {
+	k8sClient kubernetes.Interface
+
+	pvcLister         klisters.PersistentVolumeClaimLister
+	statefulSetLister v1.StatefulSetLister
+	jobLister         batchlisters.JobLister
+}
+
+// NewBootstrapManager creates a new manager for bootstrapping dgraph clusters.
+func NewBootstrapManager(
+	k8sClient kubernetes.Interface,
+	pvcLister klisters.PersistentVolumeClaimLister,
+	statefulSetLister v1.StatefulSetLister,
+	jobLister batchlisters.JobLister,
+) *BootstrapManager {
+	return &BootstrapManager{
+		k8sClient,
+		pvcLister,
+		statefulSetLister,
+		jobLister,
+	}
+}
+
+// Sync advances the bootstrap of the provided DgraphCluster by at most one phase.
+func (bm *BootstrapManager) Sync(dc *v1alpha1.DgraphCluster) error {
+	if dc.Spec.Bootstrap == nil || dc.Spec.Bootstrap.BulkLoad == nil {
+		return nil
+	}
+	if dc.Status.Bootstrap == nil {
+		dc.Status.Bootstrap = &v1alpha1.BootstrapStatus{}
+	}
+	status := dc.Status.Bootstrap
+
+	glog.Infof("bootstrap-manager: syncing bootstrap of cluster %s in phase %q",
+		dc.GetName(), status.Phase)
+	switch status.Phase {
+	case "":
+		return bm.syncNew(dc, status)
+	case v1alpha1.BootstrapPhasePending:
+		return bm.syncPending(dc, status)
+	case v1alpha1.BootstrapPhaseBulkLoading:
+		return bm.syncBulkLoading(dc, status)
+	case v1alpha1.BootstrapPhaseCopying:
+		return bm.syncCopying(dc, status)
+	}
+
+	return nil
+}
+
+// syncNew decides if the cluster needs to be bootstrapped. Bulk loading is only
+// possible for clusters whose alpha members have never been started.
+func (bm *BootstrapManager) syncNew(dc *v1alpha1.DgraphCluster,
+	status *v1alpha1.BootstrapStatus) error {
+	for _, alpha := range dgraphk8s.NewAlphaStatefulSets(dc) {
+		ss, err := bm.statefulSetLister.StatefulSets(dc.GetNamespace()).Get(alpha.GetName())
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+		if err == nil && ss.Spec.Replicas != nil && *ss.Spec.Replicas > 0 {
+			status.Phase = v1alpha1.BootstrapPhaseCompleted
+			status.Message = "alpha cluster is already running, skipping bulk load"
+			return nil
+		}
+	}
+
+	status.Phase = v1alpha1.BootstrapPhasePending
+	status.Message = "waiting for dgraph zero to be ready"
+	return nil
+}
+
+// syncPending starts the bulk loader once at least one zero member is ready.
+func (bm *BootstrapManager) syncPending(dc *v1alpha1.DgraphCluster,
+	status *v1alpha1.BootstrapStatus) error {
+	ns := dc.GetNamespace()
+	zero, err := bm.statefulSetLister.StatefulSets(ns).
+		Get(utils.DgraphZeroMemberName(dc.Spec.GetClusterID(), dc.GetName()))
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if zero.Status.ReadyReplicas < 1 {
+		glog.Info("bootstrap-manager: dgraph zero is not ready yet")
+		return nil
+	}
+
+	outputPVC := dgraphk8s.NewBulkLoadOutputPVC(dc)
+	_, err = bm.pvcLister.PersistentVolumeClaims(ns).Get(outputPVC.GetName())
+	if kerrors.IsNotFound(err) {
+		glog.Infof("bootstrap-manager: creating bulk loader output volume claim: %s",
+			outputPVC.GetName())
+		err = k8s.CreateNewPersistentVolumeClaim(bm.k8sClient, ns, outputPVC)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := bm.ensureJob(ns, dgraphk8s.NewBulkLoadJob(dc)); err != nil {
+		return err
+	}
+
+	status.Phase = v1alpha1.BootstrapPhaseBulkLoading
+	status.Message = fmt.Sprintf("running bulk loader for %d groups", dc.Spec.AlphaGroupCount())
+	return nil
+}
+
+// syncBulkLoading waits for the bulk loader job to finish.
+func (bm *BootstrapManager) syncBulkLoading(dc *v1alpha1.DgraphCluster,
+	status *v1alpha1.BootstrapStatus) error {
+	job := dgraphk8s.NewBulkLoadJob(dc)
+	finished, succeeded, err := bm.jobResult(dc.GetNamespace(), job.GetName())
+	if err != nil || !finished {
+		return err
+	}
+
+	if !succeeded {
+		status.Phase = v1alpha1.BootstrapPhaseFailed
+		status.Message = fmt.Sprintf("bulk loader job %s failed", job.GetName())
+		return nil
+	}
+
+	status.Phase = v1alpha1.BootstrapPhaseCopying
+	status.Message = "copying bulk loader output to alpha volumes"
+	status.CopiedMembers = 0
+	return nil
+}
+
+// syncCopying copies the bulk loader output to the alpha persistent volumes one member
+// at a time, as the output volume can be mounted only on a single node.
+func (bm *BootstrapManager) syncCopying(dc *v1alpha1.DgraphCluster,
+	status *v1alpha1.BootstrapStatus) error {
+	ns := dc.GetNamespace()
+
+	members := dgraphk8s.AlphaMembers(dc)
+	for status.CopiedMembers < int32(len(members)) {
+		index := status.CopiedMembers
+		member := members[index]
+
+		pvc := dgraphk8s.NewAlphaPostingsVolumeClaim(dc, member)
+		_, err := bm.pvcLister.PersistentVolumeClaims(ns).Get(pvc.GetName())
+		if kerrors.IsNotFound(err) {
+			glog.Infof("bootstrap-manager: creating volume claim for alpha member: %s",
+				pvc.GetName())
+			err = k8s.CreateNewPersistentVolumeClaim(bm.k8sClient, ns, pvc)
+		}
+		if err != nil {
+			return err
+		}
+
+		job := dgraphk8s.NewBulkLoadCopyJob(dc, index, member)
+		if err := bm.ensureJob(ns, job); err != nil {
+			return err
+		}
+		finished, succeeded, err := bm.jobResult(ns, job.GetName())
+		if err != nil || !finished {
+			return err
+		}
+		if !succeeded {
+			status.Phase = v1alpha1.BootstrapPhaseFailed
+			status.Message = fmt.Sprintf("copying bulk loader output with job %s failed",
+				job.GetName())
+			return nil
+		}
+
+		status.CopiedMembers++
+	}
+
+	// Output of the bulk loader is no longer required once copied to the alpha volumes.
+	if err := bm.cleanup(dc); err != nil {
+		return err
+	}
+
+	status.Phase = v1alpha1.BootstrapPhaseCompleted
+	status.Message = "bulk load completed"
+	return nil
+}
+
+// cleanup deletes the jobs and the output volume claim used for bulk loading.
+func (bm *BootstrapManager) cleanup(dc *v1alpha1.DgraphCluster) error {
+	ns := dc.GetNamespace()
+
+	jobs := []string{dgraphk8s.NewBulkLoadJob(dc).GetName()}
+	for index, member := range dgraphk8s.AlphaMembers(dc) {
+		jobs = append(jobs, dgraphk8s.NewBulkLoadCopyJob(dc, int32(index), member).GetName())
+	}
+	for _, name := range jobs {
+		job, err := bm.jobLister.Jobs(ns).Get(name)
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		glog.Infof("bootstrap-manager: deleting bulk loader job: %s", name)
+		if err := k8s.DeleteJob(bm.k8sClient, ns, job); err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	pvc, err := bm.pvcLister.PersistentVolumeClaims(ns).
+		Get(dgraphk8s.NewBulkLoadOutputPVC(dc).GetName())
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	glog.Infof("bootstrap-manager: deleting bulk loader output volume claim: %s", pvc.GetName())
+	if err := k8s.DeletePersistentVolumeClaim(bm.k8sClient, ns, pvc); err != nil &&
+		!kerrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// ensureJob creates the job if it does not exist yet.
+func (bm *BootstrapManager) ensureJob(ns string, job *batchv1.Job) error {
+	_, err := bm.jobLister.Jobs(ns).Get(job.GetName())
+	if kerrors.IsNotFound(err) {
+		glog.Infof("bootstrap-manager: creating job: %s", job.GetName())
+		err = k8s.CreateNewJob(bm.k8sClient, ns, job)
+		if kerrors.IsAlreadyExists(err) {
+			return nil
+		}
+	}
+
+	return err
+}
+
+// jobResult returns if the job with the provided name has finished and whether it
+// succeeded.
+func (bm *BootstrapManager) jobResult(ns, name string) (bool, bool, error) {
+	job, err := bm.jobLister.Jobs(ns).Get(name)
+	if kerrors.IsNotFound(err) {
+		// The job was just created and is not in the informer cache yet.
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+
+	finished, succeeded := k8s.IsJobFinished(job)
+	return finished, succeeded, nil
+}
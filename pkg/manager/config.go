@@ -0,0 +1,61 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"github.com/dgraph-io/dgraph-operator/pkg/k8s"
+	"github.com/golang/glog"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+	klisters "k8s.io/client-go/listers/core/v1"
+)
+
+// syncConfigMap creates or updates the config map holding the config file of a dgraph
+// component. If the component has no config file, cm is nil and the config map with the
+// provided name is deleted if it exists.
+func syncConfigMap(k8sClient kubernetes.Interface, configMapLister klisters.ConfigMapLister,
+	ns, name string, cm *corev1.ConfigMap) error {
+	oldCM, err := configMapLister.ConfigMaps(ns).Get(name)
+	if kerrors.IsNotFound(err) {
+		if cm == nil {
+			return nil
+		}
+		glog.Infof("creating config map for dgraph config file: %s", name)
+		return k8s.CreateNewConfigMap(k8sClient, ns, cm)
+	}
+	if err != nil {
+		return err
+	}
+
+	if cm == nil {
+		glog.Infof("deleting config map of removed dgraph config file: %s", name)
+		return k8s.DeleteConfigMap(k8sClient, ns, oldCM)
+	}
+	if apiequality.Semantic.DeepEqual(cm.Data, oldCM.Data) {
+		return nil
+	}
+
+	cmUpdate := oldCM.DeepCopy()
+	cmUpdate.Data = cm.Data
+	glog.Infof("updating config map for dgraph config file: %s", name)
+	_, err = k8s.UpdateConfigMap(k8sClient, ns, cmUpdate)
+
+	return err
+}
@@ -0,0 +1,202 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/k8s"
+	"github.com/dgraph-io/dgraph-operator/pkg/utils"
+	"github.com/golang/glog"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes"
+	klisters "k8s.io/client-go/listers/core/v1"
+	storagelisters "k8s.io/client-go/listers/storage/v1"
+)
+
+// volumeExpander expands the persistent volume claims of the members of a dgraph stateful
+// set when the storage requests of its volume claim templates grow.
+//
+// Volume claim templates of a stateful set can't be updated, so the claims of the existing
+// members are expanded one by one and the stateful set is recreated once all of them have
+// been expanded, so that its templates match the claims of the members added afterwards.
+type volumeExpander struct {
+	k8sClient kubernetes.Interface
+
+	pvcLister          klisters.PersistentVolumeClaimLister
+	storageClassLister storagelisters.StorageClassLister
+}
+
+// newVolumeExpander creates a new volume expander for the stateful sets of dgraph components.
+func newVolumeExpander(
+	k8sClient kubernetes.Interface,
+	pvcLister klisters.PersistentVolumeClaimLister,
+	storageClassLister storagelisters.StorageClassLister,
+) *volumeExpander {
+	return &volumeExpander{
+		k8sClient,
+		pvcLister,
+		storageClassLister,
+	}
+}
+
+// sync expands the claims of the members of the existing stateful set oldSS whose volume
+// claim templates request less storage than the ones of the desired stateful set ss, which
+// must have the same volume claim templates. It returns the status of the expansion of each
+// claim, none if no storage request grew.
+//
+// Once all the claims have been expanded, the existing stateful set is deleted leaving its
+// pods running and recreated is true: the stateful set must be created again with the
+// desired volume claim templates.
+func (ve *volumeExpander) sync(ss, oldSS *appsv1.StatefulSet) (
+	statuses []v1alpha1.VolumeExpansionStatus, recreated bool, err error) {
+	replicas := int32(1)
+	if oldSS.Spec.Replicas != nil {
+		replicas = *oldSS.Spec.Replicas
+	}
+
+	expanded := true
+	for i, template := range ss.Spec.VolumeClaimTemplates {
+		requested := template.Spec.Resources.Requests[corev1.ResourceStorage]
+		oldRequested := oldSS.Spec.VolumeClaimTemplates[i].Spec.Resources.
+			Requests[corev1.ResourceStorage]
+		if requested.Cmp(oldRequested) <= 0 {
+			continue
+		}
+
+		for ordinal := int32(0); ordinal < replicas; ordinal++ {
+			claimName := utils.StatefulSetPVCName(template.Name, oldSS.Name, ordinal)
+			pvc, err := ve.pvcLister.PersistentVolumeClaims(oldSS.Namespace).Get(claimName)
+			if kerrors.IsNotFound(err) {
+				// The claim is created from the desired template along with the pod.
+				continue
+			}
+			if err != nil {
+				return nil, false, err
+			}
+
+			status, err := ve.expandClaim(pvc, requested)
+			if err != nil {
+				return nil, false, err
+			}
+			if status.Phase != v1alpha1.VolumeExpansionPhaseExpanded {
+				expanded = false
+			}
+			statuses = append(statuses, status)
+		}
+	}
+
+	if len(statuses) == 0 || !expanded {
+		return statuses, false, nil
+	}
+
+	glog.Infof("volumes of stateful set %s expanded, recreating the stateful set with "+
+		"the new volume claim templates", oldSS.Name)
+	if err := k8s.OrphanStatefulSet(ve.k8sClient, oldSS.Namespace, oldSS); err != nil {
+		return nil, false, err
+	}
+
+	return statuses, true, nil
+}
+
+// expandClaim increases the storage request of the provided persistent volume claim to
+// the requested storage if its storage class allows it, and returns the status of the
+// expansion of the claim.
+func (ve *volumeExpander) expandClaim(pvc *corev1.PersistentVolumeClaim,
+	requested resource.Quantity) (v1alpha1.VolumeExpansionStatus, error) {
+	capacity := pvc.Status.Capacity[corev1.ResourceStorage]
+	status := v1alpha1.VolumeExpansionStatus{
+		Claim:     pvc.Name,
+		Phase:     v1alpha1.VolumeExpansionPhaseExpanding,
+		Requested: requested.String(),
+		Capacity:  capacity.String(),
+	}
+	if capacity.Cmp(requested) >= 0 {
+		status.Phase = v1alpha1.VolumeExpansionPhaseExpanded
+		return status, nil
+	}
+
+	allowed, err := ve.expansionAllowed(pvc)
+	if err != nil {
+		return status, err
+	}
+	if !allowed {
+		status.Phase = v1alpha1.VolumeExpansionPhaseUnsupported
+		status.Message = fmt.Sprintf("storage class %q of the claim doesn't allow volume "+
+			"expansion", storageClassName(pvc))
+		return status, nil
+	}
+
+	current := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if current.Cmp(requested) < 0 {
+		glog.Infof("expanding persistent volume claim %s from %s to %s", pvc.Name,
+			current.String(), requested.String())
+		pvcUpdate := pvc.DeepCopy()
+		if pvcUpdate.Spec.Resources.Requests == nil {
+			pvcUpdate.Spec.Resources.Requests = corev1.ResourceList{}
+		}
+		pvcUpdate.Spec.Resources.Requests[corev1.ResourceStorage] = requested
+		if _, err := k8s.UpdatePersistentVolumeClaim(ve.k8sClient, pvc.Namespace,
+			pvcUpdate); err != nil {
+			return status, err
+		}
+	}
+
+	for _, condition := range pvc.Status.Conditions {
+		if condition.Type == corev1.PersistentVolumeClaimFileSystemResizePending &&
+			condition.Status == corev1.ConditionTrue {
+			status.Phase = v1alpha1.VolumeExpansionPhaseFileSystemResizePending
+			status.Message = condition.Message
+		}
+	}
+
+	return status, nil
+}
+
+// expansionAllowed returns true if the storage class of the provided persistent volume
+// claim allows volume expansion.
+func (ve *volumeExpander) expansionAllowed(pvc *corev1.PersistentVolumeClaim) (bool, error) {
+	name := storageClassName(pvc)
+	if name == "" {
+		return false, nil
+	}
+
+	sc, err := ve.storageClassLister.Get(name)
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return sc.AllowVolumeExpansion != nil && *sc.AllowVolumeExpansion, nil
+}
+
+// storageClassName returns the name of the storage class of the provided persistent volume
+// claim, empty if it has none.
+func storageClassName(pvc *corev1.PersistentVolumeClaim) string {
+	if pvc.Spec.StorageClassName == nil {
+		return ""
+	}
+
+	return *pvc.Spec.StorageClassName
+}
@@ -0,0 +1,316 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+	"github.com/dgraph-io/dgraph-operator/pkg/dgraph"
+	"github.com/dgraph-io/dgraph-operator/pkg/k8s"
+	dgraphk8s "github.com/dgraph-io/dgraph-operator/pkg/k8s/dgraph"
+	"github.com/dgraph-io/dgraph-operator/pkg/utils"
+	"github.com/golang/glog"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	v1 "k8s.io/client-go/listers/apps/v1"
+	klisters "k8s.io/client-go/listers/core/v1"
+)
+
+// HibernationManager hibernates a dgraph cluster when it's paused or outside of the times
+// of its hibernation schedule, and resumes it afterwards. The hibernation is recorded in the
+// status of the cluster, which the stateful sets and the ratel deployment are scaled by:
+//
+// stopping -> hibernated -> resuming -> starting
+//
+// The alpha members are shut down through their /admin endpoint and scaled down first, then
+// zero and ratel once all the alpha members are gone. Persistent volume claims are kept.
+// When resuming, zero is scaled back up first and alpha and ratel follow once a quorum of
+// zero members is ready. The components are resumed with the replicas they had when the
+// cluster started hibernating, as the specification and the autoscaling status may have
+// changed meanwhile. Such changes are applied once all the members are ready again.
+type HibernationManager struct {
+	podLister         klisters.PodLister
+	statefulSetLister v1.StatefulSetLister
+	deploymentLister  v1.DeploymentLister
+}
+
+// NewHibernationManager creates a new manager for the hibernation of dgraph clusters.
+func NewHibernationManager(
+	podLister klisters.PodLister,
+	statefulSetLister v1.StatefulSetLister,
+	deploymentLister v1.DeploymentLister,
+) *HibernationManager {
+	return &HibernationManager{
+		podLister,
+		statefulSetLister,
+		deploymentLister,
+	}
+}
+
+// Sync advances the hibernation of the provided DgraphCluster towards the state requested
+// by its specification.
+func (hm *HibernationManager) Sync(dc *v1alpha1.DgraphCluster) error {
+	if schedule := dc.Spec.HibernationSchedule; schedule != nil {
+		if err := schedule.Validate(); err != nil {
+			return err
+		}
+	}
+
+	requested, err := dc.HibernationRequested(time.Now())
+	if err != nil {
+		return err
+	}
+
+	status := dc.Status.Hibernation
+	if !requested {
+		if status == nil {
+			return nil
+		}
+		return hm.syncResuming(dc)
+	}
+
+	if status == nil || status.Phase == v1alpha1.HibernationPhaseResuming ||
+		status.Phase == v1alpha1.HibernationPhaseStarting {
+		return hm.hibernate(dc)
+	}
+	if status.Phase == v1alpha1.HibernationPhaseStopping {
+		return hm.syncStopping(dc)
+	}
+
+	return nil
+}
+
+// hibernate records the current number of replicas of the components of the provided
+// DgraphCluster, unless it's still resuming from a previous hibernation, and shuts down its
+// alpha members. The alpha stateful sets are then scaled down to zero.
+func (hm *HibernationManager) hibernate(dc *v1alpha1.DgraphCluster) error {
+	// The initial data load and the storage migration of a member must complete first, as
+	// they manage the alpha members on their own.
+	if dc.AlphaBootstrapPending() {
+		glog.Infof("hibernation-manager: waiting for the bootstrap of cluster %s to "+
+			"complete before hibernating", dc.GetName())
+		return nil
+	}
+	if migration := dc.Status.AlphaCluster.StorageMigration; migration != nil &&
+		migration.Member != "" {
+		glog.Infof("hibernation-manager: waiting for the storage migration of alpha "+
+			"member %s to complete before hibernating", migration.Member)
+		return nil
+	}
+
+	status := dc.Status.Hibernation
+	if status == nil {
+		var err error
+		if status, err = hm.currentReplicas(dc); err != nil {
+			return err
+		}
+	}
+
+	pods, err := hm.readyAlphaPods(dc)
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods {
+		glog.Infof("hibernation-manager: shutting down alpha member %s", pod.GetName())
+		// Members which can't be shut down are stopped when their stateful set is scaled
+		// down anyway.
+		client := dgraph.NewClient(fmt.Sprintf("http://%s:%d", pod.Status.PodIP,
+			defaults.AlphaHTTPPort))
+		if err := client.Shutdown(); err != nil {
+			glog.Warningf("hibernation-manager: error shutting down alpha member %s: %s",
+				pod.GetName(), err)
+		}
+	}
+
+	now := metav1.Now()
+	status.Phase = v1alpha1.HibernationPhaseStopping
+	status.HibernationTime = &now
+	dc.Status.Hibernation = status
+
+	// Zero and ratel are scaled down right away if no alpha member is running, as when
+	// hibernating again while resuming.
+	return hm.syncStopping(dc)
+}
+
+// syncStopping scales zero and ratel down to zero once all the alpha members are gone.
+func (hm *HibernationManager) syncStopping(dc *v1alpha1.DgraphCluster) error {
+	pods, err := hm.alphaPods(dc)
+	if err != nil {
+		return err
+	}
+	if len(pods) > 0 {
+		dc.Status.Hibernation.Message = fmt.Sprintf("waiting for %d alpha members to stop",
+			len(pods))
+		return nil
+	}
+
+	glog.Infof("hibernation-manager: alpha members of cluster %s stopped, scaling down "+
+		"zero and ratel", dc.GetName())
+	dc.Status.Hibernation.Phase = v1alpha1.HibernationPhaseHibernated
+	dc.Status.Hibernation.Message = "all the members are scaled down to zero, their " +
+		"persistent volume claims are kept"
+
+	return nil
+}
+
+// syncResuming scales zero back up to the replicas recorded when the cluster started
+// hibernating, and resumes alpha and ratel once a quorum of zero members is ready.
+func (hm *HibernationManager) syncResuming(dc *v1alpha1.DgraphCluster) error {
+	status := dc.Status.Hibernation
+	if status.Phase == v1alpha1.HibernationPhaseStarting {
+		return hm.syncStarting(dc)
+	}
+	if status.Phase != v1alpha1.HibernationPhaseResuming {
+		glog.Infof("hibernation-manager: resuming cluster %s", dc.GetName())
+		status.Phase = v1alpha1.HibernationPhaseResuming
+	}
+
+	quorum := dc.ZeroReplicas()/2 + 1
+	ss, err := hm.statefulSetLister.StatefulSets(dc.GetNamespace()).
+		Get(utils.DgraphZeroMemberName(dc.Spec.GetClusterID(), dc.GetName()))
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	if err != nil || ss.Status.ReadyReplicas < quorum {
+		status.Message = fmt.Sprintf("waiting for %d zero members to be ready", quorum)
+		return nil
+	}
+
+	glog.Infof("hibernation-manager: zero of cluster %s is ready, resuming alpha and ratel",
+		dc.GetName())
+	status.Phase = v1alpha1.HibernationPhaseStarting
+	status.Message = "waiting for alpha and ratel to be ready"
+
+	return nil
+}
+
+// syncStarting completes the hibernation once the alpha stateful sets and the ratel
+// deployment have all the replicas recorded when the cluster started hibernating ready.
+func (hm *HibernationManager) syncStarting(dc *v1alpha1.DgraphCluster) error {
+	ns := dc.GetNamespace()
+	status := dc.Status.Hibernation
+
+	for _, alpha := range dgraphk8s.NewAlphaStatefulSets(dc) {
+		replicas, ok := dc.HibernatedAlphaReplicas(alpha.GetName())
+		if !ok {
+			continue
+		}
+		ss, err := hm.statefulSetLister.StatefulSets(ns).Get(alpha.GetName())
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+		if err != nil || ss.Status.ReadyReplicas < replicas {
+			status.Message = fmt.Sprintf("waiting for %d members of alpha stateful set %s "+
+				"to be ready", replicas, alpha.GetName())
+			return nil
+		}
+	}
+
+	if dc.Spec.Ratel != nil {
+		replicas := dc.RatelReplicas()
+		ratel, err := hm.deploymentLister.Deployments(ns).
+			Get(utils.DgraphRatelMemberName(dc.Spec.GetClusterID(), dc.GetName()))
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+		if err != nil || ratel.Status.ReadyReplicas < replicas {
+			status.Message = fmt.Sprintf("waiting for %d ratel replicas to be ready", replicas)
+			return nil
+		}
+	}
+
+	glog.Infof("hibernation-manager: cluster %s resumed", dc.GetName())
+	dc.Status.Hibernation = nil
+
+	return nil
+}
+
+// currentReplicas returns a new hibernation status recording the current number of
+// replicas of the components of the provided DgraphCluster.
+func (hm *HibernationManager) currentReplicas(
+	dc *v1alpha1.DgraphCluster) (*v1alpha1.HibernationStatus, error) {
+	ns := dc.GetNamespace()
+	status := &v1alpha1.HibernationStatus{
+		AlphaReplicas: make(map[string]int32),
+	}
+
+	zero, err := hm.statefulSetLister.StatefulSets(ns).
+		Get(utils.DgraphZeroMemberName(dc.Spec.GetClusterID(), dc.GetName()))
+	if err != nil && !kerrors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil && zero.Spec.Replicas != nil {
+		status.ZeroReplicas = *zero.Spec.Replicas
+	}
+
+	for _, alpha := range dgraphk8s.NewAlphaStatefulSets(dc) {
+		ss, err := hm.statefulSetLister.StatefulSets(ns).Get(alpha.GetName())
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if ss.Spec.Replicas != nil {
+			status.AlphaReplicas[ss.GetName()] = *ss.Spec.Replicas
+		}
+	}
+
+	ratelName := utils.DgraphRatelMemberName(dc.Spec.GetClusterID(), dc.GetName())
+	ratel, err := hm.deploymentLister.Deployments(ns).Get(ratelName)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil && ratel.Spec.Replicas != nil {
+		status.RatelReplicas = *ratel.Spec.Replicas
+	}
+
+	return status, nil
+}
+
+// alphaPods returns the pods of the alpha members of the provided DgraphCluster.
+func (hm *HibernationManager) alphaPods(dc *v1alpha1.DgraphCluster) ([]*corev1.Pod, error) {
+	selector := klabels.SelectorFromSet(dgraphk8s.DefaultAlphaLabels(
+		utils.DgraphAlphaMemberName(dc.Spec.GetClusterID(), dc.GetName())))
+
+	return hm.podLister.Pods(dc.GetNamespace()).List(selector)
+}
+
+// readyAlphaPods returns the pods of the alpha members of the provided DgraphCluster which
+// are ready to serve requests.
+func (hm *HibernationManager) readyAlphaPods(dc *v1alpha1.DgraphCluster) ([]*corev1.Pod,
+	error) {
+	pods, err := hm.alphaPods(dc)
+	if err != nil {
+		return nil, err
+	}
+
+	ready := make([]*corev1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if k8s.IsPodReady(pod) && pod.Status.PodIP != "" {
+			ready = append(ready, pod)
+		}
+	}
+
+	return ready, nil
+}
@@ -0,0 +1,199 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/k8s"
+	dgraphk8s "github.com/dgraph-io/dgraph-operator/pkg/k8s/dgraph"
+	k8sutils "github.com/dgraph-io/dgraph-operator/pkg/k8s/utils"
+	"github.com/dgraph-io/dgraph-operator/pkg/utils"
+
+	"github.com/golang/glog"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	networkinglisters "k8s.io/client-go/listers/networking/v1beta1"
+)
+
+// IngressManager manages the resources exposing the HTTP endpoints of dgraph alpha and ratel
+// outside of the kubernetes cluster, which are:
+//  1. Ingress: one ingress for each of alpha and ratel with an ingress configured.
+//  2. Gateway API routes: an HTTPRoute for each of alpha and ratel with routes configured,
+//     and a GRPCRoute for alpha if requested. The routes are only created when the
+//     Gateway API CRDs are installed in the cluster.
+type IngressManager struct {
+	k8sClient kubernetes.Interface
+
+	// dynamicClient is used for the Gateway API routes, as their types are not known
+	// to the kubernetes client.
+	dynamicClient dynamic.Interface
+
+	ingressLister networkinglisters.IngressLister
+}
+
+// NewIngressManager creates a new manager for the ingresses and routes of dgraph
+// components.
+func NewIngressManager(
+	k8sClient kubernetes.Interface,
+	dynamicClient dynamic.Interface,
+	ingressLister networkinglisters.IngressLister,
+) *IngressManager {
+	return &IngressManager{
+		k8sClient,
+		dynamicClient,
+		ingressLister,
+	}
+}
+
+// Sync syncs the ingresses and routes of dgraph components with the DgraphCluster
+// specification. Ingresses and routes which are no longer configured are deleted.
+func (im *IngressManager) Sync(dc *v1alpha1.DgraphCluster) error {
+	glog.Infof("ingress-manager: syncing ingresses for cluster: %s", dc.GetName())
+
+	clusterID := dc.Spec.GetClusterID()
+	alphaName := utils.DgraphAlphaMemberName(clusterID, dc.GetName())
+	ratelName := utils.DgraphRatelMemberName(clusterID, dc.GetName())
+
+	if err := im.syncIngress(dc, alphaName, dgraphk8s.NewAlphaIngress(dc)); err != nil {
+		return err
+	}
+	if err := im.syncIngress(dc, ratelName, dgraphk8s.NewRatelIngress(dc)); err != nil {
+		return err
+	}
+
+	return im.syncGatewayRoutes(dc, alphaName, ratelName)
+}
+
+// syncIngress creates or updates the ingress with the provided name. If ing is nil the
+// existing ingress is deleted if it's owned by the DgraphCluster.
+func (im *IngressManager) syncIngress(dc *v1alpha1.DgraphCluster, name string,
+	ing *networkingv1beta1.Ingress) error {
+	ns := dc.GetNamespace()
+	oldIngress, err := im.ingressLister.Ingresses(ns).Get(name)
+	if kerrors.IsNotFound(err) {
+		if ing == nil {
+			return nil
+		}
+		glog.Infof("ingress-manager: creating new ingress: %s", name)
+		return k8s.CreateNewIngress(im.k8sClient, ns, ing)
+	}
+	if err != nil {
+		return err
+	}
+
+	if ing == nil {
+		if !metav1.IsControlledBy(oldIngress, dc) {
+			return nil
+		}
+		glog.Infof("ingress-manager: deleting ingress no longer configured: %s", name)
+		return k8s.DeleteIngress(im.k8sClient, ns, oldIngress)
+	}
+
+	if apiequality.Semantic.DeepDerivative(ing.Spec, oldIngress.Spec) &&
+		metadataUpToDate(ing.ObjectMeta, oldIngress.ObjectMeta) {
+		return nil
+	}
+
+	ingressUpdate := oldIngress.DeepCopy()
+	ingressUpdate.Spec = ing.Spec
+	mergeMetadata(&ingressUpdate.ObjectMeta, ing.ObjectMeta)
+	glog.Infof("ingress-manager: updating ingress: %s", name)
+	_, err = k8s.UpdateIngress(im.k8sClient, ns, ingressUpdate)
+
+	return err
+}
+
+// syncGatewayRoutes syncs the Gateway API routes of dgraph components. Routes of
+// resources not served by the API server are skipped.
+func (im *IngressManager) syncGatewayRoutes(dc *v1alpha1.DgraphCluster,
+	alphaName, ratelName string) error {
+	routes := []struct {
+		resource string
+		name     string
+		route    *unstructured.Unstructured
+	}{
+		{dgraphk8s.HTTPRouteResource, alphaName, dgraphk8s.NewAlphaHTTPRoute(dc)},
+		{dgraphk8s.GRPCRouteResource, alphaName, dgraphk8s.NewAlphaGRPCRoute(dc)},
+		{dgraphk8s.HTTPRouteResource, ratelName, dgraphk8s.NewRatelHTTPRoute(dc)},
+	}
+
+	served, err := k8s.GatewayRouteResources(im.k8sClient.Discovery())
+	if err != nil {
+		return err
+	}
+
+	for _, r := range routes {
+		if !served[r.resource] {
+			if r.route != nil {
+				glog.Warningf("ingress-manager: %s are not served by the kubernetes API "+
+					"server, skipping %s", r.resource, r.name)
+			}
+			continue
+		}
+		if err := im.syncGatewayRoute(dc, r.resource, r.name, r.route); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncGatewayRoute creates or updates the Gateway API route with the provided resource and
+// name. If route is nil the existing route is deleted if it's owned by the DgraphCluster.
+func (im *IngressManager) syncGatewayRoute(dc *v1alpha1.DgraphCluster, resource, name string,
+	route *unstructured.Unstructured) error {
+	ns := dc.GetNamespace()
+	oldRoute, err := k8s.GetRoute(im.dynamicClient, resource, ns, name)
+	if kerrors.IsNotFound(err) {
+		if route == nil {
+			return nil
+		}
+		glog.Infof("ingress-manager: creating new %s: %s", resource, name)
+		return k8s.CreateNewRoute(im.dynamicClient, resource, ns, route)
+	}
+	if err != nil {
+		return err
+	}
+
+	if route == nil {
+		if !metav1.IsControlledBy(oldRoute, dc) {
+			return nil
+		}
+		glog.Infof("ingress-manager: deleting %s no longer configured: %s", resource, name)
+		return k8s.DeleteRoute(im.dynamicClient, resource, ns, oldRoute)
+	}
+
+	if apiequality.Semantic.DeepDerivative(route.Object["spec"], oldRoute.Object["spec"]) &&
+		apiequality.Semantic.DeepDerivative(route.GetLabels(), oldRoute.GetLabels()) &&
+		apiequality.Semantic.DeepDerivative(route.GetAnnotations(), oldRoute.GetAnnotations()) {
+		return nil
+	}
+
+	oldRoute.Object["spec"] = route.Object["spec"]
+	oldRoute.SetLabels(k8sutils.MergeStringMaps(oldRoute.GetLabels(), route.GetLabels()))
+	oldRoute.SetAnnotations(k8sutils.MergeStringMaps(oldRoute.GetAnnotations(),
+		route.GetAnnotations()))
+	glog.Infof("ingress-manager: updating %s: %s", resource, name)
+	_, err = k8s.UpdateRoute(im.dynamicClient, resource, ns, oldRoute)
+
+	return err
+}
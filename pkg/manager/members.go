@@ -0,0 +1,68 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+	"github.com/dgraph-io/dgraph-operator/pkg/k8s"
+	dgraphk8s "github.com/dgraph-io/dgraph-operator/pkg/k8s/dgraph"
+	"github.com/dgraph-io/dgraph-operator/pkg/utils"
+
+	appsv1 "k8s.io/api/apps/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	klisters "k8s.io/client-go/listers/core/v1"
+)
+
+// addStatefulSetMembers adds the members of the provided stateful set of a dgraph
+// component to members, keyed by pod name. The ID of a member is its ordinal and its URL
+// is the address of the provided port of the member through the headless service.
+func addStatefulSetMembers(podLister klisters.PodLister, dc *v1alpha1.DgraphCluster,
+	ss *appsv1.StatefulSet, port int32, members map[string]v1alpha1.DgraphComponent) error {
+	ns := dc.GetNamespace()
+	pods, err := podLister.Pods(ns).
+		List(klabels.SelectorFromSet(ss.Spec.Selector.MatchLabels))
+	if err != nil {
+		return err
+	}
+
+	prefix := ss.GetName() + defaults.K8SDelimeter
+	for _, pod := range pods {
+		// The selector of the single alpha stateful set also matches the members of the
+		// alpha group stateful sets.
+		ordinal := strings.TrimPrefix(pod.GetName(), prefix)
+		if ordinal == pod.GetName() {
+			continue
+		}
+		if _, err := strconv.ParseUint(ordinal, 10, 32); err != nil {
+			continue
+		}
+
+		host := utils.PodHost(ss.GetName(), ss.Spec.ServiceName, ordinal)
+		members[pod.GetName()] = v1alpha1.DgraphComponent{
+			Name:         pod.GetName(),
+			ID:           ordinal,
+			ComponentURL: utils.ServiceAddress(host, ns, dgraphk8s.ClusterDomain(dc), port),
+			Healthy:      k8s.IsPodReady(pod),
+		}
+	}
+
+	return nil
+}
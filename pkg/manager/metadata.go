@@ -0,0 +1,39 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	k8sutils "github.com/dgraph-io/dgraph-operator/pkg/k8s/utils"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// metadataUpToDate returns true if the labels and annotations of the provided object
+// metadata are all set on the existing object metadata.
+func metadataUpToDate(meta, oldMeta metav1.ObjectMeta) bool {
+	return apiequality.Semantic.DeepDerivative(meta.Labels, oldMeta.Labels) &&
+		apiequality.Semantic.DeepDerivative(meta.Annotations, oldMeta.Annotations)
+}
+
+// mergeMetadata sets the labels and annotations of the provided object metadata on the
+// existing object metadata, keeping the ones set by other controllers. New maps are
+// allocated so that objects from the listers' cache are left untouched.
+func mergeMetadata(oldMeta *metav1.ObjectMeta, meta metav1.ObjectMeta) {
+	oldMeta.Labels = k8sutils.MergeStringMaps(oldMeta.Labels, meta.Labels)
+	oldMeta.Annotations = k8sutils.MergeStringMaps(oldMeta.Annotations, meta.Annotations)
+}
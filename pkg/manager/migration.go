@@ -0,0 +1,464 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+	"github.com/dgraph-io/dgraph-operator/pkg/k8s"
+	dgraphk8s "github.com/dgraph-io/dgraph-operator/pkg/k8s/dgraph"
+	"github.com/golang/glog"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+	v1 "k8s.io/client-go/listers/apps/v1"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
+	klisters "k8s.io/client-go/listers/core/v1"
+)
+
+// storageMigrator migrates the data of the alpha members to new persistent volume claims
+// when the storage class of their volume claim templates changes, one member at a time.
+//
+// Migrating a member goes through the following phases which are recorded in the
+// DgraphCluster status so that it can resume after an operator restart:
+// stopping -> copying -> restoring -> starting
+type storageMigrator struct {
+	k8sClient kubernetes.Interface
+
+	podLister         klisters.PodLister
+	pvcLister         klisters.PersistentVolumeClaimLister
+	statefulSetLister v1.StatefulSetLister
+	jobLister         batchlisters.JobLister
+}
+
+// newStorageMigrator creates a new storage migrator for the alpha members.
+func newStorageMigrator(
+	k8sClient kubernetes.Interface,
+	podLister klisters.PodLister,
+	pvcLister klisters.PersistentVolumeClaimLister,
+	statefulSetLister v1.StatefulSetLister,
+	jobLister batchlisters.JobLister,
+) *storageMigrator {
+	return &storageMigrator{
+		k8sClient,
+		podLister,
+		pvcLister,
+		statefulSetLister,
+		jobLister,
+	}
+}
+
+// sync advances the migration of the alpha members of the provided DgraphCluster. A member
+// being migrated is always migrated to completion, even if migrations are disabled meanwhile.
+func (sm *storageMigrator) sync(dc *v1alpha1.DgraphCluster) error {
+	if dc.Spec.AlphaCluster.Storage.Ephemeral() || dc.AlphaBootstrapPending() ||
+		dc.Status.Hibernation != nil {
+		return nil
+	}
+
+	status := dc.Status.AlphaCluster.StorageMigration
+	inProgress := status != nil && status.Member != ""
+	if dc.Spec.AlphaCluster.StorageMigration == nil && !inProgress {
+		dc.Status.AlphaCluster.StorageMigration = nil
+		return nil
+	}
+	if !inProgress {
+		return sm.syncNext(dc)
+	}
+
+	member, ok := findAlphaMember(dc, status.Member)
+	if !ok {
+		glog.Infof("storage-migrator: alpha member %s was removed, abandoning its migration",
+			status.Member)
+		resetStorageMigration(status, fmt.Sprintf("alpha member %s was removed",
+			status.Member))
+		return nil
+	}
+
+	glog.Infof("storage-migrator: syncing migration of alpha member %s in phase %q",
+		status.Member, status.Phase)
+	switch status.Phase {
+	case v1alpha1.StorageMigrationPhaseStopping:
+		return sm.syncStopping(dc, status, member)
+	case v1alpha1.StorageMigrationPhaseCopying:
+		return sm.syncCopying(dc, status, member)
+	case v1alpha1.StorageMigrationPhaseRestoring:
+		return sm.syncRestoring(dc, status, member)
+	case v1alpha1.StorageMigrationPhaseStarting:
+		return sm.syncStarting(dc, status, member)
+	}
+
+	return nil
+}
+
+// syncNext starts migrating the first alpha member with claims of a storage class other
+// than the one of their volume claim templates, once all the alpha members are ready.
+func (sm *storageMigrator) syncNext(dc *v1alpha1.DgraphCluster) error {
+	status := dc.Status.AlphaCluster.StorageMigration
+	for _, member := range dgraphk8s.AlphaMembers(dc) {
+		claims, err := sm.claimsToMigrate(member)
+		if err != nil {
+			return err
+		}
+		if len(claims) == 0 {
+			continue
+		}
+
+		if status == nil || status.Phase == v1alpha1.StorageMigrationPhaseCompleted {
+			status = &v1alpha1.StorageMigrationStatus{}
+			dc.Status.AlphaCluster.StorageMigration = status
+		}
+
+		ready, err := sm.alphaReady(dc)
+		if err != nil {
+			return err
+		}
+		if !ready {
+			status.Message = "waiting for all the alpha members to be ready"
+			return nil
+		}
+
+		status.Phase = v1alpha1.StorageMigrationPhaseStopping
+		status.StatefulSet = member.StatefulSet.GetName()
+		status.Member = alphaMemberPodName(member)
+		status.Claims = claims
+		status.Message = fmt.Sprintf("stopping alpha member %s", status.Member)
+		glog.Infof("storage-migrator: migrating alpha member %s to new storage class",
+			status.Member)
+		return sm.syncStopping(dc, status, member)
+	}
+
+	if status != nil && status.Phase != v1alpha1.StorageMigrationPhaseCompleted {
+		status.Phase = v1alpha1.StorageMigrationPhaseCompleted
+		status.Message = "all alpha members use the storage classes of the specification"
+	}
+
+	return nil
+}
+
+// syncStopping deletes the stateful set of the member, leaving the other members running,
+// and then the pod of the member so that its claims can be copied.
+func (sm *storageMigrator) syncStopping(dc *v1alpha1.DgraphCluster,
+	status *v1alpha1.StorageMigrationStatus, member dgraphk8s.AlphaMember) error {
+	ns := dc.GetNamespace()
+
+	ss, err := sm.statefulSetLister.StatefulSets(ns).Get(status.StatefulSet)
+	if err == nil {
+		if ss.GetDeletionTimestamp() == nil {
+			glog.Infof("storage-migrator: deleting stateful set %s leaving its pods running",
+				ss.GetName())
+			if err := k8s.OrphanStatefulSet(sm.k8sClient, ns, ss); err != nil &&
+				!kerrors.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+	if !kerrors.IsNotFound(err) {
+		return err
+	}
+
+	pod, err := sm.podLister.Pods(ns).Get(status.Member)
+	if err == nil {
+		if pod.GetDeletionTimestamp() == nil {
+			glog.Infof("storage-migrator: stopping alpha member %s", pod.GetName())
+			if err := k8s.DeletePod(sm.k8sClient, ns, pod); err != nil &&
+				!kerrors.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+	if !kerrors.IsNotFound(err) {
+		return err
+	}
+
+	status.Phase = v1alpha1.StorageMigrationPhaseCopying
+	status.Message = fmt.Sprintf("copying data of alpha member %s to temporary volumes",
+		status.Member)
+	return sm.syncCopying(dc, status, member)
+}
+
+// syncCopying copies the data of the claims of the stopped member to temporary claims of
+// the new storage class.
+func (sm *storageMigrator) syncCopying(dc *v1alpha1.DgraphCluster,
+	status *v1alpha1.StorageMigrationStatus, member dgraphk8s.AlphaMember) error {
+	ns := dc.GetNamespace()
+
+	for _, claim := range migratedClaims(member, status.Claims) {
+		pvc := dgraphk8s.NewStorageMigrationClaim(claim)
+		_, err := sm.pvcLister.PersistentVolumeClaims(ns).Get(pvc.GetName())
+		if kerrors.IsNotFound(err) {
+			glog.Infof("storage-migrator: creating temporary volume claim: %s", pvc.GetName())
+			err = k8s.CreateNewPersistentVolumeClaim(sm.k8sClient, ns, pvc)
+			if kerrors.IsAlreadyExists(err) {
+				err = nil
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	job := dgraphk8s.NewStorageMigrationCopyJob(dc, status.Member, status.Claims)
+	if done, err := sm.runJob(status, job); err != nil || !done {
+		return err
+	}
+
+	status.Phase = v1alpha1.StorageMigrationPhaseRestoring
+	status.Message = fmt.Sprintf("recreating volume claims of alpha member %s with the new "+
+		"storage class", status.Member)
+	return sm.syncRestoring(dc, status, member)
+}
+
+// syncRestoring recreates the claims of the stopped member with the new storage class and
+// copies the data back to them from the temporary claims.
+func (sm *storageMigrator) syncRestoring(dc *v1alpha1.DgraphCluster,
+	status *v1alpha1.StorageMigrationStatus, member dgraphk8s.AlphaMember) error {
+	ns := dc.GetNamespace()
+
+	recreated := true
+	for _, claim := range migratedClaims(member, status.Claims) {
+		pvc, err := sm.pvcLister.PersistentVolumeClaims(ns).Get(claim.GetName())
+		if kerrors.IsNotFound(err) {
+			glog.Infof("storage-migrator: recreating volume claim %s with storage class %s",
+				claim.GetName(), storageClassName(claim))
+			err = k8s.CreateNewPersistentVolumeClaim(sm.k8sClient, ns, claim)
+			if err != nil && !kerrors.IsAlreadyExists(err) {
+				return err
+			}
+			recreated = false
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if storageClassName(pvc) != storageClassName(claim) {
+			if pvc.GetDeletionTimestamp() == nil {
+				glog.Infof("storage-migrator: deleting volume claim %s of storage class %s",
+					pvc.GetName(), storageClassName(pvc))
+				if err := k8s.DeletePersistentVolumeClaim(sm.k8sClient, ns, pvc); err != nil &&
+					!kerrors.IsNotFound(err) {
+					return err
+				}
+			}
+			recreated = false
+		}
+	}
+	if !recreated {
+		return nil
+	}
+
+	job := dgraphk8s.NewStorageMigrationRestoreJob(dc, status.Member, status.Claims)
+	if done, err := sm.runJob(status, job); err != nil || !done {
+		return err
+	}
+
+	status.Phase = v1alpha1.StorageMigrationPhaseStarting
+	status.Message = fmt.Sprintf("starting alpha member %s", status.Member)
+	return sm.syncStarting(dc, status, member)
+}
+
+// syncStarting waits for the migrated member to be ready, the alpha manager recreates its
+// stateful set once in this phase. The temporary claims are kept until then.
+func (sm *storageMigrator) syncStarting(dc *v1alpha1.DgraphCluster,
+	status *v1alpha1.StorageMigrationStatus, member dgraphk8s.AlphaMember) error {
+	ns := dc.GetNamespace()
+
+	pod, err := sm.podLister.Pods(ns).Get(status.Member)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	if err != nil || !k8s.IsPodReady(pod) {
+		glog.Infof("storage-migrator: waiting for alpha member %s to be ready",
+			status.Member)
+		return nil
+	}
+
+	if err := sm.cleanup(dc, status, member); err != nil {
+		return err
+	}
+
+	glog.Infof("storage-migrator: alpha member %s migrated", status.Member)
+	status.MigratedMembers = append(status.MigratedMembers, status.Member)
+	resetStorageMigration(status, fmt.Sprintf("alpha member %s migrated", status.Member))
+	return nil
+}
+
+// cleanup deletes the jobs and the temporary claims used to migrate the member.
+func (sm *storageMigrator) cleanup(dc *v1alpha1.DgraphCluster,
+	status *v1alpha1.StorageMigrationStatus, member dgraphk8s.AlphaMember) error {
+	ns := dc.GetNamespace()
+
+	jobs := []*batchv1.Job{
+		dgraphk8s.NewStorageMigrationCopyJob(dc, status.Member, status.Claims),
+		dgraphk8s.NewStorageMigrationRestoreJob(dc, status.Member, status.Claims),
+	}
+	for _, desired := range jobs {
+		job, err := sm.jobLister.Jobs(ns).Get(desired.GetName())
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		glog.Infof("storage-migrator: deleting storage migration job: %s", job.GetName())
+		if err := k8s.DeleteJob(sm.k8sClient, ns, job); err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	for _, claim := range migratedClaims(member, status.Claims) {
+		pvc, err := sm.pvcLister.PersistentVolumeClaims(ns).
+			Get(dgraphk8s.NewStorageMigrationClaim(claim).GetName())
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		glog.Infof("storage-migrator: deleting temporary volume claim: %s", pvc.GetName())
+		if err := k8s.DeletePersistentVolumeClaim(sm.k8sClient, ns, pvc); err != nil &&
+			!kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runJob creates the job if it does not exist yet and returns true once it succeeded. A
+// failed job is left for inspection, deleting it retries the copy.
+func (sm *storageMigrator) runJob(status *v1alpha1.StorageMigrationStatus,
+	desired *batchv1.Job) (bool, error) {
+	ns := desired.GetNamespace()
+
+	job, err := sm.jobLister.Jobs(ns).Get(desired.GetName())
+	if kerrors.IsNotFound(err) {
+		glog.Infof("storage-migrator: creating storage migration job: %s", desired.GetName())
+		err = k8s.CreateNewJob(sm.k8sClient, ns, desired)
+		if kerrors.IsAlreadyExists(err) {
+			err = nil
+		}
+		return false, err
+	}
+	if err != nil {
+		return false, err
+	}
+
+	finished, succeeded := k8s.IsJobFinished(job)
+	if finished && !succeeded {
+		status.Message = fmt.Sprintf("storage migration job %s failed, delete it to retry",
+			job.GetName())
+	}
+
+	return succeeded, nil
+}
+
+// claimsToMigrate returns the names of the existing claims of the alpha member whose storage
+// class differs from the one of their volume claim template.
+func (sm *storageMigrator) claimsToMigrate(member dgraphk8s.AlphaMember) ([]string, error) {
+	var claims []string
+	for _, claim := range dgraphk8s.NewAlphaPersistentVolumeClaims(member) {
+		pvc, err := sm.pvcLister.PersistentVolumeClaims(claim.GetNamespace()).
+			Get(claim.GetName())
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if storageClassName(pvc) != storageClassName(claim) {
+			claims = append(claims, claim.GetName())
+		}
+	}
+
+	return claims, nil
+}
+
+// alphaReady returns true if all the members of all the alpha stateful sets are ready.
+func (sm *storageMigrator) alphaReady(dc *v1alpha1.DgraphCluster) (bool, error) {
+	for _, desired := range dgraphk8s.NewAlphaStatefulSets(dc) {
+		ss, err := sm.statefulSetLister.StatefulSets(dc.GetNamespace()).Get(desired.GetName())
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		replicas := int32(1)
+		if ss.Spec.Replicas != nil {
+			replicas = *ss.Spec.Replicas
+		}
+		if ss.Status.ReadyReplicas < replicas {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// migratedClaims returns the claims of the alpha member with the provided names, as created
+// from the volume claim templates with the new storage class.
+func migratedClaims(member dgraphk8s.AlphaMember,
+	names []string) []*corev1.PersistentVolumeClaim {
+	var claims []*corev1.PersistentVolumeClaim
+	for _, claim := range dgraphk8s.NewAlphaPersistentVolumeClaims(member) {
+		for _, name := range names {
+			if claim.GetName() == name {
+				claims = append(claims, claim)
+			}
+		}
+	}
+
+	return claims
+}
+
+// findAlphaMember returns the alpha member of the provided DgraphCluster with the provided
+// pod name, false if there is none.
+func findAlphaMember(dc *v1alpha1.DgraphCluster, podName string) (dgraphk8s.AlphaMember, bool) {
+	for _, member := range dgraphk8s.AlphaMembers(dc) {
+		if alphaMemberPodName(member) == podName {
+			return member, true
+		}
+	}
+
+	return dgraphk8s.AlphaMember{}, false
+}
+
+// alphaMemberPodName returns the name of the pod of the alpha member.
+func alphaMemberPodName(member dgraphk8s.AlphaMember) string {
+	return fmt.Sprintf("%s%s%d", member.StatefulSet.GetName(), defaults.K8SDelimeter,
+		member.Ordinal)
+}
+
+// resetStorageMigration clears the member being migrated from the status, so that the
+// next one is picked on the following sync.
+func resetStorageMigration(status *v1alpha1.StorageMigrationStatus, msg string) {
+	status.Phase = ""
+	status.StatefulSet = ""
+	status.Member = ""
+	status.Claims = nil
+	status.Message = msg
+}
@@ -17,13 +17,18 @@
 package manager
 
 import (
+	"fmt"
+
 	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
 	"github.com/dgraph-io/dgraph-operator/pkg/k8s"
 	dgraphk8s "github.com/dgraph-io/dgraph-operator/pkg/k8s/dgraph"
+	"github.com/dgraph-io/dgraph-operator/pkg/utils"
 	"github.com/golang/glog"
 
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	klabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 	v1 "k8s.io/client-go/listers/apps/v1"
 	klisters "k8s.io/client-go/listers/core/v1"
@@ -58,16 +63,52 @@ func (rm *RatelManager) Sync(dc *v1alpha1.DgraphCluster) error {
 	glog.Info("syncing dgraph ratel components.")
 	if dc.Spec.Ratel == nil {
 		glog.Info("no configuration for ratel provided, skipping")
+		dc.Status.Ratel.Members = nil
 		return nil
 	}
 	if err := rm.syncRatelServiceWithDgraphCluster(dc); err != nil {
 		return err
 	}
 
-	return rm.syncRatelDeploymentWithDgraphCluster(dc)
+	if err := rm.syncRatelDeploymentWithDgraphCluster(dc); err != nil {
+		return err
+	}
+
+	return rm.syncRatelMembers(dc)
+}
+
+// syncRatelMembers records the status of the ratel members of the provided DgraphCluster,
+// keyed by pod name. Ratel members have no stable address of their own, the URL of each
+// member is the address of the ratel service.
+func (rm *RatelManager) syncRatelMembers(dc *v1alpha1.DgraphCluster) error {
+	ns := dc.GetNamespace()
+	deployment := dgraphk8s.NewRatelDeployment(dc)
+	pods, err := rm.podLister.Pods(ns).
+		List(klabels.SelectorFromSet(deployment.Spec.Selector.MatchLabels))
+	if err != nil {
+		return err
+	}
+
+	url := utils.ServiceAddress(dgraphk8s.NewRatelService(dc).GetName(), ns,
+		dgraphk8s.ClusterDomain(dc), defaults.RatelPort)
+	members := make(map[string]v1alpha1.DgraphComponent, len(pods))
+	for _, pod := range pods {
+		members[pod.GetName()] = v1alpha1.DgraphComponent{
+			Name:         pod.GetName(),
+			ComponentURL: url,
+			Healthy:      k8s.IsPodReady(pod),
+		}
+	}
+	dc.Status.Ratel.Members = members
+
+	return nil
 }
 
 func (rm *RatelManager) syncRatelServiceWithDgraphCluster(dc *v1alpha1.DgraphCluster) error {
+	if err := dc.RatelClusterSpec().Service.Validate(false); err != nil {
+		return fmt.Errorf("ratel: %s", err)
+	}
+
 	ns := dc.GetNamespace()
 	svc := dgraphk8s.NewRatelService(dc)
 	serviceName := svc.GetName()
@@ -85,11 +126,13 @@ func (rm *RatelManager) syncRatelServiceWithDgraphCluster(dc *v1alpha1.DgraphClu
 
 	// If the old service and new service spec is same don't change anything.
 	// else update the service spec as mentioned in the updated specification.
-	svc.Spec.ClusterIP = oldSVC.Spec.ClusterIP
+	preserveServiceAllocations(svc, oldSVC)
 
-	if !apiequality.Semantic.DeepDerivative(svc.Spec, oldSVC.Spec) {
+	if !serviceSpecUpToDate(svc, oldSVC) ||
+		!metadataUpToDate(svc.ObjectMeta, oldSVC.ObjectMeta) {
 		updateSVC := *oldSVC
 		updateSVC.Spec = svc.Spec
+		mergeMetadata(&updateSVC.ObjectMeta, svc.ObjectMeta)
 		glog.Info("updating service for dgraph ratel")
 		if _, err = k8s.UpdateService(rm.k8sClient, ns, &updateSVC); err != nil {
 			return err
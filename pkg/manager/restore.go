@@ -0,0 +1,162 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	listers "github.com/dgraph-io/dgraph-operator/pkg/client/listers/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/k8s"
+	dgraphk8s "github.com/dgraph-io/dgraph-operator/pkg/k8s/dgraph"
+	"github.com/golang/glog"
+
+	appsv1 "k8s.io/api/apps/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+	v1 "k8s.io/client-go/listers/apps/v1"
+	klisters "k8s.io/client-go/listers/core/v1"
+)
+
+// RestoreManager restores the data of the alpha and zero members of a new dgraph cluster
+// from the volume snapshots of a DgraphSnapshot. The persistent volume claims of all the
+// members are created from the volume snapshots before the stateful sets, which then
+// use them instead of creating empty ones from their volume claim templates.
+type RestoreManager struct {
+	k8sClient kubernetes.Interface
+
+	pvcLister            klisters.PersistentVolumeClaimLister
+	statefulSetLister    v1.StatefulSetLister
+	dgraphSnapshotLister listers.DgraphSnapshotLister
+}
+
+// NewRestoreManager creates a new manager for restoring dgraph clusters from snapshots.
+func NewRestoreManager(
+	k8sClient kubernetes.Interface,
+	pvcLister klisters.PersistentVolumeClaimLister,
+	statefulSetLister v1.StatefulSetLister,
+	dgraphSnapshotLister listers.DgraphSnapshotLister,
+) *RestoreManager {
+	return &RestoreManager{
+		k8sClient,
+		pvcLister,
+		statefulSetLister,
+		dgraphSnapshotLister,
+	}
+}
+
+// Sync creates the persistent volume claims of the members of the provided DgraphCluster
+// from the volume snapshots it's restored from, as long as none of its stateful sets
+// exist. The cluster isn't created until the snapshot is completed.
+func (rm *RestoreManager) Sync(dc *v1alpha1.DgraphCluster) error {
+	if dc.Spec.RestoreFrom == nil {
+		return nil
+	}
+
+	ns := dc.GetNamespace()
+	statefulSets := append([]*appsv1.StatefulSet{dgraphk8s.NewZeroStatefulSet(dc)},
+		dgraphk8s.NewAlphaStatefulSets(dc)...)
+	for _, ss := range statefulSets {
+		_, err := rm.statefulSetLister.StatefulSets(ns).Get(ss.GetName())
+		if err == nil {
+			// The cluster has already been created.
+			return nil
+		}
+		if !kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	snapshotName := dc.Spec.RestoreFrom.SnapshotName
+	snapshot, err := rm.dgraphSnapshotLister.DgraphSnapshots(ns).Get(snapshotName)
+	if err != nil {
+		return fmt.Errorf("error getting DgraphSnapshot %s to restore from: %s",
+			snapshotName, err)
+	}
+	if snapshot.Status.Phase != v1alpha1.SnapshotPhaseCompleted {
+		return fmt.Errorf("waiting for DgraphSnapshot %s to be completed, it's %q",
+			snapshotName, snapshot.Status.Phase)
+	}
+	if err := validateRestoreSource(dc, snapshot); err != nil {
+		return err
+	}
+
+	for _, claim := range dgraphk8s.MemberVolumeClaims(dc) {
+		volume := snapshotVolume(snapshot, claim.MemberVolume)
+		if volume == nil {
+			return fmt.Errorf("DgraphSnapshot %s has no volume snapshot for %s member %d "+
+				"of group %d, volume %q", snapshotName, claim.Component, claim.Ordinal,
+				claim.Group, claim.Volume)
+		}
+
+		_, err := rm.pvcLister.PersistentVolumeClaims(ns).Get(claim.Claim.GetName())
+		if err == nil {
+			continue
+		}
+		if !kerrors.IsNotFound(err) {
+			return err
+		}
+
+		glog.Infof("restore-manager: creating persistent volume claim %s from volume "+
+			"snapshot %s", claim.Claim.GetName(), volume.VolumeSnapshot)
+		pvc := dgraphk8s.NewRestoredVolumeClaim(claim, volume.VolumeSnapshot)
+		if err := k8s.CreateNewPersistentVolumeClaim(rm.k8sClient, ns, pvc); err != nil &&
+			!kerrors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateRestoreSource returns an error if the provided DgraphCluster can't be restored
+// from the DgraphSnapshot. The raft state of the members restored from the snapshot holds
+// the addresses of the members of the source cluster, so the restored cluster must have
+// the same addresses, and the source cluster must be gone so that they don't both use them.
+func validateRestoreSource(dc *v1alpha1.DgraphCluster, snapshot *v1alpha1.DgraphSnapshot) error {
+	source := snapshot.Status.Source
+	if source == nil {
+		return fmt.Errorf("DgraphSnapshot %s doesn't record the cluster it was taken of",
+			snapshot.GetName())
+	}
+	if dc.GetUID() == source.UID {
+		return fmt.Errorf("DgraphSnapshot %s was taken of DgraphCluster %s, which must be "+
+			"deleted and created again to be restored", snapshot.GetName(), dc.GetName())
+	}
+	if dc.GetName() != source.ClusterName || dc.Spec.GetClusterID() != source.ClusterID ||
+		dgraphk8s.ClusterDomain(dc) != source.ClusterDomain {
+		return fmt.Errorf("DgraphSnapshot %s can only be restored into a DgraphCluster named "+
+			"%s with cluster ID %s and cluster domain %s, as its members keep their raft "+
+			"addresses", snapshot.GetName(), source.ClusterName, source.ClusterID,
+			source.ClusterDomain)
+	}
+
+	return nil
+}
+
+// snapshotVolume returns the volume snapshot of the provided member volume taken by the
+// DgraphSnapshot, nil if it has none.
+func snapshotVolume(snapshot *v1alpha1.DgraphSnapshot,
+	member v1alpha1.MemberVolume) *v1alpha1.SnapshotVolume {
+	for i := range snapshot.Status.Volumes {
+		if snapshot.Status.Volumes[i].MemberVolume == member {
+			return &snapshot.Status.Volumes[i]
+		}
+	}
+
+	return nil
+}
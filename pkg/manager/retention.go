@@ -0,0 +1,212 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+	"github.com/dgraph-io/dgraph-operator/pkg/k8s"
+	dgraphk8s "github.com/dgraph-io/dgraph-operator/pkg/k8s/dgraph"
+	"github.com/dgraph-io/dgraph-operator/pkg/utils"
+	"github.com/golang/glog"
+
+	appsv1 "k8s.io/api/apps/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	v1 "k8s.io/client-go/listers/apps/v1"
+	klisters "k8s.io/client-go/listers/core/v1"
+)
+
+// PVCRetentionManager applies the retention policy of the persistent volume claims of the
+// alpha and zero members of a dgraph cluster. Kubernetes never deletes the claims created
+// from the volume claim templates of stateful sets, and they aren't owned by the
+// DgraphCluster, so they are left behind when scaling down and deleting the cluster unless
+// the policy asks for them to be deleted.
+//
+// Claims of the alpha groups removed by the autoscaler are always deleted by it, as the
+// removed members can't join the cluster again.
+type PVCRetentionManager struct {
+	k8sClient kubernetes.Interface
+
+	podLister         klisters.PodLister
+	pvcLister         klisters.PersistentVolumeClaimLister
+	statefulSetLister v1.StatefulSetLister
+}
+
+// NewPVCRetentionManager creates a new manager for the retention of the persistent volume
+// claims of dgraph members.
+func NewPVCRetentionManager(
+	k8sClient kubernetes.Interface,
+	podLister klisters.PodLister,
+	pvcLister klisters.PersistentVolumeClaimLister,
+	statefulSetLister v1.StatefulSetLister,
+) *PVCRetentionManager {
+	return &PVCRetentionManager{
+		k8sClient,
+		podLister,
+		pvcLister,
+		statefulSetLister,
+	}
+}
+
+// Sync deletes the claims of the alpha and zero members removed by scaling down if the
+// retention policy asks for it. A claim is deleted once the pod of its member is gone.
+func (rm *PVCRetentionManager) Sync(dc *v1alpha1.DgraphCluster) error {
+	// Members of a hibernated cluster are scaled down to zero but keep their claims.
+	if !dc.Spec.PVCRetentionPolicy.DeleteWhenScaled() || dc.Status.Hibernation != nil {
+		return nil
+	}
+
+	statefulSets := []*appsv1.StatefulSet{dgraphk8s.NewZeroStatefulSet(dc)}
+	// Claims of the alpha members are created ahead of the members while bootstrapping,
+	// for the bulk loader output to be copied to them.
+	if !dc.AlphaBootstrapPending() {
+		statefulSets = append(statefulSets, dgraphk8s.NewAlphaStatefulSets(dc)...)
+	}
+
+	for _, ss := range statefulSets {
+		if err := rm.deleteScaledDownClaims(dc.GetNamespace(), ss); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteScaledDownClaims deletes the claims of the members of the provided desired stateful
+// set whose ordinal is beyond both its current and desired number of replicas, as the
+// existing stateful set may not have been scaled back up yet after hibernation.
+func (rm *PVCRetentionManager) deleteScaledDownClaims(ns string,
+	desired *appsv1.StatefulSet) error {
+	name := desired.GetName()
+	ss, err := rm.statefulSetLister.StatefulSets(ns).Get(name)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	replicas := int32(1)
+	if ss.Spec.Replicas != nil {
+		replicas = *ss.Spec.Replicas
+	}
+	if *desired.Spec.Replicas > replicas {
+		replicas = *desired.Spec.Replicas
+	}
+	selector, err := metav1.LabelSelectorAsSelector(ss.Spec.Selector)
+	if err != nil {
+		return err
+	}
+	claims, err := rm.pvcLister.PersistentVolumeClaims(ns).List(selector)
+	if err != nil {
+		return err
+	}
+
+	for _, claim := range claims {
+		ordinal, ok := claimOrdinal(ss, claim.GetName())
+		if !ok || ordinal < replicas || claim.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		podName := fmt.Sprintf("%s%s%d", name, defaults.K8SDelimeter, ordinal)
+		_, err := rm.podLister.Pods(ns).Get(podName)
+		if err == nil {
+			glog.Infof("pvc-retention-manager: waiting for pod %s to be deleted before "+
+				"deleting persistent volume claim %s", podName, claim.GetName())
+			continue
+		}
+		if !kerrors.IsNotFound(err) {
+			return err
+		}
+
+		glog.Infof("pvc-retention-manager: deleting persistent volume claim %s of removed "+
+			"member %s", claim.GetName(), podName)
+		if err := k8s.DeletePersistentVolumeClaim(rm.k8sClient, ns, claim); err != nil &&
+			!kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// claimOrdinal returns the ordinal of the member of the provided stateful set a persistent
+// volume claim was created for, false if the claim wasn't created from one of the volume
+// claim templates of the stateful set.
+func claimOrdinal(ss *appsv1.StatefulSet, claimName string) (int32, bool) {
+	for _, template := range ss.Spec.VolumeClaimTemplates {
+		prefix := fmt.Sprintf("%s%s%s%s", template.GetName(), defaults.K8SDelimeter,
+			ss.GetName(), defaults.K8SDelimeter)
+		if !strings.HasPrefix(claimName, prefix) {
+			continue
+		}
+
+		ordinal, err := strconv.ParseInt(strings.TrimPrefix(claimName, prefix), 10, 32)
+		if err != nil || ordinal < 0 {
+			continue
+		}
+		return int32(ordinal), true
+	}
+
+	return 0, false
+}
+
+// Finalize deletes the claims of all the alpha and zero members of the provided DgraphCluster
+// being deleted, including the ones of removed alpha groups, if the retention policy asks
+// for it.
+func (rm *PVCRetentionManager) Finalize(dc *v1alpha1.DgraphCluster) error {
+	if !dc.Spec.PVCRetentionPolicy.DeleteWhenDeleted() {
+		return nil
+	}
+
+	ns := dc.GetNamespace()
+	clusterID := dc.Spec.GetClusterID()
+	selectors := []klabels.Selector{
+		klabels.SelectorFromSet(dgraphk8s.DefaultAlphaLabels(
+			utils.DgraphAlphaMemberName(clusterID, dc.GetName()))),
+		klabels.SelectorFromSet(dgraphk8s.DefaultZeroLabels(
+			utils.DgraphZeroMemberName(clusterID, dc.GetName()))),
+	}
+
+	for _, selector := range selectors {
+		claims, err := rm.pvcLister.PersistentVolumeClaims(ns).List(selector)
+		if err != nil {
+			return err
+		}
+
+		for _, claim := range claims {
+			if claim.GetDeletionTimestamp() != nil {
+				continue
+			}
+			glog.Infof("pvc-retention-manager: deleting persistent volume claim %s of "+
+				"deleted cluster %s", claim.GetName(), dc.GetName())
+			if err := k8s.DeletePersistentVolumeClaim(rm.k8sClient, ns, claim); err != nil &&
+				!kerrors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+)
+
+// preserveServiceAllocations copies the values kubernetes allocated for the existing service
+// to the provided service, so that updating the service doesn't change them. These are the
+// cluster IP, the node ports which are not fixed in the specification and the health check
+// node port.
+func preserveServiceAllocations(svc, oldSVC *corev1.Service) {
+	svc.Spec.ClusterIP = oldSVC.Spec.ClusterIP
+
+	// Node ports are released when the service is changed to ClusterIP.
+	if svc.Spec.Type == corev1.ServiceTypeClusterIP {
+		return
+	}
+
+	nodePorts := make(map[string]int32, len(oldSVC.Spec.Ports))
+	for _, port := range oldSVC.Spec.Ports {
+		nodePorts[port.Name] = port.NodePort
+	}
+	for i := range svc.Spec.Ports {
+		if svc.Spec.Ports[i].NodePort == 0 {
+			svc.Spec.Ports[i].NodePort = nodePorts[svc.Spec.Ports[i].Name]
+		}
+	}
+
+	if svc.Spec.ExternalTrafficPolicy == corev1.ServiceExternalTrafficPolicyTypeLocal &&
+		oldSVC.Spec.ExternalTrafficPolicy == corev1.ServiceExternalTrafficPolicyTypeLocal {
+		svc.Spec.HealthCheckNodePort = oldSVC.Spec.HealthCheckNodePort
+	}
+}
+
+// serviceSpecUpToDate returns true if the existing service matches the specification of the
+// provided service. Values defaulted by kubernetes are ignored, except for the load balancer
+// options and the session affinity configuration which can be removed from the
+// specification.
+func serviceSpecUpToDate(svc, oldSVC *corev1.Service) bool {
+	if !apiequality.Semantic.DeepDerivative(svc.Spec, oldSVC.Spec) {
+		return false
+	}
+	if svc.Spec.LoadBalancerIP != oldSVC.Spec.LoadBalancerIP ||
+		!apiequality.Semantic.DeepEqual(svc.Spec.LoadBalancerSourceRanges,
+			oldSVC.Spec.LoadBalancerSourceRanges) {
+		return false
+	}
+
+	// Kubernetes defaults the session affinity configuration of ClientIP services.
+	if svc.Spec.SessionAffinity != corev1.ServiceAffinityClientIP &&
+		oldSVC.Spec.SessionAffinityConfig != nil {
+		return false
+	}
+
+	return true
+}
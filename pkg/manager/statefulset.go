@@ -0,0 +1,44 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// checkVolumeClaimTemplates returns an error if the desired stateful set of a dgraph
+// component doesn't have the same volume claim templates as the existing one, for example
+// when switching between persistent and ephemeral storage or adding a volume. Volume claim
+// templates can't be updated, so the stateful set has to be recreated. Growing storage
+// requests are applied by the volume expander, other changes to the templates themselves
+// are ignored and only apply to stateful sets created afterwards.
+func checkVolumeClaimTemplates(ss, oldSS *appsv1.StatefulSet) error {
+	templates := ss.Spec.VolumeClaimTemplates
+	oldTemplates := oldSS.Spec.VolumeClaimTemplates
+	changed := len(templates) != len(oldTemplates)
+	for i := 0; !changed && i < len(templates); i++ {
+		changed = templates[i].Name != oldTemplates[i].Name
+	}
+	if changed {
+		return fmt.Errorf("volumes of stateful set %s can't be changed, delete the "+
+			"stateful set to recreate it", ss.GetName())
+	}
+
+	return nil
+}
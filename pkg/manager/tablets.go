@@ -0,0 +1,209 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/dgraph"
+	dgraphk8s "github.com/dgraph-io/dgraph-operator/pkg/k8s/dgraph"
+	"github.com/golang/glog"
+
+	v1 "k8s.io/client-go/listers/apps/v1"
+)
+
+// TabletManager manages the placement of predicate tablets across the alpha groups of a
+// dgraph cluster. It reports the tablets known to zero in the DgraphCluster status and
+// moves the tablets of pinned predicates to their group, within the maintenance window
+// if one is specified.
+type TabletManager struct {
+	statefulSetLister v1.StatefulSetLister
+}
+
+// NewTabletManager creates a new manager for the placement of predicate tablets.
+func NewTabletManager(statefulSetLister v1.StatefulSetLister) *TabletManager {
+	return &TabletManager{
+		statefulSetLister,
+	}
+}
+
+// Sync refreshes the tablets status of the provided DgraphCluster and moves at most one
+// pinned tablet to its group.
+func (tm *TabletManager) Sync(dc *v1alpha1.DgraphCluster) error {
+	if dc.Spec.Tablets == nil {
+		dc.Status.Tablets = nil
+		return nil
+	}
+
+	ready, err := AlphaReady(tm.statefulSetLister, dc)
+	if err != nil {
+		return err
+	}
+	if !ready {
+		glog.Infof("tablet-manager: alpha of cluster %s is not ready, skipping", dc.GetName())
+		return nil
+	}
+
+	state, err := dgraph.NewZeroClient(dgraphk8s.ZeroHTTPAddress(dc)).State()
+	if err != nil {
+		return fmt.Errorf("error getting state of zero: %s", err)
+	}
+
+	status := &v1alpha1.TabletsStatus{
+		Tablets: tabletStatuses(state),
+	}
+	dc.Status.Tablets = status
+
+	return tm.syncPlacement(dc, state, status)
+}
+
+// syncPlacement moves the first pinned tablet which is not served by its group and records
+// the pinned tablets yet to be moved in the status.
+func (tm *TabletManager) syncPlacement(dc *v1alpha1.DgraphCluster, state *dgraph.ZeroState,
+	status *v1alpha1.TabletsStatus) error {
+	var (
+		moves    []v1alpha1.TabletPlacement
+		messages []string
+	)
+	for _, placement := range dc.Spec.Tablets.Placement {
+		tablet := state.Tablet(placement.Predicate)
+		if tablet != nil && tablet.GroupID == placement.Group {
+			continue
+		}
+
+		status.PendingMoves = append(status.PendingMoves, placement.Predicate)
+		switch {
+		case tablet == nil:
+			// Zero creates the tablet when the predicate is first written to, it is
+			// moved on a later sync.
+		case !state.HasGroup(placement.Group):
+			messages = append(messages, fmt.Sprintf("group %d of predicate %s does not exist",
+				placement.Group, placement.Predicate))
+		case placement.Group == drainingGroup(dc):
+			// The autoscaler moves the tablets of the group it drains away, they would be
+			// moved back and forth.
+			messages = append(messages, fmt.Sprintf("group %d of predicate %s is being "+
+				"drained by the autoscaler", placement.Group, placement.Predicate))
+		default:
+			moves = append(moves, placement)
+		}
+	}
+	status.Message = strings.Join(messages, "; ")
+	if len(moves) == 0 {
+		return nil
+	}
+
+	if open, err := maintenanceWindowOpen(dc); err != nil || !open {
+		status.Message = "waiting for the maintenance window to move tablets"
+		if err != nil {
+			status.Message = err.Error()
+		}
+		return nil
+	}
+
+	zero, err := state.LeaderClient()
+	if err != nil {
+		return err
+	}
+
+	// Tablets are moved one at a time, the remaining ones are moved on the following syncs.
+	move := moves[0]
+	glog.Infof("tablet-manager: moving tablet of predicate %s of cluster %s to group %d",
+		move.Predicate, dc.GetName(), move.Group)
+	err = zero.MoveTablet(move.Predicate, move.Group)
+	if dgraph.IsTimeout(err) {
+		// Zero keeps moving the tablet after the request times out.
+		status.Message = fmt.Sprintf("moving tablet of predicate %s to group %d",
+			move.Predicate, move.Group)
+		return nil
+	}
+	if err != nil {
+		status.Message = fmt.Sprintf("error moving tablet of predicate %s to group %d: %s",
+			move.Predicate, move.Group, err)
+		return err
+	}
+
+	status.Message = fmt.Sprintf("moved tablet of predicate %s to group %d",
+		move.Predicate, move.Group)
+	for i, predicate := range status.PendingMoves {
+		if predicate == move.Predicate {
+			status.PendingMoves = append(status.PendingMoves[:i], status.PendingMoves[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+// maintenanceWindowOpen returns true if tablets of the provided DgraphCluster may be
+// moved now, that is if it has no tablets maintenance window or the window is open.
+func maintenanceWindowOpen(dc *v1alpha1.DgraphCluster) (bool, error) {
+	if dc.Spec.Tablets == nil || dc.Spec.Tablets.MaintenanceWindow == nil {
+		return true, nil
+	}
+
+	return dc.Spec.Tablets.MaintenanceWindow.Contains(time.Now())
+}
+
+// pinnedGroup returns the group the tablet of the provided predicate is pinned to by the
+// tablets placement of the DgraphCluster, or 0 if it isn't pinned.
+func pinnedGroup(dc *v1alpha1.DgraphCluster, predicate string) uint32 {
+	if dc.Spec.Tablets == nil {
+		return 0
+	}
+	for _, placement := range dc.Spec.Tablets.Placement {
+		if placement.Predicate == predicate {
+			return placement.Group
+		}
+	}
+
+	return 0
+}
+
+// drainingGroup returns the alpha group of the provided DgraphCluster being drained or
+// removed by the autoscaler, or 0 if none is.
+func drainingGroup(dc *v1alpha1.DgraphCluster) uint32 {
+	if status := dc.Status.AlphaCluster.Autoscaling; status != nil {
+		return status.DrainingGroup
+	}
+
+	return 0
+}
+
+// tabletStatuses returns the status of all the tablets in the zero state ordered
+// by predicate.
+func tabletStatuses(state *dgraph.ZeroState) []v1alpha1.TabletStatus {
+	var tablets []v1alpha1.TabletStatus
+	for _, group := range state.Groups {
+		for _, tablet := range group.Tablets {
+			tablets = append(tablets, v1alpha1.TabletStatus{
+				Predicate:   tablet.Predicate,
+				Group:       tablet.GroupID,
+				OnDiskBytes: tablet.Size(),
+			})
+		}
+	}
+	sort.Slice(tablets, func(i, j int) bool {
+		return tablets[i].Predicate < tablets[j].Predicate
+	})
+
+	return tablets
+}
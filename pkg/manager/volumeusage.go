@@ -0,0 +1,254 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manager
+
+import (
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
+	"github.com/dgraph-io/dgraph-operator/pkg/k8s"
+	dgraphk8s "github.com/dgraph-io/dgraph-operator/pkg/k8s/dgraph"
+	"github.com/dgraph-io/dgraph-operator/pkg/utils"
+	"github.com/golang/glog"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	klisters "k8s.io/client-go/listers/core/v1"
+	storagelisters "k8s.io/client-go/listers/storage/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// Reasons of the events recorded for the automatic expansion of volumes.
+const (
+	eventVolumeAutoExpanding   = "VolumeAutoExpanding"
+	eventVolumeAutoExpandLimit = "VolumeAutoExpandLimit"
+	eventVolumeAutoExpandError = "VolumeAutoExpandFailed"
+)
+
+// VolumeUsageManager collects the usage of the persistent volumes of the alpha and zero
+// members of a dgraph cluster from the stats summary of the kubelets of their nodes, and
+// publishes it in the status of the cluster.
+//
+// Claims of the components with an auto expansion policy are expanded when the usage of
+// their volume reaches the threshold of the policy, an event is recorded on the
+// DgraphCluster for each expansion.
+type VolumeUsageManager struct {
+	k8sClient kubernetes.Interface
+	recorder  record.EventRecorder
+
+	podLister klisters.PodLister
+	pvcLister klisters.PersistentVolumeClaimLister
+
+	volumeExpander *volumeExpander
+}
+
+// NewVolumeUsageManager creates a new manager for the usage of the volumes of dgraph
+// members.
+func NewVolumeUsageManager(
+	k8sClient kubernetes.Interface,
+	recorder record.EventRecorder,
+	podLister klisters.PodLister,
+	pvcLister klisters.PersistentVolumeClaimLister,
+	storageClassLister storagelisters.StorageClassLister,
+) *VolumeUsageManager {
+	return &VolumeUsageManager{
+		k8sClient,
+		recorder,
+		podLister,
+		pvcLister,
+		newVolumeExpander(k8sClient, pvcLister, storageClassLister),
+	}
+}
+
+// Sync collects the usage of the volumes of the members of the provided DgraphCluster
+// once every VolumeUsageInterval and expands the claims of the volumes above the threshold
+// of the auto expansion policy of their component.
+func (vm *VolumeUsageManager) Sync(dc *v1alpha1.DgraphCluster) error {
+	zeroPersistent := !dc.Spec.ZeroCluster.Storage.Ephemeral()
+	alphaPersistent := !dc.Spec.AlphaCluster.Storage.Ephemeral()
+	if !zeroPersistent && !alphaPersistent {
+		return nil
+	}
+
+	lastUpdate := dc.Status.VolumeUsageTime
+	if lastUpdate != nil && time.Since(lastUpdate.Time) < defaults.VolumeUsageInterval {
+		return nil
+	}
+
+	// Stats summaries of the nodes, shared by the members running on the same node.
+	summaries := make(map[string]*k8s.StatsSummary)
+
+	var zeroUsage, alphaUsage []v1alpha1.VolumeUsageStatus
+	var err error
+	clusterID := dc.Spec.GetClusterID()
+	if zeroPersistent {
+		zeroUsage, err = vm.collect(dc.GetNamespace(), dgraphk8s.DefaultZeroLabels(
+			utils.DgraphZeroMemberName(clusterID, dc.GetName())), summaries)
+		if err != nil {
+			return err
+		}
+	}
+	if alphaPersistent {
+		alphaUsage, err = vm.collect(dc.GetNamespace(), dgraphk8s.DefaultAlphaLabels(
+			utils.DgraphAlphaMemberName(clusterID, dc.GetName())), summaries)
+		if err != nil {
+			return err
+		}
+	}
+
+	now := metav1.Now()
+	dc.Status.VolumeUsageTime = &now
+	dc.Status.ZeroCluster.VolumeUsage = zeroUsage
+	dc.Status.AlphaCluster.VolumeUsage = alphaUsage
+
+	if err := vm.autoExpand(dc, dc.Spec.ZeroCluster.AutoExpand, zeroUsage); err != nil {
+		return err
+	}
+
+	return vm.autoExpand(dc, dc.Spec.AlphaCluster.AutoExpand, alphaUsage)
+}
+
+// collect returns the usage of the persistent volumes of the pods with the provided labels,
+// sorted by claim. Members whose node stats summary can't be fetched are left out.
+func (vm *VolumeUsageManager) collect(ns string, labels map[string]string,
+	summaries map[string]*k8s.StatsSummary) ([]v1alpha1.VolumeUsageStatus, error) {
+	pods, err := vm.podLister.Pods(ns).List(klabels.SelectorFromSet(labels))
+	if err != nil {
+		return nil, err
+	}
+
+	var usage []v1alpha1.VolumeUsageStatus
+	for _, pod := range pods {
+		nodeName := pod.Spec.NodeName
+		if nodeName == "" {
+			continue
+		}
+
+		summary, ok := summaries[nodeName]
+		if !ok {
+			summary, err = k8s.GetNodeStatsSummary(vm.k8sClient, nodeName)
+			if err != nil {
+				glog.Warningf("volume-usage-manager: error getting stats summary of node "+
+					"%s: %s", nodeName, err)
+			}
+			summaries[nodeName] = summary
+		}
+		if summary == nil {
+			continue
+		}
+
+		for _, podStats := range summary.Pods {
+			if podStats.PodRef.Name != pod.Name || podStats.PodRef.Namespace != ns {
+				continue
+			}
+			for _, volume := range podStats.VolumeStats {
+				if volume.PVCRef == nil || volume.UsedBytes == nil ||
+					volume.CapacityBytes == nil || *volume.CapacityBytes == 0 {
+					continue
+				}
+
+				available := int64(0)
+				if volume.AvailableBytes != nil {
+					available = int64(*volume.AvailableBytes)
+				}
+				usage = append(usage, v1alpha1.VolumeUsageStatus{
+					Pod:            pod.Name,
+					Claim:          volume.PVCRef.Name,
+					UsedBytes:      int64(*volume.UsedBytes),
+					AvailableBytes: available,
+					CapacityBytes:  int64(*volume.CapacityBytes),
+					UsedPercent:    int32(*volume.UsedBytes * 100 / *volume.CapacityBytes),
+				})
+			}
+		}
+	}
+
+	sort.Slice(usage, func(i, j int) bool {
+		return usage[i].Claim < usage[j].Claim
+	})
+
+	return usage, nil
+}
+
+// autoExpand increases the storage request of the claims whose usage is above the
+// threshold of the provided auto expansion policy by its increment, up to its maximum
+// size. Claims still being expanded are left alone until their capacity grows.
+func (vm *VolumeUsageManager) autoExpand(dc *v1alpha1.DgraphCluster,
+	policy *v1alpha1.VolumeAutoExpandSpec, usage []v1alpha1.VolumeUsageStatus) error {
+	if policy == nil {
+		return nil
+	}
+
+	threshold := policy.GetThresholdPercent()
+	for _, volume := range usage {
+		if volume.UsedPercent < threshold {
+			continue
+		}
+
+		pvc, err := vm.pvcLister.PersistentVolumeClaims(dc.GetNamespace()).Get(volume.Claim)
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		current := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		capacity := pvc.Status.Capacity[corev1.ResourceStorage]
+		if capacity.Cmp(current) < 0 {
+			continue
+		}
+		if current.Cmp(policy.MaxSize) >= 0 {
+			vm.recorder.Eventf(dc, corev1.EventTypeWarning, eventVolumeAutoExpandLimit,
+				"Volume of persistent volume claim %s is %d%% full and its request %s "+
+					"reached the maximum size", pvc.Name, volume.UsedPercent, current.String())
+			continue
+		}
+
+		requested := current.DeepCopy()
+		requested.Add(policy.Increment)
+		if requested.Cmp(policy.MaxSize) > 0 {
+			requested = policy.MaxSize.DeepCopy()
+		}
+
+		glog.Infof("volume-usage-manager: volume of persistent volume claim %s is %d%% "+
+			"full, expanding it to %s", pvc.Name, volume.UsedPercent, requested.String())
+		status, err := vm.volumeExpander.expandClaim(pvc, requested)
+		if err != nil {
+			vm.recorder.Eventf(dc, corev1.EventTypeWarning, eventVolumeAutoExpandError,
+				"Error expanding persistent volume claim %s to %s: %s", pvc.Name,
+				requested.String(), err)
+			return err
+		}
+		if status.Phase == v1alpha1.VolumeExpansionPhaseUnsupported {
+			vm.recorder.Eventf(dc, corev1.EventTypeWarning, eventVolumeAutoExpandError,
+				"Persistent volume claim %s can't be expanded: %s", pvc.Name, status.Message)
+			continue
+		}
+
+		vm.recorder.Eventf(dc, corev1.EventTypeNormal, eventVolumeAutoExpanding,
+			"Expanding persistent volume claim %s from %s to %s, its volume is %d%% full",
+			pvc.Name, current.String(), requested.String(), volume.UsedPercent)
+	}
+
+	return nil
+}
@@ -17,7 +17,10 @@
 package manager
 
 import (
+	"fmt"
+
 	"github.com/dgraph-io/dgraph-operator/pkg/apis/dgraph.io/v1alpha1"
+	"github.com/dgraph-io/dgraph-operator/pkg/defaults"
 	"github.com/dgraph-io/dgraph-operator/pkg/k8s"
 	dgraphk8s "github.com/dgraph-io/dgraph-operator/pkg/k8s/dgraph"
 	"github.com/dgraph-io/dgraph-operator/pkg/utils"
@@ -28,6 +31,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	v1 "k8s.io/client-go/listers/apps/v1"
 	klisters "k8s.io/client-go/listers/core/v1"
+	storagelisters "k8s.io/client-go/listers/storage/v1"
 )
 
 // ZeroManager manages Zero members in a dgraph cluster. It's main function is to sync
@@ -49,6 +53,12 @@ type ZeroManager struct {
 	podLister         klisters.PodLister
 	svcLister         klisters.ServiceLister
 	statefulSetLister v1.StatefulSetLister
+	// 4. ConfigMap: Config map holding the config file of dgraph zero, if any.
+	configMapLister klisters.ConfigMapLister
+
+	// volumeExpander expands the persistent volume claims of zero members when their
+	// storage requests grow.
+	volumeExpander *volumeExpander
 }
 
 // NewZeroManager creates a new manager for dgraph zero components
@@ -58,12 +68,17 @@ func NewZeroManager(
 	podLister klisters.PodLister,
 	svcLister klisters.ServiceLister,
 	statefulSetLister v1.StatefulSetLister,
+	configMapLister klisters.ConfigMapLister,
+	pvcLister klisters.PersistentVolumeClaimLister,
+	storageClassLister storagelisters.StorageClassLister,
 ) *ZeroManager {
 	return &ZeroManager{
 		k8sClient,
 		podLister,
 		svcLister,
 		statefulSetLister,
+		configMapLister,
+		newVolumeExpander(k8sClient, pvcLister, storageClassLister),
 	}
 }
 
@@ -76,7 +91,30 @@ func (zm *ZeroManager) Sync(dc *v1alpha1.DgraphCluster) error {
 		return err
 	}
 
-	return zm.syncZeroStatefulSetWithDgraphCluster(dc)
+	zeroName := utils.DgraphZeroMemberName(dc.Spec.GetClusterID(), dc.GetName())
+	if err := syncConfigMap(zm.k8sClient, zm.configMapLister, dc.GetNamespace(),
+		utils.DgraphConfigName(zeroName), dgraphk8s.NewZeroConfigMap(dc)); err != nil {
+		return err
+	}
+
+	if err := zm.syncZeroStatefulSetWithDgraphCluster(dc); err != nil {
+		return err
+	}
+
+	return zm.syncZeroMembers(dc)
+}
+
+// syncZeroMembers records the status of the members of the zero stateful set of the
+// provided DgraphCluster, with the address of their gRPC endpoint.
+func (zm *ZeroManager) syncZeroMembers(dc *v1alpha1.DgraphCluster) error {
+	members := make(map[string]v1alpha1.DgraphComponent)
+	if err := addStatefulSetMembers(zm.podLister, dc, dgraphk8s.NewZeroStatefulSet(dc),
+		defaults.ZeroGRPCPort, members); err != nil {
+		return err
+	}
+	dc.Status.ZeroCluster.Members = members
+
+	return nil
 }
 
 // syncZeroServiceWithDgraphCluster syncs the dgraph zero service with the DgraphCluster
@@ -90,6 +128,10 @@ func (zm *ZeroManager) Sync(dc *v1alpha1.DgraphCluster) error {
 // 1. Service(ClusterIP, NodePort or LoadBalancer)
 // 2. Headless Service - ClusterIP with ClusterIP None
 func (zm *ZeroManager) syncZeroServiceWithDgraphCluster(dc *v1alpha1.DgraphCluster) error {
+	if err := dc.ZeroClusterSpec().Service.Validate(true); err != nil {
+		return fmt.Errorf("zero: %s", err)
+	}
+
 	ns := dc.GetNamespace()
 
 	// create a new service type for zero using the dgraphcluster configuration
@@ -112,14 +154,16 @@ func (zm *ZeroManager) syncZeroServiceWithDgraphCluster(dc *v1alpha1.DgraphClust
 
 	// If the old service and new service spec is same don't change anything.
 	// else update the service spec as mentioned in the updated specification.
-	svc.Spec.ClusterIP = oldSVC.Spec.ClusterIP
+	preserveServiceAllocations(svc, oldSVC)
 
 	// We don't use DeepEquals here to not compare the default values that
 	// kubernetes might have introduced to the given type.
 	// TODO: Improve this.
-	if !apiequality.Semantic.DeepDerivative(svc.Spec, oldSVC.Spec) {
+	if !serviceSpecUpToDate(svc, oldSVC) ||
+		!metadataUpToDate(svc.ObjectMeta, oldSVC.ObjectMeta) {
 		updateSVC := *oldSVC
 		updateSVC.Spec = svc.Spec
+		mergeMetadata(&updateSVC.ObjectMeta, svc.ObjectMeta)
 		glog.Info("zero-manager: updating service for dgraph zero")
 		if _, err = k8s.UpdateService(zm.k8sClient, ns, &updateSVC); err != nil {
 			return err
@@ -142,13 +186,15 @@ func (zm *ZeroManager) syncZeroServiceWithDgraphCluster(dc *v1alpha1.DgraphClust
 	}
 
 	// If the old service and new service spec is same don't change anything.
-	if apiequality.Semantic.DeepDerivative(headlessSVC.Spec, oldHeadlessSVC.Spec) {
+	if apiequality.Semantic.DeepDerivative(headlessSVC.Spec, oldHeadlessSVC.Spec) &&
+		metadataUpToDate(headlessSVC.ObjectMeta, oldHeadlessSVC.ObjectMeta) {
 		glog.Info("zero-manager: no change found in dgraph zero headless service")
 		return nil
 	}
 
 	headlessSVCUpdate := *oldHeadlessSVC
 	headlessSVCUpdate.Spec = headlessSVC.Spec
+	mergeMetadata(&headlessSVCUpdate.ObjectMeta, headlessSVC.ObjectMeta)
 	glog.Info("zero-manager: updating headless service for dgraph zero")
 	_, err = k8s.UpdateService(zm.k8sClient, ns, &headlessSVCUpdate)
 
@@ -171,6 +217,25 @@ func (zm *ZeroManager) syncZeroStatefulSetWithDgraphCluster(dc *v1alpha1.DgraphC
 	if err != nil {
 		return err
 	}
+	if zeroStatefulSetOld.GetDeletionTimestamp() != nil {
+		glog.Info("zero-manager: waiting for the deletion of zero stateful set to recreate it")
+		return nil
+	}
+
+	if err := checkVolumeClaimTemplates(zeroStatefulSet, zeroStatefulSetOld); err != nil {
+		return err
+	}
+
+	expansions, recreated, err := zm.volumeExpander.sync(zeroStatefulSet, zeroStatefulSetOld)
+	dc.Status.ZeroCluster.VolumeExpansions = expansions
+	if err != nil || recreated {
+		return err
+	}
+
+	// Volume claim templates can't be updated, growing storage requests are applied by
+	// expanding the volumes and other changes only apply to the stateful sets created
+	// afterwards.
+	zeroStatefulSet.Spec.VolumeClaimTemplates = zeroStatefulSetOld.Spec.VolumeClaimTemplates
 
 	// If the old service and new service spec is same don't change anything.
 	if apiequality.Semantic.DeepDerivative(zeroStatefulSet.Spec, zeroStatefulSetOld.Spec) {
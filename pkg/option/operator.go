@@ -40,6 +40,10 @@ type operatorConfig struct {
 	// Workers count is the number of workers to run for the controller.
 	WorkersCount int
 
+	// ClusterDomain is the DNS domain of the kubernetes cluster, used to address the
+	// members of dgraph clusters which don't specify their own domain.
+	ClusterDomain string
+
 	// server represents configuration of the operator server.
 	Server *operatorServer
 }
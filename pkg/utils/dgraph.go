@@ -29,6 +29,21 @@ func DgraphAlphaMemberName(clusterID, clusterName string) string {
 		clusterID, defaults.K8SDelimeter, clusterName, defaults.K8SDelimeter, defaults.AlphaMemberSuffix)
 }
 
+// DgraphAlphaGroupMemberName is the name of alpha members of the group associated with
+// cluster provided.
+// The format is <clusterID>-<clusterName>-alpha-<groupID>
+func DgraphAlphaGroupMemberName(clusterID, clusterName string, groupID int32) string {
+	return fmt.Sprintf("%s%s%d",
+		DgraphAlphaMemberName(clusterID, clusterName), defaults.K8SDelimeter, groupID)
+}
+
+// DgraphPodServiceName is the name of the service exposing the dgraph member running in the
+// pod provided outside of the kubernetes cluster.
+// The format is <podName>-external
+func DgraphPodServiceName(podName string) string {
+	return fmt.Sprintf("%s%s%s", podName, defaults.K8SDelimeter, defaults.PodServiceSuffix)
+}
+
 // DgraphZeroMemberName is the name of Zero member associated with cluster provided.
 // The format is <clusterID>-<clusterName>-zero
 func DgraphZeroMemberName(clusterID, clusterName string) string {
@@ -42,3 +57,59 @@ func DgraphRatelMemberName(clusterID, clusterName string) string {
 	return fmt.Sprintf("%s%s%s%s%s",
 		clusterID, defaults.K8SDelimeter, clusterName, defaults.K8SDelimeter, defaults.RatelMemberSuffix)
 }
+
+// DgraphBulkLoadName is the name of bulk loader resources associated with cluster provided.
+// The format is <clusterID>-<clusterName>-bulk
+func DgraphBulkLoadName(clusterID, clusterName string) string {
+	return fmt.Sprintf("%s%s%s%s%s",
+		clusterID, defaults.K8SDelimeter, clusterName, defaults.K8SDelimeter,
+		defaults.BulkLoadMemberSuffix)
+}
+
+// DgraphBulkLoadCopyName is the name of the job copying bulk loader output to the alpha
+// member with the provided index.
+// The format is <clusterID>-<clusterName>-bulk-copy-<index>
+func DgraphBulkLoadCopyName(clusterID, clusterName string, index int32) string {
+	return fmt.Sprintf("%s%s%s%s%d",
+		DgraphBulkLoadName(clusterID, clusterName), defaults.K8SDelimeter,
+		defaults.BulkLoadCopySuffix, defaults.K8SDelimeter, index)
+}
+
+// StatefulSetPVCName is the name of the persistent volume claim kubernetes creates for the
+// pod with the provided ordinal using the volume claim template of a stateful set.
+// The format is <claimTemplateName>-<statefulSetName>-<ordinal>
+func StatefulSetPVCName(claimTemplateName, statefulSetName string, ordinal int32) string {
+	return fmt.Sprintf("%s%s%s%s%d",
+		claimTemplateName, defaults.K8SDelimeter, statefulSetName, defaults.K8SDelimeter, ordinal)
+}
+
+// DgraphStorageMigrationClaimName is the name of the temporary persistent volume claim
+// holding the data of the claim with the provided name while it's migrated to a new
+// storage class.
+// The format is <claimName>-migration
+func DgraphStorageMigrationClaimName(claimName string) string {
+	return fmt.Sprintf("%s%s%s", claimName, defaults.K8SDelimeter,
+		defaults.StorageMigrationSuffix)
+}
+
+// DgraphStorageMigrationJobName is the name of the job copying the data of the alpha member
+// with the provided pod name during a storage migration, step is one of copy and restore.
+// The format is <podName>-migration-<step>
+func DgraphStorageMigrationJobName(podName, step string) string {
+	return fmt.Sprintf("%s%s%s%s%s", podName, defaults.K8SDelimeter,
+		defaults.StorageMigrationSuffix, defaults.K8SDelimeter, step)
+}
+
+// DgraphLiveLoadName is the name of live loader resources associated with the DgraphLiveLoad
+// provided.
+// The format is <liveLoadName>-live
+func DgraphLiveLoadName(liveLoadName string) string {
+	return fmt.Sprintf("%s%s%s", liveLoadName, defaults.K8SDelimeter, defaults.LiveLoadMemberSuffix)
+}
+
+// DgraphConfigName is the name of the config map holding the config file of the dgraph
+// component with the provided member name.
+// The format is <memberName>-config
+func DgraphConfigName(memberName string) string {
+	return fmt.Sprintf("%s%s%s", memberName, defaults.K8SDelimeter, defaults.ConfigMemberSuffix)
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright 2019-2020 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+)
+
+// ServiceDNSName is the fully qualified DNS name of a host within the provided namespace,
+// where host is either a service name or <podName>.<headlessServiceName>.
+// The format is <host>.<namespace>.svc.<clusterDomain>
+func ServiceDNSName(host, namespace, clusterDomain string) string {
+	return fmt.Sprintf("%s.%s.svc.%s", host, namespace, clusterDomain)
+}
+
+// ServiceAddress is the address of the provided port of a host within the provided
+// namespace, see ServiceDNSName.
+// The format is <host>.<namespace>.svc.<clusterDomain>:<port>
+func ServiceAddress(host, namespace, clusterDomain string, port int32) string {
+	return fmt.Sprintf("%s:%d", ServiceDNSName(host, namespace, clusterDomain), port)
+}
+
+// PodHost is the host of the pod with the provided ordinal of a stateful set governed by
+// the provided headless service, to be used with ServiceDNSName.
+// The format is <statefulSetName>-<ordinal>.<headlessServiceName>
+func PodHost(statefulSetName, headlessServiceName string, ordinal string) string {
+	return fmt.Sprintf("%s-%s.%s", statefulSetName, ordinal, headlessServiceName)
+}